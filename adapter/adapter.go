@@ -0,0 +1,135 @@
+// Package adapter exposes a linear behind the method sets of hashicorp/golang-lru and dgraph-io/ristretto caches
+// The adapters match those interfaces structurally, so neither library is imported
+package adapter
+
+import (
+	"fmt"
+
+	"github.com/golang-common-packages/linear"
+)
+
+// key return the linear key of a cache key, strings are kept as is and other types are formatted
+func key(k interface{}) string {
+	switch k := k.(type) {
+	case string:
+		return k
+	case fmt.Stringer:
+		return k.String()
+	}
+	return fmt.Sprint(k)
+}
+
+// LRU implement the Add/Get/Contains/Peek/Remove/Keys/Len/Purge methods of golang-lru on top of a linear
+// Use linear.WithEvictionPolicy(linear.LRU()) to get least recently used eviction
+type LRU struct {
+	l *linear.Linear
+}
+
+// NewLRU return an LRU adapter over l
+func NewLRU(l *linear.Linear) *LRU {
+	return &LRU{l: l}
+}
+
+// Add add or replace the value of the key and report if it caused an eviction
+func (c *LRU) Add(k, value interface{}) bool {
+
+	evicted, _ := c.l.UpsertEvicted(key(k), value, linear.MoveToBack)
+	return evicted
+}
+
+// Get return the value of the key
+func (c *LRU) Get(k interface{}) (interface{}, bool) {
+
+	value, err := c.l.Read(key(k))
+	if err != nil {
+		return nil, false
+	}
+
+	return value, true
+}
+
+// Contains check if the key is in the cache
+func (c *LRU) Contains(k interface{}) bool {
+	_, exits := c.l.IsExits(key(k))
+	return exits
+}
+
+// Peek return the value of the key without updating its recency
+func (c *LRU) Peek(k interface{}) (interface{}, bool) {
+
+	value, err := c.l.Peek(key(k))
+	if err != nil {
+		return nil, false
+	}
+
+	return value, true
+}
+
+// Remove remove the key and report if it was present
+func (c *LRU) Remove(k interface{}) bool {
+	return c.l.Delete(key(k)) == nil
+}
+
+// Keys return the keys from the oldest to the newest
+func (c *LRU) Keys() []interface{} {
+
+	keys := c.l.Getkeys()
+	result := make([]interface{}, len(keys))
+	for i := range keys {
+		result[i] = keys[i]
+	}
+
+	return result
+}
+
+// Len return the number of keys
+func (c *LRU) Len() int {
+	return c.l.GetNumberOfKeys()
+}
+
+// Purge remove every key
+func (c *LRU) Purge() {
+	for _, k := range c.l.Getkeys() {
+		c.l.Delete(k)
+	}
+}
+
+// Ristretto implement the Set/Get/Del/Close methods of ristretto on top of a linear
+// The cost of Set is ignored, the linear accounts the size of the keys and values itself
+type Ristretto struct {
+	l *linear.Linear
+}
+
+// NewRistretto return a Ristretto adapter over l
+func NewRistretto(l *linear.Linear) *Ristretto {
+	return &Ristretto{l: l}
+}
+
+// Set add or replace the value of the key and report if it was stored
+func (c *Ristretto) Set(k, value interface{}, cost int64) bool {
+	return c.l.Upsert(key(k), value, linear.KeepPosition) == nil
+}
+
+// Get return the value of the key
+func (c *Ristretto) Get(k interface{}) (interface{}, bool) {
+
+	value, err := c.l.Read(key(k))
+	if err != nil {
+		return nil, false
+	}
+
+	return value, true
+}
+
+// Del remove the key
+func (c *Ristretto) Del(k interface{}) {
+	c.l.Delete(key(k))
+}
+
+// Wait return immediately, sets are applied synchronously
+func (c *Ristretto) Wait() {}
+
+// Close close the linear
+func (c *Ristretto) Close() {
+	c.l.Close()
+}
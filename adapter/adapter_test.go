@@ -0,0 +1,113 @@
+package adapter
+
+import (
+	"testing"
+
+	"github.com/golang-common-packages/linear"
+	"github.com/stretchr/testify/assert"
+)
+
+// lruCache is the method set shared by the golang-lru caches
+type lruCache interface {
+	Add(key, value interface{}) bool
+	Get(key interface{}) (interface{}, bool)
+	Contains(key interface{}) bool
+	Peek(key interface{}) (interface{}, bool)
+	Remove(key interface{}) bool
+	Keys() []interface{}
+	Len() int
+	Purge()
+}
+
+// ristrettoCache is the method set of the ristretto cache
+type ristrettoCache interface {
+	Set(key, value interface{}, cost int64) bool
+	Get(key interface{}) (interface{}, bool)
+	Del(key interface{})
+	Wait()
+	Close()
+}
+
+var (
+	_ lruCache       = (*LRU)(nil)
+	_ ristrettoCache = (*Ristretto)(nil)
+)
+
+func TestLRU(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	l, _ := linear.NewWithOptions(linear.WithMaxItems(2), linear.WithEvictionPolicy(linear.LRU()))
+	cache := NewLRU(l)
+
+	// Testing
+	assert.False(cache.Add("a", 1))
+	assert.False(cache.Add(2, "b"))
+	assert.True(cache.Contains("2"))
+
+	value, ok := cache.Get("a")
+	assert.True(ok)
+	assert.Equal(1, value)
+
+	if evicted := cache.Add("c", 3); !evicted {
+		t.Errorf("Add failed, expected %v, got %v", true, evicted)
+	}
+	assert.False(cache.Contains(2))
+	assert.Equal([]interface{}{"a", "c"}, cache.Keys())
+
+	assert.True(cache.Remove("a"))
+	assert.False(cache.Remove("a"))
+	assert.Equal(1, cache.Len())
+
+	cache.Purge()
+	assert.Equal(0, cache.Len())
+}
+
+func TestLRUPeek(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	l, _ := linear.NewWithOptions(linear.WithMaxItems(2), linear.WithEvictionPolicy(linear.LRU()))
+	cache := NewLRU(l)
+	cache.Add("a", 1)
+	cache.Add("b", 2)
+
+	// Testing
+	value, ok := cache.Peek("a")
+	assert.True(ok)
+	assert.Equal(1, value)
+
+	_, ok = cache.Peek("c")
+	assert.False(ok)
+
+	// Peek leaves "a" the least recently used
+	if evicted := cache.Add("c", 3); !evicted {
+		t.Errorf("Add failed, expected %v, got %v", true, evicted)
+	}
+	assert.False(cache.Contains("a"))
+	assert.True(cache.Contains("b"))
+}
+
+func TestRistretto(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	l, _ := linear.NewWithOptions()
+	cache := NewRistretto(l)
+
+	// Testing
+	assert.True(cache.Set("a", 1, 1))
+	assert.True(cache.Set("a", 2, 1))
+	cache.Wait()
+
+	value, ok := cache.Get("a")
+	assert.True(ok)
+	assert.Equal(2, value)
+
+	cache.Del("a")
+	_, ok = cache.Get("a")
+	assert.False(ok)
+
+	cache.Close()
+	assert.False(cache.Set("b", 1, 1))
+}
@@ -0,0 +1,106 @@
+package linear
+
+import (
+	"errors"
+	"sync/atomic"
+	"time"
+)
+
+// WithAggregation merge the values given to Aggregate for the same key during window with merge before they're pushed
+// The window of a key opens on its first Aggregate call, merge runs under the linear lock when the window ends so it
+// must not use the linear
+func WithAggregation(window time.Duration, merge func(key string, current, next interface{}) interface{}) Option {
+	return func(l *Linear) {
+		l.aggregateWindow = window
+		l.aggregateMerge = merge
+	}
+}
+
+// Aggregate merge the item into the window opened for its key, the merged value is pushed once the window ends
+// When the key is still in the linear at that time, its value is merged with the pushed one
+func (l *Linear) Aggregate(key string, value interface{}) error {
+
+	// Execution conditions
+	if l.IsClosed() {
+		return ErrClosed
+	}
+
+	if l.aggregateMerge == nil {
+		return ErrInvalidArgument
+	}
+
+	// Argument validator
+	if key == "" && value == nil {
+		return ErrInvalidKey
+	}
+
+	l.pendingMux.Lock()
+	defer l.pendingMux.Unlock()
+
+	if l.aggregated == nil {
+		l.aggregated = map[string]*pendingPush{}
+	}
+
+	if p, ok := l.aggregated[key]; ok {
+		p.value = l.aggregateMerge(key, p.value, value)
+		return nil
+	}
+
+	p := &pendingPush{value: value}
+	l.aggregated[key] = p
+	p.timer = time.AfterFunc(l.aggregateWindow, func() {
+		l.pendingMux.Lock()
+		if l.aggregated[key] != p {
+			l.pendingMux.Unlock()
+			return
+		}
+		delete(l.aggregated, key)
+		value := p.value
+		l.pendingMux.Unlock()
+
+		if err := l.deliverAggregate(key, value); err != nil && !errors.Is(err, ErrClosed) {
+			l.logger.Printf("linear: aggregated push of %q failed: %v", key, err)
+		}
+	})
+
+	return nil
+}
+
+// deliverAggregate push the merged value of a window, merging it into the value still in the linear if any
+// The lookup, the merge and the write happen under a single lock, without counting the lookup as a read
+func (l *Linear) deliverAggregate(key string, value interface{}) error {
+
+	// Execution conditions
+	if l.IsClosed() {
+		return ErrClosed
+	}
+
+	ctx, cancel := l.blockContext()
+	defer cancel()
+	return l.withRoom(ctx, lockPush, func() error {
+		if current, exits := l.items.Load(key); exits {
+			merged := l.aggregateMerge(key, l.resolve(current), value)
+			if l.clone != nil {
+				merged = l.clone(merged)
+			}
+
+			valueSize := l.valueSize(key, merged)
+			if calculateKeySize(key)+valueSize > l.linearSizes {
+				return newError("update", key, ErrCapacityExceeded)
+			}
+
+			if err := l.update(key, merged, valueSize); err != nil {
+				return err
+			}
+			atomic.AddInt64(&l.stats.updates, 1)
+			return nil
+		}
+
+		pushed := value
+		if l.clone != nil {
+			pushed = l.clone(pushed)
+		}
+
+		return l.push(key, pushed, l.valueSize(key, pushed))
+	})
+}
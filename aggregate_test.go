@@ -0,0 +1,86 @@
+package linear
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAggregate(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	sum := func(key string, current, next interface{}) interface{} {
+		return current.(int) + next.(int)
+	}
+	linearClient, err := NewWithOptions(WithAggregation(20*time.Millisecond, sum))
+	assert.Nil(err)
+	defer linearClient.Close()
+
+	// Testing
+	for i := 1; i <= 4; i++ {
+		assert.Nil(linearClient.Aggregate("counter", i))
+	}
+	assert.Equal(0, linearClient.GetNumberOfKeys())
+
+	assert.Eventually(func() bool { return linearClient.GetNumberOfKeys() == 1 }, time.Second, time.Millisecond)
+	value, _ := linearClient.Read("counter")
+	if value != 10 {
+		t.Errorf("Aggregate failed, expected %v, got %v", 10, value)
+	}
+
+	assert.Nil(linearClient.Aggregate("counter", 5))
+	assert.Eventually(func() bool {
+		value, _ := linearClient.Read("counter")
+		return value == 15
+	}, time.Second, time.Millisecond)
+	assert.Equal(1, linearClient.GetNumberOfKeys())
+}
+
+func TestDeliverAggregateConcurrent(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	sum := func(key string, current, next interface{}) interface{} {
+		return current.(int) + next.(int)
+	}
+	linearClient, _ := NewWithOptions(WithAggregation(time.Second, sum))
+	defer linearClient.Close()
+	linearClient.Push("counter", 0)
+
+	// Testing
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.Nil(linearClient.deliverAggregate("counter", 1))
+		}()
+	}
+	wg.Wait()
+
+	stats := linearClient.Stats()
+	assert.Equal(int64(0), stats.Hits+stats.Misses)
+
+	value, _ := linearClient.Read("counter")
+	if value != 50 {
+		t.Errorf("deliverAggregate failed, expected %v, got %v", 50, value)
+	}
+	assert.Equal(1, linearClient.GetNumberOfKeys())
+}
+
+func TestAggregateWithoutOption(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	linearClient := New(1024, false)
+
+	// Testing
+	assert.True(errors.Is(linearClient.Aggregate("1", 1), ErrInvalidArgument))
+
+	_, err := NewWithOptions(WithAggregation(time.Second, nil))
+	assert.True(errors.Is(err, ErrInvalidArgument))
+}
@@ -0,0 +1,72 @@
+package linear
+
+import (
+	"sync/atomic"
+)
+
+// Alias push newKey to the linear pointing at the value of existingKey
+// Keys sharing a value only account its size once, it is released when the last of them is removed
+func (l *Linear) Alias(newKey, existingKey string) error {
+
+	// Execution conditions
+	if l.IsClosed() {
+		return ErrClosed
+	}
+
+	// Argument validator
+	if newKey == "" || existingKey == "" {
+		return ErrInvalidKey
+	}
+
+	l.mux.Lock()
+	defer l.mux.Unlock()
+
+	return l.alias(newKey, existingKey)
+}
+
+// alias push newKey pointing at the value of existingKey, caller must hold mux
+func (l *Linear) alias(newKey, existingKey string) error {
+
+	if _, exits := l.items.Load(newKey); exits {
+		return newError("alias", newKey, ErrKeyExists)
+	}
+
+	itemSize := calculateKeySize(newKey)
+	if itemSize > l.linearSizes {
+		return newError("alias", newKey, ErrCapacityExceeded)
+	}
+
+	// Clean space for new item
+	if err := l.makeRoom("alias", newKey, itemSize, false); err != nil {
+		return err
+	}
+
+	// Checked after making room, which may evict the existing key
+	value, exits := l.items.Load(existingKey)
+	if !exits {
+		return newError("alias", existingKey, ErrKeyNotFound)
+	}
+
+	group, ok := l.shared[existingKey]
+	if !ok {
+		group = new(int)
+		*group = 1
+		l.shared[existingKey] = group
+	}
+	*group++
+	l.shared[newKey] = group
+	l.items.Store(newKey, value)
+	l.valueSizes[newKey] = l.valueSizes[existingKey]
+	l.debugTrack(newKey, value)
+	l.checksumTrack(newKey, value)
+	l.linearCurrentSize += itemSize
+	l.nodePushed(l.keys.pushBack(newKey))
+	l.evictionPushed(newKey)
+	l.publishCounters()
+	l.notifyPushed()
+	l.emit(Pushed, newKey, value)
+	l.logRecord(walRecord{Op: walAlias, Key: newKey, Target: existingKey})
+	atomic.AddInt64(&l.stats.pushes, 1)
+
+	return nil
+}
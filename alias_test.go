@@ -0,0 +1,42 @@
+package linear
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAlias(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	linearClient := New(1024, false)
+	linearClient.Push("1", "a")
+	sizeBefore := linearClient.GetLinearCurrentSize()
+
+	// Testing
+	err := linearClient.Alias("2", "1")
+	if err != nil {
+		t.Errorf("Alias failed, expected %v, got %v", nil, err)
+	}
+
+	value, err := linearClient.Read("2")
+	if err != nil {
+		t.Errorf("Alias failed, expected %v, got %v", "a", err)
+	}
+
+	assert.Equal(value, "a")
+	assert.Equal(linearClient.GetNumberOfKeys(), 2)
+	assert.Equal(linearClient.GetLinearCurrentSize()-sizeBefore, sizeBefore/2)
+
+	assert.NotNil(linearClient.Alias("2", "1"))
+	assert.NotNil(linearClient.Alias("3", "4"))
+
+	// Removing the original key keeps the value alive through the alias
+	linearClient.Take()
+	value, _ = linearClient.Read("2")
+	assert.Equal(value, "a")
+
+	linearClient.Take()
+	assert.Equal(linearClient.GetLinearCurrentSize(), int64(0))
+}
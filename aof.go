@@ -0,0 +1,314 @@
+package linear
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"time"
+)
+
+// walOp is the kind of change an append log record holds
+type walOp byte
+
+const (
+	walPush walOp = iota + 1
+	walRemove
+	walUpdate
+	walMove
+	walAlias
+	walRefs
+	walClear
+	walExpire
+)
+
+// walRecord is one change of the linear in the append log
+type walRecord struct {
+	Seq    uint64
+	Op     walOp
+	Key    string
+	Value  interface{}
+	Target string // Existing key of an alias
+	Last   bool   // A remove of the back-most occurrence of the key, written by older logs instead of Occurrence
+	Front  bool   // A push or move to the front instead of the back
+	Refs   int
+
+	Occurrence int // Position of the removed occurrence among the occurrences of the key, from the front
+
+	Deadline int64         // Wall-clock deadline of an expiry in Unix nanoseconds
+	TTL      time.Duration // TTL of an expiry, restored by sliding expiration
+
+	Encoded []byte // Value encoded by the WithCodec codec instead of gob
+}
+
+// walHeaderSize is the length and checksum written before every record
+const walHeaderSize = 8
+
+// WithAppendLog write every change of the linear as a record to the append log at path and replay it on startup
+// Every compactInterval, and on Close, the log is compacted into the snapshot file path + ".snapshot", 0 only compacts on Close
+// Records are written without fsync, so they survive a crash of the process but not of the machine.
+// Values are gob encoded, so concrete types stored behind interface{} must be registered with gob.Register, unless WithCodec is used
+// A record that fails to encode is logged and left out, replay then skips the later records of the key it misses
+func WithAppendLog(path string, compactInterval time.Duration) Option {
+	return func(l *Linear) {
+		l.walPath = path
+		l.walCompactInterval = compactInterval
+	}
+}
+
+// openLog load the append log snapshot, replay the records written after it and open the log for writing
+func (l *Linear) openLog() error {
+
+	snapshot, err := os.Open(l.walPath + ".snapshot")
+	if err == nil {
+		err = l.Restore(snapshot)
+		snapshot.Close()
+	}
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	wal, err := os.OpenFile(l.walPath, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0o600)
+	if err != nil {
+		return err
+	}
+
+	info, err := wal.Stat()
+	if err != nil {
+		wal.Close()
+		return err
+	}
+
+	l.mux.Lock()
+	defer l.mux.Unlock()
+
+	valid, err := l.replay(wal, info.Size())
+	if err != nil {
+		wal.Close()
+		return err
+	}
+
+	// Drop a record torn by a crash
+	if err := wal.Truncate(valid); err != nil {
+		wal.Close()
+		return err
+	}
+
+	l.wal = wal
+
+	return nil
+}
+
+// replay apply the records of r, size bytes long, newer than walSeq and return the length of the valid records
+// Caller must hold mux
+func (l *Linear) replay(r io.Reader, size int64) (int64, error) {
+
+	var valid int64
+	header := make([]byte, walHeaderSize)
+	for {
+		if _, err := io.ReadFull(r, header); err != nil {
+			return valid, nil
+		}
+
+		// A torn or corrupted header can't claim more than the rest of the log
+		length := binary.BigEndian.Uint32(header)
+		if int64(length) > size-valid-walHeaderSize {
+			return valid, nil
+		}
+
+		payload := make([]byte, length)
+		if _, err := io.ReadFull(r, payload); err != nil {
+			return valid, nil
+		}
+
+		if crc32.ChecksumIEEE(payload) != binary.BigEndian.Uint32(header[4:]) {
+			return valid, nil
+		}
+
+		var record walRecord
+		if err := gob.NewDecoder(bytes.NewReader(payload)).Decode(&record); err != nil {
+			return valid, nil
+		}
+		valid += walHeaderSize + int64(length)
+
+		// Already in the snapshot
+		if record.Seq <= l.walSeq {
+			continue
+		}
+
+		if err := l.apply(&record); err != nil {
+			return valid, err
+		}
+		l.walSeq = record.Seq
+	}
+}
+
+// apply redo the change of record, caller must hold mux
+func (l *Linear) apply(record *walRecord) error {
+
+	if record.Encoded != nil {
+		if !l.hasCodec() {
+			return newError("replay", record.Key, fmt.Errorf("%w: value encoded by a codec, use WithCodec", ErrCorrupted))
+		}
+
+		value, err := l.decodeValue(record.Key, record.Encoded)
+		if err != nil {
+			return err
+		}
+		record.Value = value
+	}
+
+	switch record.Op {
+	case walPush:
+		return l.pushEnd(record.Key, record.Value, l.valueSize(record.Key, record.Value), record.Front)
+	case walUpdate:
+		if _, exits := l.items.Load(record.Key); !exits {
+			return nil // The push of the key failed to encode
+		}
+		return l.update(record.Key, record.Value, l.valueSize(record.Key, record.Value))
+	case walAlias:
+		if _, exits := l.items.Load(record.Target); !exits {
+			return nil
+		}
+		return l.alias(record.Key, record.Target)
+	case walRefs:
+		if _, exits := l.items.Load(record.Key); exits {
+			l.refs[record.Key] = record.Refs
+		}
+		return nil
+	case walClear:
+		l.clear()
+		return nil
+	case walExpire:
+		if l.expiryClock == WallClock && l.keys.contains(record.Key) {
+			l.expireAt(record.Key, time.Unix(0, record.Deadline), record.TTL)
+		}
+		return nil
+	}
+
+	occurrences := l.keys.index[record.Key]
+	if len(occurrences) == 0 {
+		if record.Op == walRemove || record.Op == walMove {
+			return nil // The push of the key failed to encode
+		}
+		return newError("replay", record.Key, ErrCorrupted)
+	}
+
+	switch record.Op {
+	case walRemove:
+		if record.Occurrence < 0 {
+			return newError("replay", record.Key, ErrCorrupted)
+		}
+		if record.Occurrence >= len(occurrences) {
+			return nil // A push of the occurrence failed to encode
+		}
+		n := occurrences[record.Occurrence]
+		if record.Last {
+			n = occurrences[len(occurrences)-1]
+		}
+		item, _ := l.items.Load(record.Key)
+		l.removeNode(n, item)
+	case walMove:
+		if record.Front {
+			l.keys.moveToFront(occurrences[0])
+		} else {
+			l.keys.moveToBack(occurrences[0])
+		}
+	default:
+		return newError("replay", record.Key, ErrCorrupted)
+	}
+
+	return nil
+}
+
+// logRecord append record to the log, caller must hold mux
+func (l *Linear) logRecord(record walRecord) {
+
+	if l.wal == nil {
+		return
+	}
+
+	if l.hasCodec() && record.Value != nil {
+		encoded, err := l.encodeValue(record.Key, record.Value)
+		if err != nil {
+			l.logger.Printf("linear: encoding the append log record of %q failed: %v", record.Key, err)
+			return
+		}
+		record.Value, record.Encoded = nil, encoded
+	}
+
+	l.walSeq++
+	record.Seq = l.walSeq
+
+	var payload bytes.Buffer
+	payload.Write(make([]byte, walHeaderSize))
+	if err := gob.NewEncoder(&payload).Encode(&record); err != nil {
+		l.logger.Printf("linear: encoding the append log record of %q failed: %v", record.Key, err)
+		return
+	}
+
+	buf := payload.Bytes()
+	binary.BigEndian.PutUint32(buf, uint32(len(buf)-walHeaderSize))
+	binary.BigEndian.PutUint32(buf[4:], crc32.ChecksumIEEE(buf[walHeaderSize:]))
+	if _, err := l.wal.Write(buf); err != nil {
+		l.logger.Printf("linear: writing the append log failed: %v", err)
+	}
+}
+
+// compactLog write the current state to the append log snapshot and empty the log, caller must hold mux
+func (l *Linear) compactLog() error {
+
+	if l.wal == nil {
+		return nil
+	}
+
+	err := writeFileAtomic(l.walPath+".snapshot", func(w io.Writer) error {
+		return encodeSnapshot(w, l.captureState(), l.snapshotEncoder())
+	})
+	if err != nil {
+		return err
+	}
+
+	return l.wal.Truncate(0)
+}
+
+// startCompaction compact the append log every walCompactInterval in the background
+func (l *Linear) startCompaction() {
+	l.startWorker(func(done <-chan struct{}) {
+		ticker := time.NewTicker(l.walCompactInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				l.mux.Lock()
+				err := l.compactLog()
+				l.mux.Unlock()
+				if err != nil {
+					l.logger.Printf("linear: compacting %s failed: %v", l.walPath, err)
+				}
+			}
+		}
+	})
+}
+
+// closeLog compact the append log a last time and close it
+func (l *Linear) closeLog() error {
+
+	l.mux.Lock()
+	defer l.mux.Unlock()
+
+	err := l.compactLog()
+	if closeErr := l.wal.Close(); err == nil {
+		err = closeErr
+	}
+	l.wal = nil
+
+	return err
+}
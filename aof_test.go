@@ -0,0 +1,136 @@
+package linear
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithAppendLog(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	path := filepath.Join(t.TempDir(), "linear.wal")
+	linearClient, err := NewWithOptions(WithAppendLog(path, 0))
+	assert.Nil(err)
+
+	linearClient.Push("1", "a")
+	linearClient.Push("2", "b")
+	linearClient.Push("3", "c")
+	linearClient.Push("1", "x")
+	linearClient.Pop()
+	linearClient.Take()
+	linearClient.Update("2", "B")
+	linearClient.Alias("4", "2")
+	linearClient.Upsert("3", "c", MoveToFront)
+	linearClient.PushFront("5", "e")
+	linearClient.PushContent("content")
+	linearClient.PushContent("content")
+
+	expectedKeys := linearClient.Getkeys()
+	expectedItems := linearClient.GetItemsMap()
+	expectedSize := linearClient.GetLinearCurrentSize()
+
+	// Testing
+	// Crash without Close, with a torn record at the end of the log
+	f, _ := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0)
+	f.Write([]byte{0, 0, 0, 9, 1, 2})
+	f.Close()
+
+	recovered, err := NewWithOptions(WithAppendLog(path, 0))
+	assert.Nil(err)
+	assert.Equal(expectedKeys, recovered.Getkeys())
+	assert.Equal(expectedItems, recovered.GetItemsMap())
+	assert.Equal(expectedSize, recovered.GetLinearCurrentSize())
+
+	contentKey := expectedKeys[len(expectedKeys)-1]
+	assert.Equal(2, recovered.GetContentRefs(contentKey))
+
+	// Crash after writing the compacted snapshot but before emptying the log
+	log, _ := os.ReadFile(path)
+	assert.Nil(recovered.Close())
+	info, _ := os.Stat(path)
+	assert.Equal(int64(0), info.Size())
+	assert.Nil(os.WriteFile(path, log, 0o600))
+
+	reopened, err := NewWithOptions(WithAppendLog(path, 0))
+	assert.Nil(err)
+	assert.Equal(expectedKeys, reopened.Getkeys())
+	assert.Equal(expectedSize, reopened.GetLinearCurrentSize())
+	assert.Nil(reopened.CheckSize())
+	assert.Nil(reopened.Close())
+}
+
+// unregistered is a value type gob can't encode behind interface{}
+type unregistered struct {
+	A int
+}
+
+func TestAppendLogSkippedRecords(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	path := filepath.Join(t.TempDir(), "linear.wal")
+	linearClient, err := NewWithOptions(WithAppendLog(path, 0))
+	assert.Nil(err)
+
+	linearClient.Push("1", "a")
+	assert.Nil(linearClient.Push("2", unregistered{A: 1}))
+	linearClient.Update("2", "b")
+	linearClient.Push("3", "c")
+	linearClient.Delete("2")
+
+	// Testing
+	// Crash without Close
+	recovered, err := NewWithOptions(WithAppendLog(path, 0))
+	assert.Nil(err)
+	assert.Equal([]string{"1", "3"}, recovered.Getkeys())
+	assert.Nil(recovered.CheckSize())
+}
+
+func TestAppendLogCorruptedLength(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	path := filepath.Join(t.TempDir(), "linear.wal")
+	linearClient, err := NewWithOptions(WithAppendLog(path, 0))
+	assert.Nil(err)
+	linearClient.Push("1", "a")
+
+	// Testing
+	// A header claiming 4 GiB after the last record
+	f, _ := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0)
+	f.Write([]byte{0xff, 0xff, 0xff, 0xff, 1, 2, 3, 4})
+	f.Close()
+
+	recovered, err := NewWithOptions(WithAppendLog(path, 0))
+	assert.Nil(err)
+	assert.Equal([]string{"1"}, recovered.Getkeys())
+}
+
+func TestAppendLogMiddleOccurrence(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	path := filepath.Join(t.TempDir(), "linear.wal")
+	linearClient, err := NewWithOptions(WithAppendLog(path, 0), WithPriority())
+	assert.Nil(err)
+
+	linearClient.PushWithPriority("a", "x", 1)
+	linearClient.PushWithPriority("b", "y", 1)
+	linearClient.PushWithPriority("a", "x", 9)
+	linearClient.PushWithPriority("c", "z", 1)
+	linearClient.PushWithPriority("a", "x", 1)
+
+	// Testing
+	// The highest priority is the middle occurrence of "a"
+	item, _ := linearClient.Take()
+	assert.Equal("x", item)
+	assert.Equal([]string{"a", "b", "c", "a"}, linearClient.Getkeys())
+
+	recovered, err := NewWithOptions(WithAppendLog(path, 0))
+	assert.Nil(err)
+	assert.Equal([]string{"a", "b", "c", "a"}, recovered.Getkeys())
+}
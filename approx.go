@@ -0,0 +1,30 @@
+package linear
+
+import (
+	"sync/atomic"
+)
+
+// ApproxLen return the number of keys without taking the lock, it may lag behind concurrent operations
+func (l *Linear) ApproxLen() int {
+	return int(atomic.LoadInt64(&l.approxLen))
+}
+
+// ApproxSize return the current linear size without taking the lock, it may lag behind concurrent operations
+func (l *Linear) ApproxSize() int64 {
+	return atomic.LoadInt64(&l.approxSize)
+}
+
+// publishCounters copy the number of keys and current size for the lock-free readers, caller must hold mux
+func (l *Linear) publishCounters() {
+	atomic.StoreInt64(&l.approxLen, int64(l.keys.len))
+	atomic.StoreInt64(&l.approxSize, l.linearCurrentSize)
+
+	// Writers hold mux, so the peaks can't move under us
+	if l.linearCurrentSize > atomic.LoadInt64(&l.stats.peakBytes) {
+		atomic.StoreInt64(&l.stats.peakBytes, l.linearCurrentSize)
+	}
+
+	if int64(l.keys.len) > atomic.LoadInt64(&l.stats.peakItems) {
+		atomic.StoreInt64(&l.stats.peakItems, int64(l.keys.len))
+	}
+}
@@ -0,0 +1,22 @@
+package linear
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApprox(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	linearClient := New(1024, false)
+	linearClient.Push("1", "a")
+	linearClient.Push("2", "b")
+	linearClient.Alias("3", "1")
+	linearClient.Take()
+
+	// Testing
+	assert.Equal(linearClient.ApproxLen(), linearClient.GetNumberOfKeys())
+	assert.Equal(linearClient.ApproxSize(), linearClient.GetLinearCurrentSize())
+}
@@ -0,0 +1,155 @@
+package linear
+
+import (
+	"sort"
+)
+
+// PushAll push the items to the linear under a single lock, in ascending key order since maps are unordered
+// It returns the error of every key that could not be pushed, nil when all of them were
+func (l *Linear) PushAll(items map[string]interface{}) map[string]error {
+
+	// Execution conditions
+	if l.IsClosed() {
+		errs := make(map[string]error, len(items))
+		for key := range items {
+			errs[key] = ErrClosed
+		}
+		return errs
+	}
+
+	keys := make([]string, 0, len(items))
+	for key := range items {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return l.pushKeys(keys, items, nil)
+}
+
+// pushKeys push the items of keys in order under a single lock and add the errors to errs
+func (l *Linear) pushKeys(keys []string, items map[string]interface{}, errs map[string]error) map[string]error {
+
+	values := make(map[string]interface{}, len(keys))
+	valueSizes := make(map[string]int64, len(keys))
+	for _, key := range keys {
+		value := items[key]
+		if l.clone != nil {
+			value = l.clone(value)
+		}
+
+		values[key] = value
+		valueSizes[key] = l.valueSize(key, value)
+	}
+
+	l.mux.Lock()
+	for _, key := range keys {
+		err := ErrInvalidKey
+		if key != "" || values[key] != nil {
+			err = l.push(key, values[key], valueSizes[key])
+		}
+
+		if err != nil {
+			if errs == nil {
+				errs = map[string]error{}
+			}
+			errs[key] = err
+		}
+	}
+	l.mux.Unlock()
+
+	return errs
+}
+
+// PopN return and remove up to n items from the back of the linear under a single lock, last item first
+func (l *Linear) PopN(n int) ([]interface{}, error) {
+	return l.removeN(n, true)
+}
+
+// TakeN return and remove up to n items from the front of the linear under a single lock, first item first
+func (l *Linear) TakeN(n int) ([]interface{}, error) {
+	return l.removeN(n, false)
+}
+
+// removeN remove up to n items from the back or the front of the linear
+func (l *Linear) removeN(n int, back bool) ([]interface{}, error) {
+
+	// Execution conditions
+	if l.IsClosed() {
+		return nil, ErrClosed
+	}
+
+	// Argument validator
+	if n <= 0 {
+		return nil, ErrInvalidArgument
+	}
+
+	l.mux.Lock()
+	defer l.mux.Unlock()
+
+	// Execution conditions
+	if l.keys.len == 0 {
+		return nil, ErrEmpty
+	}
+
+	if n > l.keys.len {
+		n = l.keys.len
+	}
+
+	items := make([]interface{}, 0, n)
+	for i := 0; i < n; i++ {
+		end := l.frontNode()
+		if back {
+			end = l.backNode()
+		}
+
+		key := end.key
+		item, _ := l.items.Load(key)
+		l.removeNode(end, item)
+		item = l.resolve(item)
+		if back {
+			l.emit(Popped, key, item)
+		} else {
+			l.emit(Taken, key, item)
+		}
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
+// ReadMany return the items by keys without remove them under a single lock
+// It returns the error of every key that could not be read, nil when all of them were
+func (l *Linear) ReadMany(keys []string) (map[string]interface{}, map[string]error) {
+
+	// Execution conditions
+	if l.IsClosed() {
+		errs := make(map[string]error, len(keys))
+		for _, key := range keys {
+			errs[key] = ErrClosed
+		}
+		return nil, errs
+	}
+
+	items := make(map[string]interface{}, len(keys))
+	var errs map[string]error
+
+	l.mux.RLock()
+	for _, key := range keys {
+		item, ok := l.items.Load(key)
+		l.countLookup(ok)
+		if ok {
+			l.debugCheck(key, item)
+			l.checksumCheck(key, item)
+			items[key] = l.resolve(item)
+			continue
+		}
+
+		if errs == nil {
+			errs = map[string]error{}
+		}
+		errs[key] = newError("read", key, ErrKeyNotFound)
+	}
+	l.mux.RUnlock()
+
+	return items, errs
+}
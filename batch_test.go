@@ -0,0 +1,63 @@
+package linear
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPushAll(t *testing.T) {
+	assert := assert.New(t)
+
+	linearClient, _ := NewWithOptions(WithMaxItems(3), WithFullPolicy(Reject))
+
+	// Testing
+	errs := linearClient.PushAll(map[string]interface{}{"2": "b", "1": "a", "3": "c", "4": "d"})
+	assert.Equal(len(errs), 1)
+	assert.True(errors.Is(errs["4"], ErrFull))
+
+	assert.Equal(linearClient.Getkeys(), []string{"1", "2", "3"})
+	assert.Equal(linearClient.Stats().Pushes, int64(3))
+	assert.Nil(linearClient.CheckSize())
+}
+
+func TestPopNTakeN(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	linearClient := New(1024, false)
+	linearClient.PushAll(map[string]interface{}{"1": "a", "2": "b", "3": "c", "4": "d", "5": "e"})
+
+	// Testing
+	items, err := linearClient.PopN(2)
+	assert.Nil(err)
+	assert.Equal(items, []interface{}{"e", "d"})
+
+	items, err = linearClient.TakeN(5)
+	assert.Nil(err)
+	assert.Equal(items, []interface{}{"a", "b", "c"})
+
+	_, err = linearClient.TakeN(1)
+	assert.True(errors.Is(err, ErrEmpty))
+
+	_, err = linearClient.PopN(0)
+	assert.True(errors.Is(err, ErrInvalidArgument))
+	assert.Equal(linearClient.GetLinearCurrentSize(), int64(0))
+}
+
+func TestReadMany(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	linearClient := New(1024, false)
+	linearClient.PushAll(map[string]interface{}{"1": "a", "2": "b"})
+
+	// Testing
+	items, errs := linearClient.ReadMany([]string{"1", "2", "3"})
+	assert.Equal(items, map[string]interface{}{"1": "a", "2": "b"})
+	assert.Equal(len(errs), 1)
+	assert.True(errors.Is(errs["3"], ErrKeyNotFound))
+
+	assert.Equal(linearClient.GetNumberOfKeys(), 2)
+}
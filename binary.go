@@ -0,0 +1,194 @@
+package linear
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+)
+
+// binaryMagic start every binary encoding, followed by the format version
+var binaryMagic = []byte("LINBIN")
+
+// binaryVersion is the binary format written
+const binaryVersion = 1
+
+// MarshalBinary encode the linear size, the size checker and the items from front to back
+// Keys are length prefixed, values are encoded once per key by the WithCodec codec and the encoding ends with a checksum
+func (l *Linear) MarshalBinary() ([]byte, error) {
+
+	// Execution conditions
+	if l.IsClosed() {
+		return nil, ErrClosed
+	}
+
+	var buf bytes.Buffer
+	buf.Write(binaryMagic)
+	buf.WriteByte(binaryVersion)
+
+	varint := make([]byte, binary.MaxVarintLen64)
+	writeUvarint := func(v uint64) {
+		buf.Write(varint[:binary.PutUvarint(varint, v)])
+	}
+
+	l.mux.RLock()
+	defer l.mux.RUnlock()
+
+	writeUvarint(uint64(l.linearSizes))
+	if l.sizeChecker {
+		buf.WriteByte(1)
+	} else {
+		buf.WriteByte(0)
+	}
+	writeUvarint(uint64(l.keys.len))
+
+	written := make(map[string]bool, len(l.keys.index))
+	for n := l.keys.head; n != nil; n = n.next {
+		writeUvarint(uint64(len(n.key)))
+		buf.WriteString(n.key)
+
+		// Later occurrences share the value of the first one
+		if written[n.key] {
+			buf.WriteByte(0)
+			continue
+		}
+		written[n.key] = true
+
+		item, _ := l.items.Load(n.key)
+		if item == nil {
+			buf.WriteByte(2) // Codecs such as gob don't encode nil interfaces
+			continue
+		}
+		buf.WriteByte(1)
+
+		value, err := l.encodeValue(n.key, item)
+		if err != nil {
+			return nil, err
+		}
+		writeUvarint(uint64(len(value)))
+		buf.Write(value)
+	}
+
+	checksum := make([]byte, 4)
+	binary.BigEndian.PutUint32(checksum, crc32.ChecksumIEEE(buf.Bytes()))
+	buf.Write(checksum)
+
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary replace the content of the linear with the encoded items and apply the encoded linear size and size checker
+// The linear must come from a constructor, a corrupted encoding leaves it unchanged
+func (l *Linear) UnmarshalBinary(data []byte) error {
+
+	// Execution conditions
+	if l.mux == nil {
+		return ErrInvalidArgument
+	}
+
+	if l.IsClosed() {
+		return ErrClosed
+	}
+
+	header := len(binaryMagic) + 1
+	if len(data) < header+4 || !bytes.Equal(data[:len(binaryMagic)], binaryMagic) {
+		return fmt.Errorf("%w: not a binary encoding", ErrCorrupted)
+	}
+
+	if data[len(binaryMagic)] != binaryVersion {
+		return fmt.Errorf("%w: binary version %d", ErrUnsupportedVersion, data[len(binaryMagic)])
+	}
+
+	body := data[:len(data)-4]
+	if crc32.ChecksumIEEE(body) != binary.BigEndian.Uint32(data[len(data)-4:]) {
+		return fmt.Errorf("%w: checksum mismatch", ErrCorrupted)
+	}
+
+	r := bytes.NewReader(body[header:])
+	truncated := fmt.Errorf("%w: truncated binary encoding", ErrCorrupted)
+	readBytes := func() ([]byte, error) {
+		length, err := binary.ReadUvarint(r)
+		if err != nil || length > uint64(r.Len()) {
+			return nil, truncated
+		}
+		b := make([]byte, length)
+		r.Read(b)
+		return b, nil
+	}
+
+	linearSizes, err := binary.ReadUvarint(r)
+	if err != nil {
+		return truncated
+	}
+	sizeChecker, err := r.ReadByte()
+	if err != nil {
+		return truncated
+	}
+	count, err := binary.ReadUvarint(r)
+	if err != nil || count > uint64(r.Len()) {
+		return truncated
+	}
+
+	// Argument validator
+	if int64(linearSizes) <= 0 {
+		return ErrInvalidSize
+	}
+
+	state := snapshotState{
+		Keys:   make([]string, 0, count),
+		Values: map[string]interface{}{},
+	}
+	for i := uint64(0); i < count; i++ {
+		key, err := readBytes()
+		if err != nil {
+			return err
+		}
+		state.Keys = append(state.Keys, string(key))
+
+		flag, err := r.ReadByte()
+		if err != nil {
+			return truncated
+		}
+		if flag == 0 {
+			continue
+		}
+
+		if _, ok := state.Values[string(key)]; ok || flag > 2 {
+			return fmt.Errorf("%w: unexpected value of %q", ErrCorrupted, key)
+		}
+		if flag == 2 {
+			state.Values[string(key)] = nil
+			continue
+		}
+
+		encoded, err := readBytes()
+		if err != nil {
+			return err
+		}
+		if state.Values[string(key)], err = l.decodeValue(string(key), encoded); err != nil {
+			return err
+		}
+	}
+
+	if r.Len() != 0 {
+		return fmt.Errorf("%w: trailing bytes", ErrCorrupted)
+	}
+
+	for _, key := range state.Keys {
+		if _, ok := state.Values[key]; !ok {
+			return fmt.Errorf("%w: no value for %q", ErrCorrupted, key)
+		}
+	}
+
+	l.mux.Lock()
+	defer l.mux.Unlock()
+
+	l.restoreState(&state)
+	l.linearSizes = int64(linearSizes)
+	l.sizeChecker = sizeChecker == 1
+
+	l.linearCurrentSize = l.computeSize()
+	l.publishCounters()
+	l.notifyPushed()
+
+	return l.compactLog()
+}
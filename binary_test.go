@@ -0,0 +1,58 @@
+package linear
+
+import (
+	"encoding"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	_ encoding.BinaryMarshaler   = (*Linear)(nil)
+	_ encoding.BinaryUnmarshaler = (*Linear)(nil)
+)
+
+func TestMarshalBinary(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	linearClient := New(1024, true)
+	linearClient.Push("2", "b")
+	linearClient.Push("1", 1)
+	linearClient.Push("3", []string{"c"})
+	linearClient.Push("4", nil)
+	linearClient.Push("2", "x")
+
+	// Testing
+	data, err := linearClient.MarshalBinary()
+	assert.Nil(err)
+
+	restored := New(1, false)
+	assert.Nil(restored.UnmarshalBinary(data))
+	assert.Equal([]string{"2", "1", "3", "4", "2"}, restored.Getkeys())
+	assert.Equal(int64(1024), restored.GetLinearSizes())
+	assert.Equal(linearClient.GetLinearCurrentSize(), restored.GetLinearCurrentSize())
+	assert.Nil(restored.CheckSize())
+
+	value, _ := restored.Read("1")
+	if value != 1 {
+		t.Errorf("UnmarshalBinary failed, expected %v, got %v", 1, value)
+	}
+	value, _ = restored.Read("3")
+	assert.Equal([]string{"c"}, value)
+	value, _ = restored.Read("4")
+	assert.Nil(value)
+
+	// A corrupted encoding leaves the linear unchanged
+	corrupted := append([]byte(nil), data...)
+	corrupted[len(binaryMagic)+3] ^= 0xff
+	assert.True(errors.Is(restored.UnmarshalBinary(corrupted), ErrCorrupted))
+	assert.True(errors.Is(restored.UnmarshalBinary(data[:len(data)-1]), ErrCorrupted))
+	assert.Len(restored.Getkeys(), 5)
+
+	unsupported := append([]byte(nil), data...)
+	unsupported[len(binaryMagic)] = binaryVersion + 1
+	assert.True(errors.Is(restored.UnmarshalBinary(unsupported), ErrUnsupportedVersion))
+	assert.True(errors.Is((&Linear{}).UnmarshalBinary(data), ErrInvalidArgument))
+}
@@ -0,0 +1,70 @@
+package linear
+
+import (
+	"sync"
+	"time"
+)
+
+// loaderBreaker stop calling the loaders for a cool-down once they failed too many times in a row
+type loaderBreaker struct {
+	failures  int
+	coolDown  time.Duration
+	mux       sync.Mutex
+	failed    int       // Failures in a row
+	openUntil time.Time // Loaders are not called before
+	probing   bool      // A call is let through to try the loader after the cool-down
+}
+
+// WithLoaderBreaker stop calling the GetOrLoadMany and WithRefreshAhead loaders for coolDown once they failed failures
+// times in a row. While it is open, GetOrLoadMany serves the missing keys from the fallback and returns ErrCircuitOpen
+// with the items it found, and refresh-ahead keeps the current values until they expire.
+// After coolDown a single call tries the loader again, a success closes the breaker and a failure opens it again
+func WithLoaderBreaker(failures int, coolDown time.Duration) Option {
+	return func(l *Linear) {
+		l.breaker = &loaderBreaker{failures: failures, coolDown: coolDown}
+	}
+}
+
+// allow check if the loader may be called, a true result must be followed by done
+func (b *loaderBreaker) allow() bool {
+
+	if b == nil {
+		return true
+	}
+
+	b.mux.Lock()
+	defer b.mux.Unlock()
+
+	if b.failed < b.failures {
+		return true
+	}
+
+	// Open, until the cool-down ends and then for every call but the probe
+	if b.probing || time.Now().Before(b.openUntil) {
+		return false
+	}
+	b.probing = true
+
+	return true
+}
+
+// done record the result of a loader call allow let through
+func (b *loaderBreaker) done(err error) {
+
+	if b == nil {
+		return
+	}
+
+	b.mux.Lock()
+	defer b.mux.Unlock()
+
+	b.probing = false
+	if err == nil {
+		b.failed = 0
+		return
+	}
+
+	if b.failed++; b.failed >= b.failures {
+		b.openUntil = time.Now().Add(b.coolDown)
+	}
+}
@@ -0,0 +1,101 @@
+package linear
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithLoaderBreaker(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	_, err := NewWithOptions(WithLoaderBreaker(0, time.Second))
+	assert.True(errors.Is(err, ErrInvalidArgument))
+	_, err = NewWithOptions(WithLoaderBreaker(1, 0))
+	assert.True(errors.Is(err, ErrInvalidArgument))
+
+	fallback := func(key string) (interface{}, bool) {
+		return "stale", key == "2"
+	}
+	linearClient, _ := NewWithOptions(WithLoaderBreaker(2, 50*time.Millisecond), WithFallback(fallback))
+	defer linearClient.Close()
+	linearClient.Push("1", "a")
+
+	calls := 0
+	down := errors.New("source down")
+	failing := func(missing []string) (map[string]interface{}, error) {
+		calls++
+		return nil, down
+	}
+	loading := func(missing []string) (map[string]interface{}, error) {
+		calls++
+		return map[string]interface{}{"2": "b", "3": "c"}, nil
+	}
+
+	// Testing
+	for i := 0; i < 2; i++ {
+		_, err = linearClient.GetOrLoadMany([]string{"1", "2"}, failing)
+		assert.True(errors.Is(err, down))
+	}
+
+	// Open, the loader is not called and the fallback serves the missing keys
+	items, err := linearClient.GetOrLoadMany([]string{"1", "2", "3"}, loading)
+	assert.True(errors.Is(err, ErrCircuitOpen))
+	assert.Equal(map[string]interface{}{"1": "a", "2": "stale"}, items)
+	assert.Equal(2, calls)
+
+	// A failed probe after the cool-down opens it again
+	time.Sleep(60 * time.Millisecond)
+	_, err = linearClient.GetOrLoadMany([]string{"2"}, failing)
+	assert.True(errors.Is(err, down))
+	_, err = linearClient.GetOrLoadMany([]string{"2"}, loading)
+	assert.True(errors.Is(err, ErrCircuitOpen))
+	assert.Equal(3, calls)
+
+	// A successful probe closes it
+	time.Sleep(60 * time.Millisecond)
+	items, err = linearClient.GetOrLoadMany([]string{"2"}, loading)
+	assert.Nil(err)
+	assert.Equal(map[string]interface{}{"2": "b"}, items)
+	items, err = linearClient.GetOrLoadMany([]string{"3"}, loading)
+	assert.Nil(err)
+	assert.Equal(map[string]interface{}{"3": "c"}, items)
+	assert.Equal(5, calls)
+}
+
+func TestLoaderBreakerRefreshAhead(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	loads := make(chan string, 10)
+	load := func(key string) (interface{}, error) {
+		loads <- key
+		return nil, errors.New("source down")
+	}
+
+	linearClient, _ := NewWithOptions(WithWheelTick(time.Millisecond), WithRefreshAhead(0.01, load), WithLoaderBreaker(1, time.Minute))
+	defer linearClient.Close()
+	linearClient.PushWithTTL("1", "a", time.Second)
+	linearClient.PushWithTTL("2", "b", time.Second)
+	time.Sleep(20 * time.Millisecond)
+
+	// Testing
+	linearClient.Read("1")
+	select {
+	case key := <-loads:
+		assert.Equal("1", key)
+	case <-time.After(time.Second):
+		t.Fatalf("WithLoaderBreaker failed, expected %v, got %v", "a reload of 1", "none")
+	}
+
+	// The breaker opened on the failed reload, so reads keep the current value without reloading
+	time.Sleep(10 * time.Millisecond)
+	value, err := linearClient.Read("2")
+	assert.Nil(err)
+	assert.Equal("b", value)
+	time.Sleep(10 * time.Millisecond)
+	assert.Equal(0, len(loads))
+}
@@ -0,0 +1,207 @@
+package linear
+
+import (
+	"fmt"
+	"math/bits"
+	"sync"
+)
+
+const (
+	minBufferClass = 6  // 64 bytes
+	maxBufferClass = 20 // 1 MiB, larger buffers are not pooled
+)
+
+// bufferPools hold released buffers by size class, class c holds buffers of at least 1<<c bytes
+var bufferPools [maxBufferClass + 1]sync.Pool
+
+// getBuffer return a buffer of n bytes, its capacity is the one of its size class so it returns to the same class
+func getBuffer(n int) []byte {
+
+	class := bits.Len(uint(n - 1)) // Smallest class holding n bytes
+	if n <= 1 {
+		class = 0
+	}
+	if class < minBufferClass {
+		class = minBufferClass
+	}
+	if class > maxBufferClass {
+		return make([]byte, n)
+	}
+
+	if buf, ok := bufferPools[class].Get().(*[]byte); ok {
+		return (*buf)[:n]
+	}
+
+	return make([]byte, n, 1<<class)
+}
+
+// Release return a buffer of TakeBytes or ReadBytes to the pool, b must not be used afterwards
+func Release(b []byte) {
+
+	class := bits.Len(uint(cap(b))) - 1 // Largest class b can hold
+	if class < minBufferClass {
+		return
+	}
+	if class > maxBufferClass {
+		class = maxBufferClass
+	}
+
+	b = b[:0]
+	bufferPools[class].Put(&b)
+}
+
+// PushBytes push a copy of b to the linear with key, the copy comes from the buffer pool and accounts len(b) bytes
+func (l *Linear) PushBytes(key string, b []byte) error {
+
+	// Execution conditions
+	if l.IsClosed() {
+		return ErrClosed
+	}
+
+	// Argument validator
+	if key == "" && b == nil {
+		return ErrInvalidKey
+	}
+
+	buf := getBuffer(len(b))
+	copy(buf, b)
+
+	// The stored slice is capped to its length, so the linear accounts len(b) bytes
+	stored := buf[:len(b):len(b)]
+	acquired := l.lock(lockPush)
+	err := l.push(key, stored, l.valueSize(key, stored))
+	kept := err == nil && l.holds(key, stored)
+	l.unlock(lockPush, acquired)
+
+	// The key kept its value, so buf never escaped
+	if !kept {
+		Release(buf)
+	}
+
+	return err
+}
+
+// ReadBytes return a pooled copy of the bytes of the key without remove it, pass it to Release once done
+func (l *Linear) ReadBytes(key string) ([]byte, error) {
+
+	item, err := l.Read(key)
+	if err != nil {
+		return nil, err
+	}
+
+	b, ok := item.([]byte)
+	if !ok {
+		return nil, newError("read", key, fmt.Errorf("%w: value is %T, not bytes", ErrInvalidArgument, item))
+	}
+
+	buf := getBuffer(len(b))
+	copy(buf, b)
+
+	return buf, nil
+}
+
+// TakeBytes return and remove the bytes of the first item out of the linear as a pooled copy, pass it to Release once done
+// The stored buffer is not handed over, earlier reads, views and events may still hold it
+// The first item is left in the linear when its value is not bytes
+func (l *Linear) TakeBytes() ([]byte, error) {
+
+	// Execution conditions
+	if l.IsClosed() {
+		return nil, ErrClosed
+	}
+
+	acquired := l.lock(lockTake)
+	defer l.unlock(lockTake, acquired)
+
+	// Spilled items are older than the ones in memory, they are decoded from the spill file so nothing else holds them
+	if l.hasSpilled() {
+		record := l.spill.records[0]
+		item, err := l.readSpillRecord(record)
+		if err != nil {
+			l.dropSpillRecord(record)
+			return nil, newError("take", record.key, err)
+		}
+
+		b, ok := item.([]byte)
+		if !ok {
+			return nil, newError("take", record.key, fmt.Errorf("%w: value is %T, not bytes", ErrInvalidArgument, item))
+		}
+
+		l.dropSpillRecord(record)
+		l.emit(Taken, record.key, item)
+		return b, nil
+	}
+
+	first := l.frontNode()
+	if first == nil {
+		return nil, ErrEmpty
+	}
+
+	key := first.key
+	item, _ := l.items.Load(key)
+	b, ok := l.resolve(item).([]byte)
+	if !ok {
+		return nil, newError("take", key, fmt.Errorf("%w: value is %T, not bytes", ErrInvalidArgument, l.resolve(item)))
+	}
+
+	l.removeNode(first, item)
+	l.emit(Taken, key, item)
+
+	buf := getBuffer(len(b))
+	copy(buf, b)
+
+	return buf, nil
+}
+
+// holds check the value of the key is b itself, caller must hold mux
+func (l *Linear) holds(key string, b []byte) bool {
+	item, _ := l.items.Load(key)
+	stored, ok := l.resolve(item).([]byte)
+	return ok && len(stored) == len(b) && (len(b) == 0 || &stored[0] == &b[0])
+}
+
+// Borrow return the bytes of the key without copy them, they must not be modified
+// The key is not evicted until release is called, Take, Get, Delete and expiry still remove it but leave data valid
+func (l *Linear) Borrow(key string) (data []byte, release func(), err error) {
+
+	// Execution conditions
+	if l.IsClosed() {
+		return nil, nil, ErrClosed
+	}
+
+	l.mux.Lock()
+	defer l.mux.Unlock()
+
+	item, ok := l.items.Load(key)
+	l.countLookup(ok)
+	if !ok {
+		return nil, nil, newError("borrow", key, ErrKeyNotFound)
+	}
+
+	b, ok := l.resolve(item).([]byte)
+	if !ok {
+		return nil, nil, newError("borrow", key, fmt.Errorf("%w: value is %T, not bytes", ErrInvalidArgument, l.resolve(item)))
+	}
+
+	if l.borrowed == nil {
+		l.borrowed = map[string]int{}
+	}
+	l.borrowed[key]++
+	l.evictionAccessed(key)
+	epoch := l.borrowEpoch
+
+	var once sync.Once
+	return b, func() {
+		once.Do(func() {
+			l.mux.Lock()
+			if l.borrowEpoch != epoch {
+				l.mux.Unlock()
+				return
+			}
+			if l.borrowed[key]--; l.borrowed[key] == 0 {
+				delete(l.borrowed, key)
+			}
+			l.mux.Unlock()
+		})
+	}, nil
+}
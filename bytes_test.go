@@ -0,0 +1,175 @@
+package linear
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPushBytes(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	linearClient := New(1024, true)
+	payload := []byte("payload")
+
+	// Testing
+	assert.Nil(linearClient.PushBytes("1", payload))
+	payload[0] = 'P'
+
+	expected := calculateKeySize("1") + calculateValueSize([]byte("payload"))
+	if size := linearClient.GetLinearCurrentSize(); size != expected {
+		t.Errorf("PushBytes failed, expected %v, got %v", expected, size)
+	}
+	assert.Nil(linearClient.CheckSize())
+
+	b, err := linearClient.ReadBytes("1")
+	assert.Nil(err)
+	assert.Equal("payload", string(b))
+	Release(b)
+
+	b, err = linearClient.TakeBytes()
+	assert.Nil(err)
+	assert.Equal("payload", string(b))
+	Release(b)
+
+	_, err = linearClient.TakeBytes()
+	assert.True(errors.Is(err, ErrEmpty))
+
+	linearClient.Push("2", "string")
+	_, err = linearClient.TakeBytes()
+	assert.True(errors.Is(err, ErrInvalidArgument))
+	_, err = linearClient.ReadBytes("2")
+	assert.True(errors.Is(err, ErrInvalidArgument))
+}
+
+func TestTakeBytesShared(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	linearClient := New(1024, false)
+	linearClient.PushBytes("1", []byte("abc"))
+	linearClient.Push("1", nil)
+
+	// Testing
+	b, _ := linearClient.TakeBytes()
+	b[0] = 'x'
+	Release(b)
+
+	b, _ = linearClient.TakeBytes()
+	assert.Equal("abc", string(b))
+}
+
+func TestTakeBytesNotPooled(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	linearClient := New(1024, false)
+	caller := make([]byte, 64)
+	copy(caller, "caller")
+	linearClient.Push("1", caller)
+	linearClient.PushBytes("2", []byte("pooled"))
+	stored, _ := linearClient.Read("2")
+
+	// Testing
+	b, err := linearClient.TakeBytes()
+	assert.Nil(err)
+	if &b[0] == &caller[0] {
+		t.Errorf("TakeBytes failed, expected %v, got %v", "a copy", "the caller slice")
+	}
+	b[0] = 'x'
+	Release(b)
+	assert.Equal("caller", string(caller[:6]))
+
+	// Earlier reads keep the stored buffer, so it is copied too
+	b, err = linearClient.TakeBytes()
+	assert.Nil(err)
+	assert.Equal("pooled", string(b))
+	if &b[0] == &stored.([]byte)[0] {
+		t.Errorf("TakeBytes failed, expected %v, got %v", "a copy", "the stored buffer")
+	}
+	b[0] = 'x'
+	Release(b)
+	assert.Equal("pooled", string(stored.([]byte)))
+}
+
+func TestTakeBytesSpilled(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	linearClient, _ := NewWithOptions(WithMaxItems(1), WithSpillover(t.TempDir(), 1024))
+	defer linearClient.Close()
+	linearClient.PushBytes("1", []byte("a"))
+	linearClient.PushBytes("2", []byte("b"))
+
+	// Testing
+	b, err := linearClient.TakeBytes()
+	assert.Nil(err)
+	assert.Equal("a", string(b))
+
+	b, err = linearClient.TakeBytes()
+	assert.Nil(err)
+	assert.Equal("b", string(b))
+}
+
+func TestBufferPool(t *testing.T) {
+	assert := assert.New(t)
+
+	// Testing
+	for _, n := range []int{0, 1, 63, 64, 65, 1000, 1 << 21} {
+		buf := getBuffer(n)
+		assert.Equal(n, len(buf))
+		class := cap(buf)
+		Release(buf)
+
+		// Released buffers keep their size class
+		buf = getBuffer(n)
+		assert.Equal(class, cap(buf))
+		if n > 1 && n <= 1<<maxBufferClass && class&(class-1) != 0 {
+			t.Errorf("getBuffer failed, expected %v, got %v", "a size class capacity", class)
+		}
+		Release(buf)
+	}
+}
+
+func TestBorrow(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	linearClient, _ := NewWithOptions(WithMaxItems(2))
+	linearClient.PushBytes("1", []byte("one"))
+	linearClient.PushBytes("2", []byte("two"))
+
+	// Testing
+	data, release, err := linearClient.Borrow("1")
+	assert.Nil(err)
+	assert.Equal("one", string(data))
+
+	// The borrowed front is skipped by the eviction
+	assert.Nil(linearClient.PushBytes("3", []byte("three")))
+	assert.Equal([]string{"1", "3"}, linearClient.Getkeys())
+
+	_, release3, _ := linearClient.Borrow("3")
+	err = linearClient.PushBytes("4", []byte("four"))
+	if !errors.Is(err, ErrFull) {
+		t.Errorf("Borrow failed, expected %v, got %v", ErrFull, err)
+	}
+	release3()
+
+	// Taking a borrowed key copies the buffer
+	taken, _ := linearClient.TakeBytes()
+	taken[0] = 'x'
+	Release(taken)
+	assert.Equal("one", string(data))
+
+	release()
+	release()
+	assert.Nil(linearClient.PushBytes("5", []byte("five")))
+
+	linearClient.Push("6", 6)
+	_, _, err = linearClient.Borrow("6")
+	assert.True(errors.Is(err, ErrInvalidArgument))
+	_, _, err = linearClient.Borrow("7")
+	assert.True(errors.Is(err, ErrKeyNotFound))
+}
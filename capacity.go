@@ -0,0 +1,87 @@
+package linear
+
+// FullPolicy decide what a push does once the linear holds its maximum number of items
+type FullPolicy int
+
+const (
+	// EvictOldest remove items from the front to make room for the new one
+	EvictOldest FullPolicy = iota
+	// Reject refuse the new item with ErrFull, it applies to the item and the byte limits with or without size checker
+	Reject
+	// Block make Push, PushBack, PushFront, PushContext, PushContent, Upsert and aggregated pushes wait until the new item
+	// fits in the item and the byte limits, other pushes get ErrFull. Pushes without a context wait for ever unless
+	// WithBlockTimeout bounds them
+	Block
+)
+
+// makeRoom evict items chosen by the eviction policy until an item of itemSize fits in the linear, or reject it following the full policy
+// Caller must hold mux
+func (l *Linear) makeRoom(op, key string, itemSize int64, front bool) error {
+
+	if l.maxItems > 0 {
+		for l.keys.len >= l.maxItems {
+			if l.fullPolicy != EvictOldest {
+				return newError(op, key, ErrFull)
+			}
+			if !l.evict(front) {
+				return newError(op, key, ErrFull)
+			}
+		}
+	}
+
+	// The byte limit evicts only with the size checker, Reject and Block enforce it either way
+	if l.sizeChecker || l.fullPolicy != EvictOldest {
+		for l.linearCurrentSize+itemSize > l.linearSizes && l.keys.head != nil {
+			if l.fullPolicy != EvictOldest {
+				return newError(op, key, ErrFull)
+			}
+			if !l.evict(front) {
+				return newError(op, key, ErrCapacityExceeded)
+			}
+		}
+	}
+
+	return nil
+}
+
+// roomChan return a channel closed once an item leaves the linear or its limits change, caller must hold mux
+func (l *Linear) roomChan() <-chan struct{} {
+	if l.room == nil {
+		l.room = make(chan struct{})
+	}
+	return l.room
+}
+
+// notifyRoom wake up the pushes waiting for room, caller must hold mux
+func (l *Linear) notifyRoom() {
+	if l.room != nil {
+		close(l.room)
+		l.room = nil
+	}
+}
+
+// GetMaxItems return the maximum number of keys, 0 means no cap
+func (l *Linear) GetMaxItems() int {
+
+	l.mux.RLock()
+	maxItems := l.maxItems
+	l.mux.RUnlock()
+
+	return maxItems
+}
+
+// SetMaxItems change the maximum number of keys, 0 means no cap, it applies from the next push
+func (l *Linear) SetMaxItems(maxItems int) error {
+
+	// Argument validator
+	if maxItems < 0 {
+		return ErrInvalidArgument
+	}
+
+	l.mux.Lock()
+	l.maxItems = maxItems
+	l.notifyRoom()
+	l.mux.Unlock()
+
+	return nil
+}
@@ -0,0 +1,165 @@
+package linear
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMaxItems(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	linearClient, _ := NewWithOptions(WithMaxItems(2))
+
+	// Testing
+	linearClient.Push("1", "a")
+	linearClient.Push("2", "b")
+	linearClient.Push("3", "c")
+
+	assert.Equal(linearClient.Getkeys(), []string{"2", "3"})
+	assert.Equal(linearClient.GetMaxItems(), 2)
+
+	assert.Nil(linearClient.SetMaxItems(1))
+	linearClient.Push("4", "d")
+	assert.Equal(linearClient.Getkeys(), []string{"4"})
+
+	assert.True(errors.Is(linearClient.SetMaxItems(-1), ErrInvalidArgument))
+	assert.Nil(linearClient.CheckSize())
+}
+
+func TestFullPolicyReject(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	linearClient, _ := NewWithOptions(WithMaxItems(1), WithFullPolicy(Reject))
+	linearClient.Push("1", "a")
+
+	// Testing
+	assert.True(errors.Is(linearClient.Push("2", "b"), ErrFull))
+	assert.True(errors.Is(linearClient.Alias("3", "1"), ErrFull))
+	assert.Equal(linearClient.Getkeys(), []string{"1"})
+
+	_, err := NewWithOptions(WithFullPolicy(FullPolicy(9)))
+	assert.True(errors.Is(err, ErrInvalidArgument))
+}
+
+func TestFullPolicyRejectBytes(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	linearClient, _ := NewWithOptions(WithMaxBytes(calculateItemSize("1", "a")*2), WithSizeChecker(true), WithFullPolicy(Reject))
+	linearClient.Push("1", "a")
+	linearClient.Push("2", "b")
+
+	// Testing
+	err := linearClient.Push("3", "c")
+	assert.True(errors.Is(err, ErrFull))
+	assert.Equal([]string{"1", "2"}, linearClient.Getkeys())
+}
+
+func TestFullPolicyBytesWithoutSizeChecker(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	maxBytes := calculateItemSize("1", "a") * 2
+	rejecting, _ := NewWithOptions(WithMaxBytes(maxBytes), WithFullPolicy(Reject))
+	rejecting.Push("1", "a")
+	rejecting.Push("2", "b")
+
+	blocking, _ := NewWithOptions(WithMaxBytes(maxBytes), WithFullPolicy(Block))
+	defer blocking.Close()
+	blocking.Push("1", "a")
+	blocking.Push("2", "b")
+
+	// Testing
+	assert.True(errors.Is(rejecting.Push("3", "c"), ErrFull))
+	assert.Equal([]string{"1", "2"}, rejecting.Getkeys())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	err := blocking.PushContext(ctx, "3", "c")
+	assert.True(errors.Is(err, context.DeadlineExceeded))
+
+	done := make(chan error)
+	go func() {
+		done <- blocking.Push("3", "c")
+	}()
+
+	time.Sleep(10 * time.Millisecond)
+	blocking.Take()
+	if err := <-done; err != nil {
+		t.Errorf("Push failed, expected %v, got %v", nil, err)
+	}
+	assert.Equal([]string{"2", "3"}, blocking.Getkeys())
+	assert.Nil(blocking.CheckSize())
+}
+
+func TestFullPolicyBlock(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	linearClient, _ := NewWithOptions(WithMaxItems(1), WithFullPolicy(Block))
+	defer linearClient.Close()
+	linearClient.Push("1", "a")
+
+	// Testing
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	err := linearClient.PushContext(ctx, "2", "b")
+	assert.True(errors.Is(err, context.DeadlineExceeded))
+
+	done := make(chan error)
+	go func() {
+		done <- linearClient.Push("3", "c")
+	}()
+
+	time.Sleep(10 * time.Millisecond)
+	item, _ := linearClient.Take()
+	assert.Equal("a", item)
+
+	if err := <-done; err != nil {
+		t.Errorf("Push failed, expected %v, got %v", nil, err)
+	}
+	assert.Equal([]string{"3"}, linearClient.Getkeys())
+
+	// Pushes that don't wait are rejected
+	_, _, err = linearClient.GetOrSet("4", "d")
+	assert.True(errors.Is(err, ErrFull))
+
+	go func() {
+		done <- linearClient.Push("5", "e")
+	}()
+	time.Sleep(10 * time.Millisecond)
+	linearClient.Close()
+	assert.True(errors.Is(<-done, ErrClosed))
+}
+
+func TestBlockTimeout(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	_, err := NewWithOptions(WithBlockTimeout(-time.Second))
+	assert.True(errors.Is(err, ErrInvalidArgument))
+
+	linearClient, _ := NewWithOptions(WithMaxItems(1), WithFullPolicy(Block), WithBlockTimeout(10*time.Millisecond))
+	defer linearClient.Close()
+	linearClient.Push("1", "a")
+
+	// Testing
+	assert.True(errors.Is(linearClient.Push("2", "b"), context.DeadlineExceeded))
+	assert.True(errors.Is(linearClient.Upsert("3", "c", KeepPosition), context.DeadlineExceeded))
+	_, err = linearClient.PushContent("d")
+	assert.True(errors.Is(err, context.DeadlineExceeded))
+
+	// Pushes with a context follow it instead
+	go func() {
+		time.Sleep(30 * time.Millisecond)
+		linearClient.Take()
+	}()
+	assert.Nil(linearClient.PushContext(context.Background(), "4", "e"))
+	assert.Equal([]string{"4"}, linearClient.Getkeys())
+}
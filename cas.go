@@ -0,0 +1,56 @@
+package linear
+
+import (
+	"reflect"
+	"sync/atomic"
+)
+
+// CompareAndSwap replace the value of the key with new when it is deeply equal to old and report if it did
+func (l *Linear) CompareAndSwap(key string, old, new interface{}) (bool, error) {
+	return l.UpdateIf(key, func(current interface{}) bool {
+		return reflect.DeepEqual(current, old)
+	}, new)
+}
+
+// UpdateIf replace the value of the key with new when predicate returns true for its current value and report if it did
+// predicate runs under the linear lock, so it must not use the linear
+func (l *Linear) UpdateIf(key string, predicate func(old interface{}) bool, new interface{}) (bool, error) {
+
+	// Execution conditions
+	if l.IsClosed() {
+		return false, ErrClosed
+	}
+
+	// Argument validator
+	if key == "" || predicate == nil {
+		return false, ErrInvalidArgument
+	}
+
+	newValueSize := l.valueSize(key, new)
+	if calculateKeySize(key)+newValueSize > l.GetLinearSizes() {
+		return false, newError("update", key, ErrCapacityExceeded)
+	}
+
+	if l.clone != nil {
+		new = l.clone(new)
+	}
+
+	l.mux.Lock()
+	defer l.mux.Unlock()
+
+	current, exits := l.items.Load(key)
+	if !exits {
+		return false, newError("update", key, ErrKeyNotFound)
+	}
+
+	if !predicate(l.resolve(current)) {
+		return false, nil
+	}
+
+	if err := l.update(key, new, newValueSize); err != nil {
+		return false, err
+	}
+	atomic.AddInt64(&l.stats.updates, 1)
+
+	return true, nil
+}
@@ -0,0 +1,70 @@
+package linear
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompareAndSwap(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	linearClient := New(1024, false)
+	linearClient.Push("1", []int{1})
+
+	// Testing
+	swapped, err := linearClient.CompareAndSwap("1", []int{2}, []int{3})
+	assert.Nil(err)
+	assert.False(swapped)
+
+	swapped, err = linearClient.CompareAndSwap("1", []int{1}, []int{1, 2})
+	assert.Nil(err)
+	assert.True(swapped)
+
+	value, _ := linearClient.Read("1")
+	assert.Equal([]int{1, 2}, value)
+	assert.Nil(linearClient.CheckSize())
+
+	_, err = linearClient.CompareAndSwap("2", nil, 1)
+	assert.True(errors.Is(err, ErrKeyNotFound))
+}
+
+func TestUpdateIf(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	linearClient := New(1024, false)
+	linearClient.Push("counter", 0)
+
+	// Testing
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				current, _ := linearClient.Read("counter")
+				swapped, err := linearClient.UpdateIf("counter", func(old interface{}) bool {
+					return old == current
+				}, current.(int)+1)
+				assert.Nil(err)
+				if swapped {
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	value, _ := linearClient.Read("counter")
+	if value != 20 {
+		t.Errorf("UpdateIf failed, expected %v, got %v", 20, value)
+	}
+	assert.Equal(int64(20), linearClient.Stats().Updates)
+
+	_, err := linearClient.UpdateIf("counter", nil, 1)
+	assert.True(errors.Is(err, ErrInvalidArgument))
+}
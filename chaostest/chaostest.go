@@ -0,0 +1,299 @@
+// Package chaostest runs randomized concurrent operation mixes against a store and checks its invariants, so backend
+// authors and users can validate custom eviction policies and backends
+package chaostest
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-common-packages/linear"
+)
+
+// Store is the method set the harness drives, *linear.Linear and *linear.Sharded implement it
+type Store interface {
+	Push(key string, value interface{}) error
+	Read(key string) (interface{}, error)
+	Take() (interface{}, error)
+	Pop() (interface{}, error)
+	Getkeys() []string
+	GetNumberOfKeys() int
+}
+
+// Verifier is a store checking its own internal state, such as *linear.Linear
+type Verifier interface {
+	Verify() []linear.Issue
+}
+
+// Counter is a store counting the items it removed by itself, such as *linear.Linear
+type Counter interface {
+	Stats() linear.Stats
+}
+
+// Config set the size and the mix of a run, zero fields take their default
+type Config struct {
+	Workers    int   // Goroutines running operations, 4 by default
+	Operations int   // Operations per worker, 1000 by default
+	Seed       int64 // Seed of the operation mixes, from the clock when 0
+	Push       int   // Weight of Push in the mix, 4 by default
+	Read       int   // Weight of Read, 2 by default
+	Take       int   // Weight of Take, 1 by default
+	Pop        int   // Weight of Pop, 1 by default
+	// CheckInterval is how often Verify runs during the run on a Verifier store, 10ms by default
+	CheckInterval time.Duration
+}
+
+// Value is the value pushed by the harness, it names its own key so reads can be checked
+type Value struct {
+	Key    string
+	Worker int
+	Seq    int
+}
+
+// Report is the outcome of a run
+type Report struct {
+	Seed       int64
+	Pushed     int // Successful pushes
+	Removed    int // Items returned by Take and Pop
+	Remaining  int // Keys left in the store
+	Errors     map[string]int
+	Violations []string
+}
+
+// withDefaults return config with its zero fields set
+func (config Config) withDefaults() Config {
+	if config.Workers <= 0 {
+		config.Workers = 4
+	}
+	if config.Operations <= 0 {
+		config.Operations = 1000
+	}
+	if config.Seed == 0 {
+		config.Seed = time.Now().UnixNano()
+	}
+	if config.Push+config.Read+config.Take+config.Pop <= 0 {
+		config.Push, config.Read, config.Take, config.Pop = 4, 2, 1, 1
+	}
+	if config.CheckInterval <= 0 {
+		config.CheckInterval = 10 * time.Millisecond
+	}
+	return config
+}
+
+// worker is the state of one goroutine of a run
+type worker struct {
+	id      int
+	rand    *rand.Rand
+	pushed  []string
+	removed []Value
+	errors  map[string]int
+	faults  []string
+}
+
+// Run run config.Workers goroutines of random operations against store and check:
+//   - Read returns the value pushed with the key, and Take and Pop return values the harness pushed
+//   - no item is returned twice
+//   - the keys left keep the push order of every worker
+//   - pushed items are all removed, left, or counted as evicted or expired by a Counter store
+//   - GetNumberOfKeys matches Getkeys, and Verify reports no issue during and after the run on a Verifier store
+//
+// The store must start empty and use unique keys only from the harness
+func Run(store Store, config Config) Report {
+
+	config = config.withDefaults()
+	report := Report{Seed: config.Seed, Errors: map[string]int{}}
+	fail := func(format string, args ...interface{}) {
+		report.Violations = append(report.Violations, fmt.Sprintf(format, args...))
+	}
+
+	var before linear.Stats
+	counter, counted := store.(Counter)
+	if counted {
+		before = counter.Stats()
+	}
+
+	// Verify concurrently with the operations
+	done := make(chan struct{})
+	checked := make(chan []linear.Issue, 1)
+	go func() {
+		var issues []linear.Issue
+		defer func() { checked <- issues }()
+
+		verifier, ok := store.(Verifier)
+		if !ok {
+			return
+		}
+
+		ticker := time.NewTicker(config.CheckInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				if found := verifier.Verify(); len(found) > 0 && issues == nil {
+					issues = found
+				}
+			}
+		}
+	}()
+
+	workers := make([]*worker, config.Workers)
+	var wg sync.WaitGroup
+	for i := range workers {
+		workers[i] = &worker{id: i, rand: rand.New(rand.NewSource(config.Seed + int64(i))), errors: map[string]int{}}
+		wg.Add(1)
+		go func(w *worker) {
+			defer wg.Done()
+			w.run(store, config)
+		}(workers[i])
+	}
+	wg.Wait()
+	close(done)
+
+	for _, issue := range <-checked {
+		fail("Verify during the run: %s", issue)
+	}
+
+	// Every removed value is a pushed one, returned once
+	pushed := map[string]Value{}
+	for _, w := range workers {
+		for seq, key := range w.pushed {
+			pushed[key] = Value{Key: key, Worker: w.id, Seq: seq}
+		}
+		for op, n := range w.errors {
+			report.Errors[op] += n
+		}
+		for _, fault := range w.faults {
+			fail("%s", fault)
+		}
+	}
+	report.Pushed = len(pushed)
+
+	removed := map[string]bool{}
+	for _, w := range workers {
+		for _, value := range w.removed {
+			if expected, ok := pushed[value.Key]; !ok || expected != value {
+				fail("removed %+v was not pushed", value)
+			}
+			if removed[value.Key] {
+				fail("%q was removed twice", value.Key)
+			}
+			removed[value.Key] = true
+		}
+	}
+	report.Removed = len(removed)
+
+	// The keys left keep the push order of every worker
+	keys := store.GetNumberOfKeys()
+	remaining := store.Getkeys()
+	report.Remaining = len(remaining)
+	if keys != len(remaining) {
+		fail("GetNumberOfKeys is %d, Getkeys holds %d keys", keys, len(remaining))
+	}
+
+	last := map[int]int{}
+	for _, key := range remaining {
+		value, ok := pushed[key]
+		if !ok {
+			fail("%q is left but was not pushed", key)
+			continue
+		}
+		if removed[key] {
+			fail("%q is left but was removed", key)
+		}
+		if previous, ok := last[value.Worker]; ok && previous > value.Seq {
+			fail("%q is left out of the push order of worker %d", key, value.Worker)
+		}
+		last[value.Worker] = value.Seq
+	}
+
+	// Pushed items are removed, left, or removed by the store itself
+	gone := report.Pushed - report.Removed - report.Remaining
+	if counted {
+		after := counter.Stats()
+		dropped := int(after.Evictions - before.Evictions + after.Expired - before.Expired)
+		if gone != dropped {
+			fail("%d pushed items are missing, the store evicted or expired %d", gone, dropped)
+		}
+	} else if gone < 0 {
+		fail("%d more items were removed or left than pushed", -gone)
+	}
+
+	if verifier, ok := store.(Verifier); ok {
+		for _, issue := range verifier.Verify() {
+			fail("Verify after the run: %s", issue)
+		}
+	}
+
+	sort.Strings(report.Violations)
+	return report
+}
+
+// RunT run the harness and fail t with every violation, the seed is logged to replay a failed run
+func RunT(t testing.TB, store Store, config Config) Report {
+	t.Helper()
+
+	report := Run(store, config)
+	for _, violation := range report.Violations {
+		t.Errorf("chaostest (seed %d): %s", report.Seed, violation)
+	}
+
+	return report
+}
+
+// run run the operations of one worker
+func (w *worker) run(store Store, config Config) {
+
+	total := config.Push + config.Read + config.Take + config.Pop
+	for i := 0; i < config.Operations; i++ {
+		switch pick := w.rand.Intn(total); {
+		case pick < config.Push:
+			key := fmt.Sprintf("%d-%d", w.id, len(w.pushed))
+			if err := store.Push(key, Value{Key: key, Worker: w.id, Seq: len(w.pushed)}); err != nil {
+				w.errors["push"]++
+				continue
+			}
+			w.pushed = append(w.pushed, key)
+		case pick < config.Push+config.Read:
+			if len(w.pushed) == 0 {
+				continue
+			}
+			key := w.pushed[w.rand.Intn(len(w.pushed))]
+			value, err := store.Read(key)
+			if err != nil {
+				if !errors.Is(err, linear.ErrKeyNotFound) && !errors.Is(err, linear.ErrEmpty) {
+					w.errors["read"]++
+				}
+				continue
+			}
+			if v, ok := value.(Value); !ok || v.Key != key {
+				w.faults = append(w.faults, fmt.Sprintf("Read(%q) returned %+v", key, value))
+			}
+		default:
+			remove, op := store.Take, "take"
+			if pick >= config.Push+config.Read+config.Take {
+				remove, op = store.Pop, "pop"
+			}
+
+			value, err := remove()
+			if err != nil {
+				if !errors.Is(err, linear.ErrEmpty) {
+					w.errors[op]++
+				}
+				continue
+			}
+
+			v, ok := value.(Value)
+			if !ok {
+				w.faults = append(w.faults, fmt.Sprintf("%s returned %+v, not a harness value", op, value))
+				continue
+			}
+			w.removed = append(w.removed, v)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package chaostest
+
+import (
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/golang-common-packages/linear"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunLinear(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	linearClient, err := linear.NewWithOptions()
+	assert.Nil(err)
+	defer linearClient.Close()
+
+	// Testing
+	report := RunT(t, linearClient, Config{Seed: 1})
+	if report.Pushed == 0 || report.Removed == 0 {
+		t.Errorf("Run failed, expected pushes and removals, got %+v", report)
+	}
+	assert.Equal(report.Pushed, report.Removed+report.Remaining)
+	assert.Empty(report.Errors)
+}
+
+func TestRunEviction(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	linearClient, err := linear.NewWithOptions(linear.WithMaxItems(50), linear.WithFullPolicy(linear.EvictOldest))
+	assert.Nil(err)
+	defer linearClient.Close()
+
+	// Testing
+	report := RunT(t, linearClient, Config{Seed: 2, Workers: 8, Push: 8, Read: 2, Take: 1, Pop: 1})
+	if report.Pushed <= report.Removed+report.Remaining {
+		t.Errorf("Run failed, expected evictions, got %+v", report)
+	}
+	assert.True(report.Remaining <= 50)
+}
+
+func TestRunSharded(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	shardedClient, err := linear.NewSharded(linear.WithShards(4))
+	assert.Nil(err)
+	defer shardedClient.Close()
+
+	// Testing
+	report := RunT(t, shardedClient, Config{Seed: 3})
+	assert.Equal(report.Pushed, report.Removed+report.Remaining)
+}
+
+// brokenStore return its oldest item on Take without removing it
+type brokenStore struct {
+	mux    sync.Mutex
+	keys   []string
+	values map[string]interface{}
+}
+
+func (s *brokenStore) Push(key string, value interface{}) error {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	s.keys = append(s.keys, key)
+	s.values[key] = value
+	return nil
+}
+
+func (s *brokenStore) Read(key string) (interface{}, error) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	if value, ok := s.values[key]; ok {
+		return value, nil
+	}
+	return nil, linear.ErrKeyNotFound
+}
+
+func (s *brokenStore) Take() (interface{}, error) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	if len(s.keys) == 0 {
+		return nil, linear.ErrEmpty
+	}
+	return s.values[s.keys[0]], nil
+}
+
+func (s *brokenStore) Pop() (interface{}, error) {
+	return s.Take()
+}
+
+func (s *brokenStore) Getkeys() []string {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	return append([]string(nil), s.keys...)
+}
+
+func (s *brokenStore) GetNumberOfKeys() int {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	return len(s.keys)
+}
+
+func TestRunViolations(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	store := &brokenStore{values: map[string]interface{}{}}
+
+	// Testing
+	report := Run(store, Config{Seed: 4, Workers: 2, Operations: 100})
+	assert.Equal(int64(4), report.Seed)
+	if len(report.Violations) == 0 {
+		t.Errorf("Run failed, expected violations, got %+v", report)
+	}
+
+	twice := false
+	for _, violation := range report.Violations {
+		twice = twice || strings.Contains(violation, "removed twice")
+	}
+	assert.True(twice)
+}
@@ -0,0 +1,157 @@
+package linear
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math"
+	"math/rand"
+	"reflect"
+	"sync/atomic"
+)
+
+// checksums keep the hash of the stored values checked by Read
+type checksums struct {
+	rate       float64
+	onMismatch func(key string, value interface{})
+	values     map[string]uint64
+}
+
+// WithChecksums store a hash of the content of every value and check it on a sample of the reads
+// rate is the share of Read and ReadMany calls checked, from above 0 to 1
+// onMismatch is called with the key and the value that no longer matches its hash, nil logs it instead
+func WithChecksums(rate float64, onMismatch func(key string, value interface{})) Option {
+	return func(l *Linear) {
+		l.checksums = &checksums{rate: rate, onMismatch: onMismatch, values: map[string]uint64{}}
+	}
+}
+
+// checksumTrack store the hash of the value of the key, caller must hold mux
+func (l *Linear) checksumTrack(key string, value interface{}) {
+	if l.checksums == nil {
+		return
+	}
+
+	hash := hashValue(value)
+	l.checksumMux.Lock()
+	l.checksums.values[key] = hash
+	l.checksumMux.Unlock()
+}
+
+// checksumForget drop the hash of the key, caller must hold mux
+func (l *Linear) checksumForget(key string) {
+	if l.checksums == nil {
+		return
+	}
+
+	l.checksumMux.Lock()
+	delete(l.checksums.values, key)
+	l.checksumMux.Unlock()
+}
+
+// checksumReset drop every hash, caller must hold mux
+func (l *Linear) checksumReset() {
+	if l.checksums == nil {
+		return
+	}
+
+	l.checksumMux.Lock()
+	l.checksums.values = map[string]uint64{}
+	l.checksumMux.Unlock()
+}
+
+// checksumCheck compare a sample of the values read with their stored hash
+func (l *Linear) checksumCheck(key string, value interface{}) {
+	if l.checksums == nil || rand.Float64() >= l.checksums.rate {
+		return
+	}
+
+	l.checksumMux.Lock()
+	hash, ok := l.checksums.values[key]
+	l.checksumMux.Unlock()
+
+	if !ok || hash == hashValue(value) {
+		return
+	}
+
+	atomic.AddInt64(&l.stats.checksumMismatches, 1)
+	if l.checksums.onMismatch != nil {
+		l.checksums.onMismatch(key, value)
+		return
+	}
+	l.logger.Printf("linear: value of key %q doesn't match its checksum", key)
+}
+
+// hashValue return a hash of the content reachable from value
+func hashValue(value interface{}) uint64 {
+
+	// A lazy value is hashed by its encoding, which its decoding doesn't change
+	if lazy, ok := value.(*lazyValue); ok {
+		value = lazy.data
+	}
+
+	h := fnv.New64a()
+	hashWalk(h, reflect.ValueOf(value), map[uintptr]bool{})
+	return h.Sum64()
+}
+
+// hashWalk write the content of v to w, visited stops on cycles
+func hashWalk(w interface{ Write([]byte) (int, error) }, v reflect.Value, visited map[uintptr]bool) {
+
+	if !v.IsValid() {
+		w.Write([]byte{0})
+		return
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice:
+		if v.IsNil() {
+			w.Write([]byte{0})
+			return
+		}
+
+		if v.Kind() != reflect.Slice {
+			if visited[v.Pointer()] {
+				return
+			}
+			visited[v.Pointer()] = true
+		}
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		hashWalk(w, v.Elem(), visited)
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			hashWalk(w, v.Index(i), visited)
+		}
+	case reflect.Map:
+		var sum uint64 // Map iteration order is random, so combine entries order independently
+		iter := v.MapRange()
+		for iter.Next() {
+			h := fnv.New64a()
+			hashWalk(h, iter.Key(), visited)
+			hashWalk(h, iter.Value(), visited)
+			sum += h.Sum64()
+		}
+		fmt.Fprint(w, sum)
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			hashWalk(w, v.Field(i), visited)
+		}
+	case reflect.String:
+		fmt.Fprint(w, v.Len(), ":") // The length keeps adjacent strings apart, so "ab", "c" differs from "a", "bc"
+		w.Write([]byte(v.String()))
+	case reflect.Bool:
+		fmt.Fprint(w, v.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		fmt.Fprint(w, v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		fmt.Fprint(w, v.Uint())
+	case reflect.Float32, reflect.Float64:
+		fmt.Fprint(w, math.Float64bits(v.Float()))
+	case reflect.Complex64, reflect.Complex128:
+		fmt.Fprint(w, v.Complex())
+	default:
+		fmt.Fprint(w, v.Kind()) // Channels and funcs are hashed by kind only, so replacing one goes unnoticed
+	}
+}
@@ -0,0 +1,67 @@
+//go:build !lineardebug
+// +build !lineardebug
+
+package linear
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithChecksums(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	var mismatched []string
+	linearClient, err := NewWithOptions(WithChecksums(1, func(key string, value interface{}) {
+		mismatched = append(mismatched, key)
+	}))
+	assert.Nil(err)
+
+	value := []int{1, 2}
+	linearClient.Push("1", value)
+	linearClient.Push("2", []int{3})
+
+	// Testing
+	linearClient.Read("1")
+	assert.Empty(mismatched)
+
+	value[0] = 9 // Silent corruption of the stored value
+	linearClient.Read("1")
+	linearClient.Read("2")
+	linearClient.ReadMany([]string{"1"})
+	assert.Equal([]string{"1", "1"}, mismatched)
+	if mismatches := linearClient.Stats().ChecksumMismatches; mismatches != 2 {
+		t.Errorf("WithChecksums failed, expected %v, got %v", 2, mismatches)
+	}
+
+	// Updating the value stores a new checksum
+	linearClient.Update("1", []int{4})
+	linearClient.Read("1")
+	assert.Len(mismatched, 2)
+
+	_, err = NewWithOptions(WithChecksums(0, nil))
+	assert.True(errors.Is(err, ErrInvalidArgument))
+	_, err = NewWithOptions(WithChecksums(1.5, nil))
+	assert.True(errors.Is(err, ErrInvalidArgument))
+}
+
+func TestHashValue(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	type pair struct {
+		A, B string
+	}
+
+	// Testing
+	assert.NotEqual(hashValue(pair{"ab", "c"}), hashValue(pair{"a", "bc"}))
+	assert.NotEqual(hashValue([]string{"ab", "c"}), hashValue([]string{"a", "bc"}))
+	assert.NotEqual(hashValue([]string{"", "a"}), hashValue([]string{"a", ""}))
+	assert.Equal(hashValue(pair{"ab", "c"}), hashValue(pair{"ab", "c"}))
+
+	// Channels and funcs are hashed by kind only
+	assert.Equal(hashValue(make(chan int)), hashValue(make(chan int)))
+}
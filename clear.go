@@ -0,0 +1,112 @@
+package linear
+
+import (
+	"context"
+)
+
+// Clear remove every item out of the linear and reset its size
+// It doesn't emit events for the removed items, pending delayed pushes are kept
+func (l *Linear) Clear() error {
+
+	// Execution conditions
+	if l.IsClosed() {
+		return ErrClosed
+	}
+
+	l.mux.Lock()
+	l.clear()
+	l.mux.Unlock()
+
+	return nil
+}
+
+// Drain remove every item out of the linear and return them from front to back, duplicated keys included
+func (l *Linear) Drain() ([]Entry, error) {
+
+	// Execution conditions
+	if l.IsClosed() {
+		return nil, ErrClosed
+	}
+
+	l.mux.Lock()
+	defer l.mux.Unlock()
+
+	entries := make([]Entry, 0, l.keys.len)
+	for n := l.keys.head; n != nil; n = n.next {
+		entry := l.entryOf(n)
+		entry.Value = l.resolve(entry.Value)
+		entries = append(entries, entry)
+	}
+
+	l.clear()
+	for _, entry := range entries {
+		l.emit(Taken, entry.Key, entry.Value)
+	}
+
+	return entries, nil
+}
+
+// drainBatch is the number of items DrainContext removes per lock
+const drainBatch = 1024
+
+// DrainContext remove the items out of the linear from front to back and return them, like Drain but in batches
+// Between batches it calls progress with the items and bytes removed so far and stops with the error of ctx once it is done
+// Items pushed after the call are left in the linear
+func (l *Linear) DrainContext(ctx context.Context, progress ProgressFunc) ([]Entry, error) {
+
+	// Execution conditions
+	if l.IsClosed() {
+		return nil, ErrClosed
+	}
+
+	l.mux.RLock()
+	total := l.keys.len
+	l.mux.RUnlock()
+
+	entries := make([]Entry, 0, total)
+	var removed int64
+	for len(entries) < total {
+		if err := ctx.Err(); err != nil {
+			return entries, err
+		}
+
+		l.mux.Lock()
+		before := l.linearCurrentSize
+		for i := 0; i < drainBatch && len(entries) < total && l.keys.head != nil; i++ {
+			entry := l.entryOf(l.keys.head)
+			l.removeNode(l.keys.head, entry.Value)
+			entry.Value = l.resolve(entry.Value)
+			l.emit(Taken, entry.Key, entry.Value)
+			entries = append(entries, entry)
+		}
+		removed += before - l.linearCurrentSize
+		empty := l.keys.head == nil
+		l.mux.Unlock()
+
+		if progress != nil {
+			progress(len(entries), total, removed)
+		}
+
+		// Emptied by other callers
+		if empty {
+			break
+		}
+	}
+
+	return entries, nil
+}
+
+// clear empty the linear, caller must hold mux
+func (l *Linear) clear() {
+
+	l.items.Range(func(key, value interface{}) bool {
+		l.debugForget(key.(string), value)
+		return true
+	})
+
+	l.restoreState(&snapshotState{})
+	l.linearCurrentSize = 0
+	l.publishCounters()
+	l.notifyRoom()
+	l.logRecord(walRecord{Op: walClear})
+}
@@ -0,0 +1,111 @@
+package linear
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClear(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	path := filepath.Join(t.TempDir(), "linear.wal")
+	linearClient, _ := NewWithOptions(WithMaxBytes(1024), WithAppendLog(path, 0))
+	linearClient.Push("1", "a")
+	linearClient.PushWithTTL("2", "b", time.Hour)
+	linearClient.Alias("3", "1")
+
+	// Testing
+	assert.Nil(linearClient.Clear())
+	assert.True(linearClient.IsEmpty())
+	if size := linearClient.GetLinearCurrentSize(); size != 0 {
+		t.Errorf("Clear failed, expected %v, got %v", 0, size)
+	}
+	assert.Equal(0, linearClient.WheelStats().Pending)
+	assert.Nil(linearClient.CheckSize())
+
+	linearClient.Push("4", "d")
+	recovered, err := NewWithOptions(WithAppendLog(path, 0))
+	assert.Nil(err)
+	assert.Equal([]string{"4"}, recovered.Getkeys())
+
+	linearClient.Close()
+	assert.True(errors.Is(linearClient.Clear(), ErrClosed))
+}
+
+func TestDrain(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	linearClient := New(1024, false)
+	linearClient.Push("1", "a")
+	linearClient.Push("2", "b")
+	linearClient.Push("1", "x")
+	events, cancel := linearClient.Subscribe(8)
+	defer cancel()
+
+	// Testing
+	entries, err := linearClient.Drain()
+	assert.Nil(err)
+	assert.Len(entries, 3)
+	for i, expected := range []Entry{{Key: "1", Value: "a"}, {Key: "2", Value: "b"}, {Key: "1", Value: "a"}} {
+		assert.Equal(expected.Key, entries[i].Key)
+		assert.Equal(expected.Value, entries[i].Value)
+	}
+	assert.True(linearClient.IsEmpty())
+	assert.Equal(int64(0), linearClient.GetLinearCurrentSize())
+
+	for range entries {
+		event := <-events
+		assert.Equal(Taken, event.Type)
+	}
+
+	entries, _ = linearClient.Drain()
+	assert.Empty(entries)
+}
+
+func TestDrainContext(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	linearClient := New(1<<30, false)
+	for i := 0; i < 2*drainBatch+1; i++ {
+		linearClient.Push(strconv.Itoa(i), i)
+	}
+	size := linearClient.GetLinearCurrentSize()
+
+	// Testing
+	var calls, lastDone, lastTotal int
+	var lastBytes int64
+	entries, err := linearClient.DrainContext(context.Background(), func(done, total int, bytes int64) {
+		calls++
+		lastDone, lastTotal, lastBytes = done, total, bytes
+	})
+	assert.Nil(err)
+	assert.Len(entries, 2*drainBatch+1)
+	assert.Equal("0", entries[0].Key)
+	assert.Equal(3, calls)
+	assert.Equal(2*drainBatch+1, lastDone)
+	assert.Equal(2*drainBatch+1, lastTotal)
+	if lastBytes != size {
+		t.Errorf("DrainContext failed, expected %v, got %v", size, lastBytes)
+	}
+
+	// Cancelled after the first batch
+	for i := 0; i < 2*drainBatch; i++ {
+		linearClient.Push(strconv.Itoa(i), i)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	entries, err = linearClient.DrainContext(ctx, func(done, total int, bytes int64) {
+		cancel()
+	})
+	assert.True(errors.Is(err, context.Canceled))
+	assert.Len(entries, drainBatch)
+	assert.Equal(drainBatch, linearClient.GetNumberOfKeys())
+}
@@ -0,0 +1,313 @@
+// Package client provides a Go client for the linear HTTP server of the httpserver package
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/golang-common-packages/linear"
+	"github.com/golang-common-packages/linear/httpserver"
+)
+
+// Client call one of several linear HTTP servers, failing over to the next one when a server can't be reached
+type Client struct {
+	endpoints []string
+	http      *http.Client
+	retries   int
+	backoff   time.Duration
+	hedge     time.Duration
+	next      uint32 // Endpoint of the next call, accessed atomically
+}
+
+// Option configure a client
+type Option func(*Client)
+
+// WithHTTPClient send the requests with c, its transport pools the connections
+func WithHTTPClient(c *http.Client) Option {
+	return func(client *Client) {
+		client.http = c
+	}
+}
+
+// WithRetries retry a failed call up to retries times on the next endpoint, waiting backoff doubled on every retry
+// Read is retried on any network or server error, Push, Take and Pop only when the request didn't reach a server
+// or the server was closed, so an item is never pushed or removed twice
+func WithRetries(retries int, backoff time.Duration) Option {
+	return func(client *Client) {
+		client.retries = retries
+		client.backoff = backoff
+	}
+}
+
+// WithHedgedReads send a Read to the next endpoint too when the first one didn't answer within delay, the first answer wins
+func WithHedgedReads(delay time.Duration) Option {
+	return func(client *Client) {
+		client.hedge = delay
+	}
+}
+
+// New return a client of the servers at endpoints, base URLs such as http://host:port
+func New(endpoints []string, opts ...Option) (*Client, error) {
+
+	client := &Client{
+		endpoints: make([]string, 0, len(endpoints)),
+		http:      &http.Client{Timeout: 10 * time.Second},
+	}
+	for _, endpoint := range endpoints {
+		client.endpoints = append(client.endpoints, strings.TrimSuffix(endpoint, "/"))
+	}
+
+	for _, opt := range opts {
+		opt(client)
+	}
+
+	// Argument validator
+	if len(client.endpoints) == 0 || client.http == nil || client.retries < 0 || client.backoff < 0 || client.hedge < 0 {
+		return nil, linear.ErrInvalidArgument
+	}
+
+	return client, nil
+}
+
+// Push push item to the linear with key
+func (c *Client) Push(key string, value interface{}) error {
+
+	body, err := json.Marshal(httpserver.Item{Key: key, Value: value})
+	if err != nil {
+		return err
+	}
+
+	_, err = c.call(false, func(ctx context.Context, endpoint string) (*http.Request, error) {
+		request, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint+"/items", bytes.NewReader(body))
+		if err == nil {
+			request.Header.Set("Content-Type", "application/json")
+		}
+		return request, err
+	})
+
+	return err
+}
+
+// Read return the value of the key without remove it
+func (c *Client) Read(key string) (interface{}, error) {
+	return c.item(true, http.MethodGet, "/items/"+url.PathEscape(key))
+}
+
+// Take return and remove the first item out of the linear
+func (c *Client) Take() (interface{}, error) {
+	return c.item(false, http.MethodDelete, "/items/front")
+}
+
+// Pop return and remove the last item out of the linear
+func (c *Client) Pop() (interface{}, error) {
+	return c.item(false, http.MethodDelete, "/items/back")
+}
+
+// Stats return the counters of the linear
+func (c *Client) Stats() (linear.Stats, error) {
+
+	var stats linear.Stats
+	body, err := c.call(true, requestOf(http.MethodGet, "/stats"))
+	if err != nil {
+		return stats, err
+	}
+
+	err = json.Unmarshal(body, &stats)
+	return stats, err
+}
+
+// item call a route answering an item and return its value
+func (c *Client) item(safe bool, method, path string) (interface{}, error) {
+
+	body, err := c.call(safe, requestOf(method, path))
+	if err != nil {
+		return nil, err
+	}
+
+	var item httpserver.Item
+	if err := json.Unmarshal(body, &item); err != nil {
+		return nil, err
+	}
+
+	return item.Value, nil
+}
+
+// requestOf return the builder of a request without body
+func requestOf(method, path string) func(ctx context.Context, endpoint string) (*http.Request, error) {
+	return func(ctx context.Context, endpoint string) (*http.Request, error) {
+		return http.NewRequestWithContext(ctx, method, endpoint+path, nil)
+	}
+}
+
+// call send the request built by newRequest and return the response body, retrying and failing over as configured
+// A safe call can be retried on any failure and hedged
+func (c *Client) call(safe bool, newRequest func(ctx context.Context, endpoint string) (*http.Request, error)) ([]byte, error) {
+
+	backoff := c.backoff
+	for attempt := 0; ; attempt++ {
+		first := int(atomic.AddUint32(&c.next, 1) - 1)
+
+		var body []byte
+		var err error
+		if safe && c.hedge > 0 && len(c.endpoints) > 1 {
+			body, err = c.hedged(first, newRequest)
+		} else {
+			body, err = c.send(context.Background(), c.endpoints[first%len(c.endpoints)], newRequest)
+		}
+
+		if err == nil || attempt == c.retries || !retryable(err, safe) {
+			return body, err
+		}
+
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+}
+
+// hedged send the request to the endpoint first, and to the next one if it didn't answer within the hedge delay
+func (c *Client) hedged(first int, newRequest func(ctx context.Context, endpoint string) (*http.Request, error)) ([]byte, error) {
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	type result struct {
+		body []byte
+		err  error
+	}
+	results := make(chan result, 2)
+	send := func(endpoint string) {
+		body, err := c.send(ctx, endpoint, newRequest)
+		results <- result{body, err}
+	}
+
+	go send(c.endpoints[first%len(c.endpoints)])
+
+	timer := time.NewTimer(c.hedge)
+	defer timer.Stop()
+
+	sent := 1
+	var last result
+	for received := 0; received < sent; {
+		select {
+		case <-timer.C:
+			if sent == 1 {
+				sent++
+				go send(c.endpoints[(first+1)%len(c.endpoints)])
+			}
+		case last = <-results:
+			received++
+			// A definite answer of the server, such as a missing key, wins too
+			if last.err == nil || !retryable(last.err, true) {
+				return last.body, last.err
+			}
+			// The first endpoint failed, don't wait for the hedge delay
+			if sent == 1 {
+				sent++
+				go send(c.endpoints[(first+1)%len(c.endpoints)])
+			}
+		}
+	}
+
+	return last.body, last.err
+}
+
+// send send one request to endpoint and return the response body, or the linear error matching the status
+func (c *Client) send(ctx context.Context, endpoint string, newRequest func(ctx context.Context, endpoint string) (*http.Request, error)) ([]byte, error) {
+
+	request, err := newRequest(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := c.http.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode >= 200 && response.StatusCode < 300 {
+		return body, nil
+	}
+
+	return nil, statusError(response.StatusCode, strings.TrimSpace(string(body)))
+}
+
+// StatusError is a failed call the server answered with an HTTP status
+type StatusError struct {
+	Status  int
+	Message string
+	err     error
+}
+
+// Error return the error message
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("linear server answered %d: %s", e.Status, e.Message)
+}
+
+// Unwrap return the linear error matching the status, so errors.Is works as with an in-memory linear
+func (e *StatusError) Unwrap() error {
+	return e.err
+}
+
+// statusError return the error of a failed status, message is the error text written by the server
+func statusError(status int, message string) error {
+
+	var err error
+	switch status {
+	case http.StatusNotFound:
+		err = linear.ErrKeyNotFound
+		if strings.Contains(message, linear.ErrEmpty.Error()) {
+			err = linear.ErrEmpty
+		}
+	case http.StatusBadRequest:
+		err = linear.ErrInvalidArgument
+		for _, known := range []error{linear.ErrInvalidKey, linear.ErrInvalidValue} {
+			if strings.Contains(message, known.Error()) {
+				err = known
+			}
+		}
+	case http.StatusInsufficientStorage:
+		err = linear.ErrFull
+		if strings.Contains(message, linear.ErrCapacityExceeded.Error()) {
+			err = linear.ErrCapacityExceeded
+		}
+	case http.StatusServiceUnavailable:
+		err = linear.ErrClosed
+	}
+
+	return &StatusError{Status: status, Message: message, err: err}
+}
+
+// retryable check if a call that failed with err can be sent again, unsafe calls only when no server handled them
+func retryable(err error, safe bool) bool {
+
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		if statusErr.Status == http.StatusServiceUnavailable {
+			return true
+		}
+		return safe && statusErr.Status >= 500 && statusErr.Status != http.StatusInsufficientStorage
+	}
+
+	if safe {
+		return true
+	}
+
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
@@ -0,0 +1,144 @@
+package client
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang-common-packages/linear"
+	"github.com/golang-common-packages/linear/httpserver"
+	"github.com/stretchr/testify/assert"
+)
+
+// queue is the method set shared by the client and the in-memory linear
+type queue interface {
+	Push(key string, value interface{}) error
+	Read(key string) (interface{}, error)
+	Take() (interface{}, error)
+	Pop() (interface{}, error)
+}
+
+var (
+	_ queue = (*Client)(nil)
+	_ queue = (*linear.Linear)(nil)
+)
+
+func TestClient(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	l, _ := linear.NewWithOptions(linear.WithMaxItems(2), linear.WithFullPolicy(linear.Reject))
+	server := httptest.NewServer(httpserver.Handler(l))
+	defer server.Close()
+
+	_, err := New(nil)
+	assert.True(errors.Is(err, linear.ErrInvalidArgument))
+
+	client, _ := New([]string{server.URL + "/"})
+
+	// Testing
+	_, err = client.Take()
+	assert.True(errors.Is(err, linear.ErrEmpty))
+
+	assert.Nil(client.Push("a/1", "a"))
+	assert.Nil(client.Push("2", map[string]interface{}{"n": 2.0}))
+	assert.True(errors.Is(client.Push("3", "c"), linear.ErrFull))
+
+	value, err := client.Read("a/1")
+	assert.Nil(err)
+	assert.Equal("a", value)
+	_, err = client.Read("3")
+	assert.True(errors.Is(err, linear.ErrKeyNotFound))
+
+	value, _ = client.Pop()
+	assert.Equal(map[string]interface{}{"n": 2.0}, value)
+	value, _ = client.Take()
+	assert.Equal("a", value)
+
+	stats, err := client.Stats()
+	assert.Nil(err)
+	if stats.Pushes != 2 {
+		t.Errorf("Stats failed, expected %v, got %v", 2, stats.Pushes)
+	}
+
+	l.Close()
+	assert.True(errors.Is(client.Push("4", "d"), linear.ErrClosed))
+}
+
+func TestWithRetries(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	l, _ := linear.NewWithOptions()
+	defer l.Close()
+	server := httptest.NewServer(httpserver.Handler(l))
+	defer server.Close()
+
+	// Nothing listens on the address of a closed server
+	down := httptest.NewServer(http.NotFoundHandler())
+	down.Close()
+
+	var failures int32
+	flaky := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&failures, 1)
+		http.Error(w, "overloaded", http.StatusBadGateway)
+	}))
+	defer flaky.Close()
+
+	// Testing
+	client, _ := New([]string{down.URL, server.URL}, WithRetries(1, time.Millisecond))
+	assert.Nil(client.Push("1", "a"))
+	assert.Nil(client.Push("2", "b"))
+	assert.Equal([]string{"1", "2"}, l.Getkeys())
+
+	// A server error may come after the item was removed, so only reads are retried on it
+	client, _ = New([]string{flaky.URL, server.URL}, WithRetries(1, time.Millisecond))
+	_, err := client.Take()
+	var statusErr *StatusError
+	assert.True(errors.As(err, &statusErr))
+	assert.Equal(http.StatusBadGateway, statusErr.Status)
+
+	// Calls rotate over the endpoints, so both reads succeed and one of them was retried
+	for i := 0; i < 2; i++ {
+		value, err := client.Read("1")
+		assert.Nil(err)
+		assert.Equal("a", value)
+	}
+	assert.Equal(int32(2), atomic.LoadInt32(&failures))
+}
+
+func TestWithHedgedReads(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	l, _ := linear.NewWithOptions()
+	defer l.Close()
+	assert.Nil(l.Push("1", "a"))
+	fast := httptest.NewServer(httpserver.Handler(l))
+	defer fast.Close()
+
+	release := make(chan struct{})
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer slow.Close()
+	defer close(release)
+
+	client, _ := New([]string{slow.URL, fast.URL}, WithHedgedReads(10*time.Millisecond))
+
+	// Testing
+	start := time.Now()
+	value, err := client.Read("1")
+	assert.Nil(err)
+	assert.Equal("a", value)
+	assert.True(time.Since(start) < time.Second)
+
+	_, err = client.Read("2")
+	assert.True(errors.Is(err, linear.ErrKeyNotFound))
+}
@@ -0,0 +1,150 @@
+package linear
+
+import (
+	"reflect"
+)
+
+// cloneVisit identify an already cloned reference to keep cycles intact
+type cloneVisit struct {
+	ptr uintptr
+	typ reflect.Type
+	len int
+}
+
+// deepClone return a deep copy of value, unexported struct fields are copied shallowly
+func deepClone(value interface{}) interface{} {
+	if value == nil {
+		return nil
+	}
+
+	return cloneValue(reflect.ValueOf(value), map[cloneVisit]reflect.Value{}).Interface()
+}
+
+// cloneValue recursively copy v, visited keeps the copies of pointers, maps and slices already seen
+func cloneValue(v reflect.Value, visited map[cloneVisit]reflect.Value) reflect.Value {
+
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return v
+		}
+
+		visit := cloneVisit{v.Pointer(), v.Type(), 0}
+		if cloned, ok := visited[visit]; ok {
+			return cloned
+		}
+
+		cloned := reflect.New(v.Type().Elem())
+		visited[visit] = cloned
+		cloned.Elem().Set(cloneValue(v.Elem(), visited))
+		return cloned
+
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+
+		cloned := reflect.New(v.Type()).Elem()
+		cloned.Set(cloneValue(v.Elem(), visited))
+		return cloned
+
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+
+		visit := cloneVisit{v.Pointer(), v.Type(), 0}
+		if cloned, ok := visited[visit]; ok {
+			return cloned
+		}
+
+		cloned := reflect.MakeMapWithSize(v.Type(), v.Len())
+		visited[visit] = cloned
+		iter := v.MapRange()
+		for iter.Next() {
+			cloned.SetMapIndex(cloneValue(iter.Key(), visited), cloneValue(iter.Value(), visited))
+		}
+		return cloned
+
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+
+		visit := cloneVisit{v.Pointer(), v.Type(), v.Len()}
+		if cloned, ok := visited[visit]; ok {
+			return cloned
+		}
+
+		cloned := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		visited[visit] = cloned
+		for i := 0; i < v.Len(); i++ {
+			cloned.Index(i).Set(cloneValue(v.Index(i), visited))
+		}
+		return cloned
+
+	case reflect.Array:
+		cloned := reflect.New(v.Type()).Elem()
+		for i := 0; i < v.Len(); i++ {
+			cloned.Index(i).Set(cloneValue(v.Index(i), visited))
+		}
+		return cloned
+
+	case reflect.Struct:
+		cloned := reflect.New(v.Type()).Elem()
+		cloned.Set(v)
+		for i := 0; i < v.NumField(); i++ {
+			if cloned.Field(i).CanSet() {
+				cloned.Field(i).Set(cloneValue(v.Field(i), visited))
+			}
+		}
+		return cloned
+	}
+
+	return v
+}
+
+// Clone return an independent copy of the linear with deep copies of its values
+// The copy keeps the size and item limits, the size checker and the value hooks, but not the eviction policy,
+// priorities, TTLs, subscribers, persistence or append log
+func (l *Linear) Clone() (*Linear, error) {
+
+	// Execution conditions
+	if l.IsClosed() {
+		return nil, ErrClosed
+	}
+
+	l.mux.RLock()
+	state := l.captureState()
+	opts := []Option{
+		WithMaxBytes(l.linearSizes),
+		WithSizeChecker(l.sizeChecker),
+		WithMaxItems(l.maxItems),
+		WithFullPolicy(l.fullPolicy),
+		WithGrowthPolicy(l.growthPolicy),
+		WithLogger(l.logger),
+		func(c *Linear) {
+			c.clone = l.clone
+			c.sizeFunc = l.sizeFunc
+			c.fallback = l.fallback
+		},
+	}
+	l.mux.RUnlock()
+
+	for key, value := range state.Values {
+		state.Values[key] = deepClone(l.resolve(value))
+	}
+
+	clone, err := NewWithOptions(opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	clone.mux.Lock()
+	clone.restoreState(state)
+	clone.linearCurrentSize = clone.trackedSize()
+	clone.publishCounters()
+	clone.mux.Unlock()
+
+	return clone, nil
+}
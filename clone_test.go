@@ -0,0 +1,82 @@
+package linear
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithCopyOnWrite(t *testing.T) {
+	assert := assert.New(t)
+
+	type node struct {
+		Name     string
+		Tags     []string
+		Children map[string]*node
+	}
+
+	// Setting up
+	linearClient := New(1024, false, WithCopyOnWrite(nil))
+
+	value := &node{Name: "a", Tags: []string{"x"}, Children: map[string]*node{}}
+	value.Children["self"] = value
+	linearClient.Push("1", value)
+
+	// Testing
+	value.Name = "b"
+	value.Tags[0] = "y"
+
+	item, err := linearClient.Read("1")
+	if err != nil {
+		t.Errorf("WithCopyOnWrite failed, expected %v, got %v", "a", err)
+	}
+
+	stored := item.(*node)
+	assert.Equal(stored.Name, "a")
+	assert.Equal(stored.Tags, []string{"x"})
+	assert.True(stored.Children["self"] == stored)
+
+	// Custom clone function
+	calls := 0
+	linearClient = New(1024, false, WithCopyOnWrite(func(v interface{}) interface{} {
+		calls++
+		return v
+	}))
+	linearClient.Push("1", "a")
+	linearClient.Update("1", "b")
+
+	assert.Equal(calls, 2)
+}
+
+func TestClone(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	linearClient := New(1024, true)
+	linearClient.Push("1", []int{1})
+	linearClient.Push("2", "b")
+	linearClient.Alias("3", "1")
+
+	// Testing
+	clone, err := linearClient.Clone()
+	assert.Nil(err)
+	assert.Equal(linearClient.Getkeys(), clone.Getkeys())
+	assert.Equal(linearClient.GetLinearCurrentSize(), clone.GetLinearCurrentSize())
+	assert.Equal(linearClient.GetLinearSizes(), clone.GetLinearSizes())
+	assert.Nil(clone.CheckSize())
+
+	value, _ := clone.Read("1")
+	original, _ := linearClient.Read("1")
+	assert.Equal(original, value)
+	if &value.([]int)[0] == &original.([]int)[0] {
+		t.Errorf("Clone failed, expected %v, got %v", "a copied value", "a shared value")
+	}
+
+	clone.Take()
+	assert.Equal([]string{"1", "2", "3"}, linearClient.Getkeys())
+
+	linearClient.Close()
+	_, err = linearClient.Clone()
+	assert.True(errors.Is(err, ErrClosed))
+}
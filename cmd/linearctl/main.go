@@ -0,0 +1,50 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/golang-common-packages/linear"
+)
+
+const usage = `usage: linearctl verify <file>...
+
+verify check snapshot files and append logs, with the snapshot next to them, for broken invariants.
+It exits with status 1 when an issue is found and 2 when a file can't be read.`
+
+func main() {
+	if len(os.Args) < 3 || os.Args[1] != "verify" {
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	os.Exit(verify(os.Args[2:]))
+}
+
+// verify print the issues of every file and return the exit status
+func verify(paths []string) int {
+
+	status := 0
+	for _, path := range paths {
+		issues, err := linear.VerifyFile(path)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", path, err)
+			status = 2
+			continue
+		}
+
+		if len(issues) == 0 {
+			fmt.Printf("%s: ok\n", path)
+			continue
+		}
+
+		for _, issue := range issues {
+			fmt.Printf("%s: %s\n", path, issue)
+		}
+		if status == 0 {
+			status = 1
+		}
+	}
+
+	return status
+}
@@ -0,0 +1,135 @@
+package linear
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Codec serialize the values written to snapshots, the append log and the binary encoding
+// Decode is given a *interface{} to fill
+type Codec interface {
+	Encode(v interface{}) ([]byte, error)
+	Decode(data []byte, v interface{}) error
+}
+
+// GobCodec encode values with encoding/gob, concrete types stored behind interface{} must be registered with gob.Register
+type GobCodec struct{}
+
+// Encode return the gob encoding of v as an interface value
+func (GobCodec) Encode(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(&v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Decode decode the gob encoding of an interface value into v
+func (GobCodec) Decode(data []byte, v interface{}) error {
+	return gob.NewDecoder(bytes.NewReader(data)).Decode(v)
+}
+
+// JSONCodec encode values with encoding/json, they are decoded as the generic JSON types
+type JSONCodec struct{}
+
+// Encode return the JSON encoding of v
+func (JSONCodec) Encode(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+// Decode decode the JSON encoding data into v
+func (JSONCodec) Decode(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
+
+// WithCodec serialize the values of snapshots, the append log and MarshalBinary with codec instead of gob
+// Files written with a codec must be read by a linear with the same codec, the codec of a TypeRegistry keeps the Go types
+func WithCodec(codec Codec) Option {
+	return func(l *Linear) {
+		l.codec = codec
+	}
+}
+
+// prefixCodec is the codec of the keys starting with prefix
+type prefixCodec struct {
+	prefix string
+	codec  Codec
+}
+
+// WithPrefixCodec serialize the values of the keys starting with prefix with codec, the longest matching prefix wins
+// and the other keys use the WithCodec codec, or gob. Files must be read by a linear with the same codecs
+func WithPrefixCodec(prefix string, codec Codec) Option {
+	return func(l *Linear) {
+		l.prefixCodecs = append(l.prefixCodecs, prefixCodec{prefix: prefix, codec: codec})
+	}
+}
+
+// codecOrDefault return the configured codec, GobCodec without WithCodec
+func (l *Linear) codecOrDefault() Codec {
+	if l.codec != nil {
+		return l.codec
+	}
+	return GobCodec{}
+}
+
+// codecFor return the codec of the key, the one of its longest WithPrefixCodec prefix or codecOrDefault
+func (l *Linear) codecFor(key string) Codec {
+
+	var found *prefixCodec
+	for i, prefixed := range l.prefixCodecs {
+		if strings.HasPrefix(key, prefixed.prefix) && (found == nil || len(prefixed.prefix) > len(found.prefix)) {
+			found = &l.prefixCodecs[i]
+		}
+	}
+
+	if found != nil {
+		return found.codec
+	}
+	return l.codecOrDefault()
+}
+
+// hasCodec check if values are encoded by a codec instead of written as gob interface values
+func (l *Linear) hasCodec() bool {
+	return l.codec != nil || len(l.prefixCodecs) > 0
+}
+
+// encodeValue return the encoding of value by the codec, nil values are encoded as no bytes
+func (l *Linear) encodeValue(key string, value interface{}) ([]byte, error) {
+
+	if value == nil {
+		return nil, nil
+	}
+
+	// A lazy value is encoded already by the codec of its key
+	if lazy, ok := value.(*lazyValue); ok {
+		if lazy.key == key {
+			return lazy.data, nil
+		}
+		value = l.resolve(lazy)
+	}
+
+	data, err := l.codecFor(key).Encode(value)
+	if err != nil {
+		return nil, fmt.Errorf("encoding the value of %q: %w", key, err)
+	}
+
+	return data, nil
+}
+
+// decodeValue return the value encoded by encodeValue
+func (l *Linear) decodeValue(key string, data []byte) (interface{}, error) {
+
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	var value interface{}
+	if err := l.codecFor(key).Decode(data, &value); err != nil {
+		return nil, fmt.Errorf("%w: decoding the value of %q: %v", ErrCorrupted, key, err)
+	}
+
+	return value, nil
+}
@@ -0,0 +1,131 @@
+package linear
+
+import (
+	"bytes"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// point is not registered with gob, so only a codec can persist it
+type point struct {
+	X, Y int
+}
+
+func TestWithCodec(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	linearClient, _ := NewWithOptions(WithCodec(JSONCodec{}))
+	linearClient.Push("1", point{1, 2})
+	linearClient.Push("2", nil)
+	linearClient.Push("1", point{3, 4})
+
+	// Testing
+	var buf bytes.Buffer
+	assert.Nil(linearClient.Snapshot(&buf))
+
+	restored, _ := NewWithOptions(WithCodec(JSONCodec{}))
+	assert.Nil(restored.Restore(bytes.NewReader(buf.Bytes())))
+	assert.Equal([]string{"1", "2", "1"}, restored.Getkeys())
+	value, _ := restored.Read("1")
+	assert.Equal(map[string]interface{}{"X": float64(1), "Y": float64(2)}, value)
+	value, _ = restored.Read("2")
+	assert.Nil(value)
+
+	// A linear without the codec can't read the values
+	gobLinear, _ := NewWithOptions()
+	assert.True(errors.Is(gobLinear.Restore(bytes.NewReader(buf.Bytes())), ErrCorrupted))
+
+	data, err := linearClient.MarshalBinary()
+	assert.Nil(err)
+	binary, _ := NewWithOptions(WithCodec(MsgpackCodec{}))
+	assert.True(errors.Is(binary.UnmarshalBinary(data), ErrCorrupted))
+	binary, _ = NewWithOptions(WithCodec(JSONCodec{}))
+	assert.Nil(binary.UnmarshalBinary(data))
+	assert.Equal(restored.GetItemsMap(), binary.GetItemsMap())
+}
+
+func TestWithCodecAppendLog(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	path := filepath.Join(t.TempDir(), "linear.wal")
+	linearClient, _ := NewWithOptions(WithCodec(MsgpackCodec{}), WithAppendLog(path, 0))
+	defer linearClient.Close()
+	linearClient.Push("1", []int{1, 2})
+	linearClient.Push("2", map[string]interface{}{"a": "b"})
+	linearClient.Update("2", "b")
+
+	// Testing
+	// Replayed without Close, so the values come from the log records
+	issues, err := VerifyFile(path, WithCodec(MsgpackCodec{}))
+	assert.Nil(err)
+	assert.Len(issues, 0)
+	issues, _ = VerifyFile(path)
+	assert.Len(issues, 1)
+
+	reopened, err := NewWithOptions(WithCodec(MsgpackCodec{}), WithAppendLog(path, 0))
+	assert.Nil(err)
+	defer reopened.Close()
+	value, _ := reopened.Read("1")
+	assert.Equal([]interface{}{int64(1), int64(2)}, value)
+	value, _ = reopened.Read("2")
+	assert.Equal("b", value)
+}
+
+func TestWithPrefixCodec(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	_, err := NewWithOptions(WithPrefixCodec("", JSONCodec{}))
+	assert.True(errors.Is(err, ErrInvalidArgument))
+	_, err = NewWithOptions(WithPrefixCodec("a:", JSONCodec{}), WithPrefixCodec("a:", MsgpackCodec{}))
+	assert.True(errors.Is(err, ErrInvalidArgument))
+
+	opts := []Option{WithPrefixCodec("config:", JSONCodec{}), WithPrefixCodec("event:", MsgpackCodec{}), WithPrefixCodec("event:json:", JSONCodec{})}
+	linearClient, _ := NewWithOptions(opts...)
+	linearClient.Push("config:1", point{1, 2})
+	linearClient.Push("event:1", []int{1})
+	linearClient.Push("event:json:1", []int{1})
+	linearClient.Push("other", "a")
+
+	// Testing
+	config, _ := linearClient.encodeValue("config:1", point{1, 2})
+	assert.Equal(`{"X":1,"Y":2}`, string(config))
+
+	var buf bytes.Buffer
+	assert.Nil(linearClient.Snapshot(&buf))
+
+	restored, _ := NewWithOptions(opts...)
+	assert.Nil(restored.Restore(bytes.NewReader(buf.Bytes())))
+	value, _ := restored.Read("config:1")
+	assert.Equal(map[string]interface{}{"X": float64(1), "Y": float64(2)}, value)
+	value, _ = restored.Read("event:1")
+	assert.Equal([]interface{}{int64(1)}, value)
+	value, _ = restored.Read("event:json:1")
+	assert.Equal([]interface{}{float64(1)}, value)
+	value, _ = restored.Read("other")
+	assert.Equal("a", value)
+
+	// A linear without the prefix codecs can't read the values
+	gobLinear, _ := NewWithOptions()
+	assert.True(errors.Is(gobLinear.Restore(bytes.NewReader(buf.Bytes())), ErrCorrupted))
+
+	// The append log encodes its records with them too
+	path := filepath.Join(t.TempDir(), "linear.wal")
+	logged, _ := NewWithOptions(append(opts, WithAppendLog(path, 0))...)
+	defer logged.Close()
+	logged.Push("event:1", []int{1})
+	logged.Push("config:1", point{1, 2})
+
+	reopened, err := NewWithOptions(append(opts, WithAppendLog(path, 0))...)
+	assert.Nil(err)
+	defer reopened.Close()
+	value, _ = reopened.Read("event:1")
+	assert.Equal([]interface{}{int64(1)}, value)
+	value, _ = reopened.Read("config:1")
+	assert.Equal(map[string]interface{}{"X": float64(1), "Y": float64(2)}, value)
+}
@@ -0,0 +1,73 @@
+package linear
+
+// GetOrSet return the value of the key when it exits, otherwise push the item and return value
+// The check and the push happen under a single lock, loaded reports if the value was already there
+func (l *Linear) GetOrSet(key string, value interface{}) (interface{}, bool, error) {
+
+	// Execution conditions
+	if l.IsClosed() {
+		return nil, false, ErrClosed
+	}
+
+	// Argument validator
+	if key == "" && value == nil {
+		return nil, false, ErrInvalidKey
+	}
+
+	if l.clone != nil {
+		value = l.clone(value)
+	}
+
+	valueSize := l.valueSize(key, value)
+
+	l.mux.Lock()
+	defer l.mux.Unlock()
+
+	return l.getOrPush(key, func() (interface{}, int64) { return value, valueSize })
+}
+
+// ComputeIfAbsent return the value of the key when it exits, otherwise push the value returned by compute and return it
+// compute runs under the linear lock, so it is called at most once per missing key and must not use the linear
+func (l *Linear) ComputeIfAbsent(key string, compute func() interface{}) (interface{}, error) {
+
+	// Execution conditions
+	if l.IsClosed() {
+		return nil, ErrClosed
+	}
+
+	// Argument validator
+	if key == "" || compute == nil {
+		return nil, ErrInvalidArgument
+	}
+
+	l.mux.Lock()
+	defer l.mux.Unlock()
+
+	actual, _, err := l.getOrPush(key, func() (interface{}, int64) {
+		value := compute()
+		if l.clone != nil {
+			value = l.clone(value)
+		}
+		return value, l.valueSize(key, value)
+	})
+
+	return actual, err
+}
+
+// getOrPush return the value of the key, or push the value made by newValue when the key doesn't exit, caller must hold mux
+func (l *Linear) getOrPush(key string, newValue func() (interface{}, int64)) (interface{}, bool, error) {
+
+	if actual, loaded := l.items.Load(key); loaded {
+		l.countLookup(true)
+		l.evictionAccessed(key)
+		return l.resolve(actual), true, nil
+	}
+	l.countLookup(false)
+
+	value, valueSize := newValue()
+	if err := l.push(key, value, valueSize); err != nil {
+		return nil, false, err
+	}
+
+	return value, false, nil
+}
@@ -0,0 +1,67 @@
+package linear
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetOrSet(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	linearClient := New(1024, false)
+
+	// Testing
+	actual, loaded, err := linearClient.GetOrSet("1", "a")
+	assert.Nil(err)
+	assert.False(loaded)
+	assert.Equal("a", actual)
+
+	actual, loaded, err = linearClient.GetOrSet("1", "b")
+	assert.Nil(err)
+	assert.True(loaded)
+	assert.Equal("a", actual)
+
+	assert.Equal(1, linearClient.GetNumberOfKeys())
+	assert.Equal(calculateItemSize("1", "a"), linearClient.GetLinearCurrentSize())
+
+	_, _, err = linearClient.GetOrSet("2", make([]byte, 2048))
+	assert.True(errors.Is(err, ErrCapacityExceeded))
+	assert.Equal(1, linearClient.GetNumberOfKeys())
+}
+
+func TestComputeIfAbsent(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	linearClient := New(1024, false)
+	var calls int32
+	compute := func() interface{} {
+		atomic.AddInt32(&calls, 1)
+		return "computed"
+	}
+
+	// Testing
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			actual, err := linearClient.ComputeIfAbsent("1", compute)
+			assert.Nil(err)
+			assert.Equal("computed", actual)
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(int32(1), calls)
+	assert.Equal(1, linearClient.GetNumberOfKeys())
+	assert.Nil(linearClient.CheckSize())
+
+	_, err := linearClient.ComputeIfAbsent("2", nil)
+	assert.True(errors.Is(err, ErrInvalidArgument))
+}
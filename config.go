@@ -0,0 +1,257 @@
+package linear
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Config describe a linear instance declaratively, the zero value of a field keeps the default behaviour
+type Config struct {
+	MaxBytes     int64             `json:"maxBytes" yaml:"maxBytes"` // 0 means unbounded
+	SizeChecker  bool              `json:"sizeChecker" yaml:"sizeChecker"`
+	MaxItems     int               `json:"maxItems" yaml:"maxItems"`
+	FullPolicy   string            `json:"fullPolicy" yaml:"fullPolicy"`     // "evict-oldest", "reject" or "block"
+	BlockTimeout Duration          `json:"blockTimeout" yaml:"blockTimeout"` // Bound of the "block" waits, see WithBlockTimeout
+	Eviction     string            `json:"eviction" yaml:"eviction"`         // "fifo", "lifo", "lru", "lfu" or "random"
+	TTL          TTLConfig         `json:"ttl" yaml:"ttl"`
+	Persistence  PersistenceConfig `json:"persistence" yaml:"persistence"`
+	AppendLog    AppendLogConfig   `json:"appendLog" yaml:"appendLog"`
+	Metrics      MetricsConfig     `json:"metrics" yaml:"metrics"`
+}
+
+// TTLConfig configure expiry, see WithDefaultTTL, WithWheelTick and WithExpiryClock
+type TTLConfig struct {
+	Default   Duration `json:"default" yaml:"default"`
+	WheelTick Duration `json:"wheelTick" yaml:"wheelTick"`
+	Clock     string   `json:"clock" yaml:"clock"` // "monotonic" or "wall"
+}
+
+// PersistenceConfig configure the snapshot file, see WithPersistence
+type PersistenceConfig struct {
+	Path     string   `json:"path" yaml:"path"`
+	Interval Duration `json:"interval" yaml:"interval"`
+}
+
+// AppendLogConfig configure the append log, see WithAppendLog
+type AppendLogConfig struct {
+	Path            string   `json:"path" yaml:"path"`
+	CompactInterval Duration `json:"compactInterval" yaml:"compactInterval"`
+}
+
+// MetricsConfig configure the drift check and the history, see WithDriftCheck and WithHistory
+type MetricsConfig struct {
+	DriftCheckInterval Duration `json:"driftCheckInterval" yaml:"driftCheckInterval"`
+	HistoryInterval    Duration `json:"historyInterval" yaml:"historyInterval"`
+	HistorySize        int      `json:"historySize" yaml:"historySize"`
+}
+
+// Duration is a time.Duration written as a string like "1m30s" in config files
+type Duration time.Duration
+
+// MarshalJSON encode the duration as a string
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+// UnmarshalJSON decode a duration string or a number of nanoseconds
+func (d *Duration) UnmarshalJSON(data []byte) error {
+
+	var text string
+	if err := json.Unmarshal(data, &text); err != nil {
+		var nanoseconds int64
+		if err := json.Unmarshal(data, &nanoseconds); err != nil {
+			return fmt.Errorf("%w: duration %s", ErrInvalidArgument, data)
+		}
+		*d = Duration(nanoseconds)
+		return nil
+	}
+
+	return d.parse(text)
+}
+
+// UnmarshalYAML decode a duration string
+func (d *Duration) UnmarshalYAML(value *yaml.Node) error {
+	return d.parse(value.Value)
+}
+
+// parse set the duration from its string form
+func (d *Duration) parse(text string) error {
+
+	duration, err := time.ParseDuration(text)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidArgument, err)
+	}
+	*d = Duration(duration)
+
+	return nil
+}
+
+// ParseConfig parse a JSON or YAML config
+func ParseConfig(data []byte) (Config, error) {
+
+	var cfg Config
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '{' {
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&cfg); err != nil {
+			return Config{}, err
+		}
+		return cfg, nil
+	}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&cfg); err != nil && !errors.Is(err, io.EOF) {
+		return Config{}, err
+	}
+
+	return cfg, nil
+}
+
+// LoadConfig read and parse the JSON or YAML config file at path
+func LoadConfig(path string) (Config, error) {
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Config{}, err
+	}
+
+	return ParseConfig(data)
+}
+
+// NewFromConfig return new linear instance configured by cfg, opts are applied after it
+func NewFromConfig(cfg Config, opts ...Option) (*Linear, error) {
+
+	cfgOpts, err := cfg.Options()
+	if err != nil {
+		return nil, err
+	}
+
+	return NewWithOptions(append(cfgOpts, opts...)...)
+}
+
+// Options return the options matching the config
+func (cfg Config) Options() ([]Option, error) {
+
+	fullPolicy, err := parseFullPolicy(cfg.FullPolicy)
+	if err != nil {
+		return nil, err
+	}
+
+	var eviction EvictionPolicy
+	switch cfg.Eviction {
+	case "", "fifo":
+	case "lifo":
+		eviction = LIFO()
+	case "lru":
+		eviction = LRU()
+	case "lfu":
+		eviction = LFU()
+	case "random":
+		eviction = Random(time.Now().UnixNano())
+	default:
+		return nil, fmt.Errorf("%w: eviction policy %q", ErrInvalidArgument, cfg.Eviction)
+	}
+
+	clock := Monotonic
+	switch cfg.TTL.Clock {
+	case "", "monotonic":
+	case "wall":
+		clock = WallClock
+	default:
+		return nil, fmt.Errorf("%w: expiry clock %q", ErrInvalidArgument, cfg.TTL.Clock)
+	}
+
+	opts := []Option{
+		WithMaxBytes(maxBytes(cfg.MaxBytes)),
+		WithSizeChecker(cfg.SizeChecker),
+		WithMaxItems(cfg.MaxItems),
+		WithFullPolicy(fullPolicy),
+		WithBlockTimeout(time.Duration(cfg.BlockTimeout)),
+		WithEvictionPolicy(eviction),
+		WithDefaultTTL(time.Duration(cfg.TTL.Default)),
+		WithWheelTick(time.Duration(cfg.TTL.WheelTick)),
+		WithExpiryClock(clock),
+	}
+
+	if cfg.Persistence.Path != "" {
+		opts = append(opts, WithPersistence(cfg.Persistence.Path, time.Duration(cfg.Persistence.Interval)))
+	}
+
+	if cfg.AppendLog.Path != "" {
+		opts = append(opts, WithAppendLog(cfg.AppendLog.Path, time.Duration(cfg.AppendLog.CompactInterval)))
+	}
+
+	if cfg.Metrics.DriftCheckInterval != 0 {
+		opts = append(opts, WithDriftCheck(time.Duration(cfg.Metrics.DriftCheckInterval), nil))
+	}
+
+	if cfg.Metrics.HistoryInterval != 0 || cfg.Metrics.HistorySize != 0 {
+		opts = append(opts, WithHistory(time.Duration(cfg.Metrics.HistoryInterval), cfg.Metrics.HistorySize))
+	}
+
+	return opts, nil
+}
+
+// ApplyConfig apply the fields of cfg that are safe to change at runtime: the sizes, the full policy and the default TTL
+// The other fields need a new instance and are ignored, nothing is applied when a field is invalid
+func (l *Linear) ApplyConfig(cfg Config) error {
+
+	// Execution conditions
+	if l.IsClosed() {
+		return ErrClosed
+	}
+
+	fullPolicy, err := parseFullPolicy(cfg.FullPolicy)
+	if err != nil {
+		return err
+	}
+
+	// Argument validator
+	if cfg.MaxBytes < 0 {
+		return ErrInvalidSize
+	}
+
+	if cfg.MaxItems < 0 || cfg.TTL.Default < 0 {
+		return ErrInvalidArgument
+	}
+
+	l.mux.Lock()
+	l.linearSizes = maxBytes(cfg.MaxBytes)
+	l.sizeChecker = cfg.SizeChecker
+	l.maxItems = cfg.MaxItems
+	l.fullPolicy = fullPolicy
+	l.defaultTTL = time.Duration(cfg.TTL.Default)
+	l.mux.Unlock()
+
+	return nil
+}
+
+// maxBytes return the linear size of a config, 0 means unbounded
+func maxBytes(configured int64) int64 {
+	if configured == 0 {
+		return math.MaxInt64
+	}
+	return configured
+}
+
+// parseFullPolicy return the full policy named in a config
+func parseFullPolicy(name string) (FullPolicy, error) {
+	switch name {
+	case "", "evict-oldest":
+		return EvictOldest, nil
+	case "reject":
+		return Reject, nil
+	case "block":
+		return Block, nil
+	}
+	return 0, fmt.Errorf("%w: full policy %q", ErrInvalidArgument, name)
+}
@@ -0,0 +1,91 @@
+package linear
+
+import (
+	"errors"
+	"math"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseConfig(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	yamlConfig := []byte(`
+maxBytes: 1024
+sizeChecker: true
+maxItems: 10
+fullPolicy: reject
+blockTimeout: 5s
+ttl:
+  default: 1m
+  clock: wall
+metrics:
+  historyInterval: 1s
+  historySize: 60
+`)
+	jsonConfig := []byte(`{"maxBytes": 1024, "sizeChecker": true, "maxItems": 10, "fullPolicy": "reject",
+		"blockTimeout": "5s", "ttl": {"default": "1m", "clock": "wall"}, "metrics": {"historyInterval": 1000000000, "historySize": 60}}`)
+
+	// Testing
+	for _, data := range [][]byte{yamlConfig, jsonConfig} {
+		cfg, err := ParseConfig(data)
+		assert.Nil(err)
+		assert.Equal(int64(1024), cfg.MaxBytes)
+		assert.True(cfg.SizeChecker)
+		assert.Equal("reject", cfg.FullPolicy)
+		assert.Equal(Duration(5*time.Second), cfg.BlockTimeout)
+		assert.Equal(Duration(time.Minute), cfg.TTL.Default)
+		assert.Equal(Duration(time.Second), cfg.Metrics.HistoryInterval)
+		assert.Equal(60, cfg.Metrics.HistorySize)
+	}
+
+	cfg, err := ParseConfig(nil)
+	assert.Nil(err)
+	assert.Equal(Config{}, cfg)
+
+	_, err = ParseConfig([]byte("maxByte: 1"))
+	assert.NotNil(err)
+
+	_, err = ParseConfig([]byte(`{"ttl": {"default": "soon"}}`))
+	assert.True(errors.Is(err, ErrInvalidArgument))
+}
+
+func TestNewFromConfig(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	cfg := Config{MaxItems: 2, FullPolicy: "reject"}
+	cfg.Persistence.Path = filepath.Join(t.TempDir(), "linear.snapshot")
+	linearClient, err := NewFromConfig(cfg)
+	assert.Nil(err)
+	defer linearClient.Close()
+
+	// Testing
+	assert.Equal(int64(math.MaxInt64), linearClient.GetLinearSizes())
+	linearClient.Push("1", "a")
+	linearClient.Push("2", "b")
+	assert.True(errors.Is(linearClient.Push("3", "c"), ErrFull))
+
+	cfg.MaxItems = 3
+	cfg.MaxBytes = 4096
+	cfg.TTL.Default = Duration(10 * time.Millisecond)
+	assert.Nil(linearClient.ApplyConfig(cfg))
+	assert.Equal(3, linearClient.GetMaxItems())
+	assert.Equal(int64(4096), linearClient.GetLinearSizes())
+
+	assert.Nil(linearClient.Push("3", "c"))
+	assert.Eventually(func() bool {
+		_, exits := linearClient.IsExits("3")
+		return !exits
+	}, time.Second, time.Millisecond)
+
+	assert.True(errors.Is(linearClient.ApplyConfig(Config{FullPolicy: "newest"}), ErrInvalidArgument))
+	assert.Equal(3, linearClient.GetMaxItems())
+
+	_, err = NewFromConfig(Config{TTL: TTLConfig{Clock: "solar"}})
+	assert.True(errors.Is(err, ErrInvalidArgument))
+}
@@ -0,0 +1,98 @@
+package linear
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// PushContent push item to the linear with the key derived from the hash of its content and return that key
+// Pushing the same content again doesn't store a new item but increases its reference count
+func (l *Linear) PushContent(value interface{}) (string, error) {
+
+	// Execution conditions
+	if l.IsClosed() {
+		return "", ErrClosed
+	}
+
+	var sum [sha256.Size]byte
+	switch content := value.(type) {
+	case []byte:
+		sum = sha256.Sum256(content)
+	case string:
+		sum = sha256.Sum256([]byte(content))
+	default:
+		return "", ErrInvalidValue
+	}
+
+	key := hex.EncodeToString(sum[:])
+
+	if l.clone != nil {
+		value = l.clone(value)
+	}
+	valueSize := l.valueSize(key, value)
+
+	ctx, cancel := l.blockContext()
+	defer cancel()
+	if err := l.withRoom(ctx, lockPush, func() error {
+		return l.pushContent(key, value, valueSize)
+	}); err != nil {
+		return "", err
+	}
+
+	return key, nil
+}
+
+// pushContent push the content under its key unless it exits already and increase its reference count, caller must hold mux
+func (l *Linear) pushContent(key string, value interface{}, valueSize int64) error {
+
+	if _, exits := l.items.Load(key); !exits {
+		if err := l.push(key, value, valueSize); err != nil {
+			return err
+		}
+	}
+
+	l.refs[key]++
+	l.logRecord(walRecord{Op: walRefs, Key: key, Refs: l.refs[key]})
+
+	return nil
+}
+
+// DeleteContent decrease the reference count of the content key and remove the item once nobody references it
+// The count and the removal change under a single lock, so a concurrent PushContent keeps the item
+func (l *Linear) DeleteContent(key string) error {
+
+	// Execution conditions
+	if l.IsClosed() {
+		return ErrClosed
+	}
+
+	acquired := l.lock(lockDelete)
+	defer l.unlock(lockDelete, acquired)
+
+	refs, ok := l.refs[key]
+	if !ok {
+		return newError("delete content", key, ErrKeyNotFound)
+	}
+
+	if refs > 1 {
+		l.refs[key]--
+		l.logRecord(walRecord{Op: walRefs, Key: key, Refs: l.refs[key]})
+		return nil
+	}
+
+	if !l.deleteKey(key, Deleted) {
+		return newError("delete content", key, ErrKeyNotFound)
+	}
+
+	return nil
+}
+
+// GetContentRefs return the reference count of the content key
+func (l *Linear) GetContentRefs(key string) int {
+
+	l.mux.RLock()
+	refs := l.refs[key]
+	l.mux.RUnlock()
+
+	return refs
+}
@@ -0,0 +1,104 @@
+package linear
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPushContent(t *testing.T) {
+	assert := assert.New(t)
+
+	linearClient := New(1024, false)
+
+	// Testing
+	key1, err := linearClient.PushContent([]byte("a"))
+	if err != nil {
+		t.Errorf("PushContent failed, expected %v, got %v", nil, err)
+	}
+
+	key2, err := linearClient.PushContent("a")
+	if err != nil {
+		t.Errorf("PushContent failed, expected %v, got %v", nil, err)
+	}
+
+	assert.Equal(key1, key2)
+	assert.Equal(linearClient.GetContentRefs(key1), 2)
+	assert.Equal(linearClient.GetNumberOfKeys(), 1)
+
+	_, err = linearClient.PushContent(1)
+	assert.NotNil(err)
+}
+
+func TestPushContentConcurrent(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	linearClient := New(1024, false)
+
+	// Testing
+	var wg sync.WaitGroup
+	keys := make([]string, 50)
+	for i := range keys {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			keys[i], _ = linearClient.PushContent("same")
+		}(i)
+	}
+	wg.Wait()
+
+	assert.Equal(1, linearClient.GetNumberOfKeys())
+	assert.Equal(50, linearClient.GetContentRefs(keys[0]))
+	assert.Empty(linearClient.Verify())
+}
+
+func TestDeleteContent(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	linearClient := New(1024, false)
+	key, _ := linearClient.PushContent("a")
+	linearClient.PushContent("a")
+
+	// Testing
+	assert.Nil(linearClient.DeleteContent(key))
+	assert.Equal(linearClient.GetNumberOfKeys(), 1)
+
+	assert.Nil(linearClient.DeleteContent(key))
+	assert.Equal(linearClient.GetNumberOfKeys(), 0)
+	assert.Equal(linearClient.GetLinearCurrentSize(), int64(0))
+
+	assert.NotNil(linearClient.DeleteContent(key))
+}
+
+func TestDeleteContentConcurrent(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	linearClient := New(1024, false)
+	key, _ := linearClient.PushContent("a")
+
+	// Testing
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			linearClient.PushContent("a")
+		}()
+		go func() {
+			defer wg.Done()
+			linearClient.DeleteContent(key)
+		}()
+	}
+	wg.Wait()
+
+	// A referenced content is never removed
+	_, exits := linearClient.IsExits(key)
+	if refs := linearClient.GetContentRefs(key); exits != (refs > 0) {
+		t.Errorf("DeleteContent failed, expected the item to exit with %v references, got %v", refs, exits)
+	}
+	assert.Empty(linearClient.Verify())
+}
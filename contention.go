@@ -0,0 +1,107 @@
+package linear
+
+import (
+	"math"
+	"sort"
+)
+
+// minContentionWrites is the number of writes needed before keys are reported as contended
+const minContentionWrites = 100
+
+// ContendedKey is a key receiving a disproportionate share of the writes, which serialize on the linear lock
+// A key is reported once its writes may reach the threshold, keys under 90% of it are never reported
+// Conflating its writes with Aggregate or Debounce, or spreading it over shards, relieves the lock
+type ContendedKey struct {
+	Key    string
+	Writes int64   // Lower bound of the writes to the key
+	Share  float64 // Lower bound of the share of all writes
+}
+
+// contentionTracker find the keys over a share of the writes with the Misra-Gries heavy hitters algorithm,
+// which keeps a bounded number of counters, caller must hold mux
+type contentionTracker struct {
+	threshold float64
+	capacity  int
+	counters  map[string]int64
+	writes    int64
+}
+
+// WithContentionDetection report in Stats the keys receiving at least threshold of the writes, between 0 and 1
+func WithContentionDetection(threshold float64) Option {
+	return func(l *Linear) {
+		l.contention = &contentionTracker{threshold: threshold}
+	}
+}
+
+// init size the tracker to never miss a key over the threshold and report if the threshold is valid
+func (t *contentionTracker) init() bool {
+	if t.threshold <= 0 || t.threshold > 1 {
+		return false
+	}
+	t.capacity = int(math.Ceil(10 / t.threshold))
+	t.counters = make(map[string]int64, t.capacity)
+	return true
+}
+
+// write count a write to key
+func (t *contentionTracker) write(key string) {
+
+	t.writes++
+	if _, ok := t.counters[key]; ok || len(t.counters) < t.capacity {
+		t.counters[key]++
+		return
+	}
+
+	for counted := range t.counters {
+		if t.counters[counted]--; t.counters[counted] == 0 {
+			delete(t.counters, counted)
+		}
+	}
+}
+
+// contended return the keys over the threshold, most written first
+func (t *contentionTracker) contended() []ContendedKey {
+
+	if t.writes < minContentionWrites {
+		return nil
+	}
+
+	// Counters miss at most writes / capacity writes of their key
+	maxMissed := t.writes / int64(t.capacity)
+
+	var keys []ContendedKey
+	for key, count := range t.counters {
+		if float64(count+maxMissed) >= t.threshold*float64(t.writes) {
+			keys = append(keys, ContendedKey{Key: key, Writes: count, Share: float64(count) / float64(t.writes)})
+		}
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].Writes != keys[j].Writes {
+			return keys[i].Writes > keys[j].Writes
+		}
+		return keys[i].Key < keys[j].Key
+	})
+
+	return keys
+}
+
+// countWrite count a write to key when contention detection is on, caller must hold mux
+func (l *Linear) countWrite(key string) {
+	if l.contention != nil {
+		l.contention.write(key)
+	}
+}
+
+// contendedKeys return the contended keys when contention detection is on
+func (l *Linear) contendedKeys() []ContendedKey {
+
+	if l.contention == nil {
+		return nil
+	}
+
+	l.mux.RLock()
+	defer l.mux.RUnlock()
+
+	return l.contention.contended()
+}
@@ -0,0 +1,70 @@
+package linear
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContentionDetection(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	linearClient, err := NewWithOptions(WithContentionDetection(0.2))
+	assert.Nil(err)
+
+	// Testing
+	for i := 0; i < 50; i++ {
+		linearClient.Push("hot", i)
+	}
+	assert.Empty(linearClient.Stats().ContendedKeys)
+
+	for i := 0; i < 1000; i++ {
+		switch {
+		case i%3 == 0:
+			linearClient.Push("hot", i)
+		case i%11 == 0:
+			linearClient.Update("warm", i)
+			linearClient.Push("warm", i)
+		default:
+			linearClient.Push(strconv.Itoa(i), i)
+		}
+	}
+
+	contended := linearClient.Stats().ContendedKeys
+	assert.Len(contended, 1)
+	assert.Equal("hot", contended[0].Key)
+	assert.True(contended[0].Writes <= 50+334)
+
+	linearClient.ResetStats()
+	assert.Empty(linearClient.Stats().ContendedKeys)
+
+	_, err = NewWithOptions(WithContentionDetection(1.5))
+	assert.True(errors.Is(err, ErrInvalidArgument))
+}
+
+func TestContentionTracker(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	tracker := &contentionTracker{threshold: 0.25}
+	assert.True(tracker.init())
+
+	// Testing
+	for i := 0; i < 1000; i++ {
+		tracker.write(strconv.Itoa(i))
+		if i%2 == 0 {
+			tracker.write("a")
+		}
+		if i%3 == 0 {
+			tracker.write("b")
+		}
+	}
+
+	assert.True(len(tracker.counters) <= tracker.capacity)
+	contended := tracker.contended()
+	assert.Len(contended, 1)
+	assert.Equal("a", contended[0].Key)
+}
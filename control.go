@@ -0,0 +1,181 @@
+package linear
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// auditLogSize is the number of changes kept by the audit log
+const auditLogSize = 256
+
+// maxTunablesSize is the largest Tunables body accepted by ControlHandler
+const maxTunablesSize = 1 << 16
+
+// Tunables are the settings that can change at runtime, a nil field is left unchanged by Tune
+type Tunables struct {
+	MaxBytes    *int64    `json:"maxBytes,omitempty"`
+	MaxItems    *int      `json:"maxItems,omitempty"`
+	SizeChecker *bool     `json:"sizeChecker,omitempty"`
+	FullPolicy  *string   `json:"fullPolicy,omitempty"` // "evict-oldest", "reject" or "block"
+	DefaultTTL  *Duration `json:"defaultTTL,omitempty"`
+}
+
+// AuditEntry record a setting changed by Tune
+type AuditEntry struct {
+	At      time.Time `json:"at"`
+	Who     string    `json:"who"`
+	Setting string    `json:"setting"`
+	Old     string    `json:"old"`
+	New     string    `json:"new"`
+}
+
+// GetTunables return the current value of every tunable setting
+func (l *Linear) GetTunables() Tunables {
+
+	l.mux.RLock()
+	maxBytes, maxItems, sizeChecker := l.linearSizes, l.maxItems, l.sizeChecker
+	fullPolicy, defaultTTL := fullPolicyName(l.fullPolicy), Duration(l.defaultTTL)
+	l.mux.RUnlock()
+
+	return Tunables{
+		MaxBytes:    &maxBytes,
+		MaxItems:    &maxItems,
+		SizeChecker: &sizeChecker,
+		FullPolicy:  &fullPolicy,
+		DefaultTTL:  &defaultTTL,
+	}
+}
+
+// Tune validate and apply the set fields of tunables, then record every changed setting in the audit log as done by who
+// Nothing is applied when a field is invalid
+func (l *Linear) Tune(who string, tunables Tunables) error {
+
+	// Execution conditions
+	if l.IsClosed() {
+		return ErrClosed
+	}
+
+	// Argument validator
+	if tunables.MaxBytes != nil && *tunables.MaxBytes <= 0 {
+		return ErrInvalidSize
+	}
+
+	if (tunables.MaxItems != nil && *tunables.MaxItems < 0) || (tunables.DefaultTTL != nil && *tunables.DefaultTTL < 0) {
+		return ErrInvalidArgument
+	}
+
+	var fullPolicy FullPolicy
+	if tunables.FullPolicy != nil {
+		var err error
+		if fullPolicy, err = parseFullPolicy(*tunables.FullPolicy); err != nil {
+			return err
+		}
+	}
+
+	now := time.Now()
+	var changes []AuditEntry
+	change := func(setting string, old, new interface{}) {
+		if old != new {
+			changes = append(changes, AuditEntry{At: now, Who: who, Setting: setting, Old: fmt.Sprint(old), New: fmt.Sprint(new)})
+		}
+	}
+
+	l.mux.Lock()
+	if tunables.MaxBytes != nil {
+		l.notifyRoom()
+		change("maxBytes", l.linearSizes, *tunables.MaxBytes)
+		l.linearSizes = *tunables.MaxBytes
+	}
+	if tunables.MaxItems != nil {
+		l.notifyRoom()
+		change("maxItems", l.maxItems, *tunables.MaxItems)
+		l.maxItems = *tunables.MaxItems
+	}
+	if tunables.SizeChecker != nil {
+		change("sizeChecker", l.sizeChecker, *tunables.SizeChecker)
+		l.sizeChecker = *tunables.SizeChecker
+	}
+	if tunables.FullPolicy != nil {
+		l.notifyRoom()
+		change("fullPolicy", fullPolicyName(l.fullPolicy), fullPolicyName(fullPolicy))
+		l.fullPolicy = fullPolicy
+	}
+	if tunables.DefaultTTL != nil {
+		change("defaultTTL", l.defaultTTL, time.Duration(*tunables.DefaultTTL))
+		l.defaultTTL = time.Duration(*tunables.DefaultTTL)
+	}
+	l.mux.Unlock()
+
+	l.auditMux.Lock()
+	for _, entry := range changes {
+		l.logger.Printf("linear: %s changed %s from %s to %s", entry.Who, entry.Setting, entry.Old, entry.New)
+		l.audit = append(l.audit, entry)
+	}
+	if len(l.audit) > auditLogSize {
+		l.audit = append([]AuditEntry(nil), l.audit[len(l.audit)-auditLogSize:]...)
+	}
+	l.auditMux.Unlock()
+
+	return nil
+}
+
+// AuditLog return the last settings changed by Tune, oldest first
+func (l *Linear) AuditLog() []AuditEntry {
+
+	l.auditMux.Lock()
+	defer l.auditMux.Unlock()
+
+	return append([]AuditEntry(nil), l.audit...)
+}
+
+// fullPolicyName return the config name of a full policy
+func fullPolicyName(policy FullPolicy) string {
+	switch policy {
+	case Reject:
+		return "reject"
+	case Block:
+		return "block"
+	}
+	return "evict-oldest"
+}
+
+// ControlHandler return an HTTP handler exposing the tunables of l
+// GET returns the tunables and the audit log, PATCH applies a JSON Tunables body as done by the X-Linear-User header
+// or the remote address, bodies over 64 KiB are refused
+func ControlHandler(l *Linear) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+		case http.MethodPatch:
+			var tunables Tunables
+			decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxTunablesSize))
+			decoder.DisallowUnknownFields()
+			if err := decoder.Decode(&tunables); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+
+			who := r.Header.Get("X-Linear-User")
+			if who == "" {
+				who = r.RemoteAddr
+			}
+
+			if err := l.Tune(who, tunables); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+		default:
+			w.Header().Set("Allow", "GET, PATCH")
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(struct {
+			Tunables Tunables     `json:"tunables"`
+			Audit    []AuditEntry `json:"audit"`
+		}{l.GetTunables(), l.AuditLog()})
+	})
+}
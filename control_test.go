@@ -0,0 +1,88 @@
+package linear
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTune(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	linearClient := New(1024, false)
+	maxItems, fullPolicy, ttl := 10, "reject", Duration(time.Minute)
+
+	// Testing
+	assert.Nil(linearClient.Tune("ops", Tunables{MaxItems: &maxItems, FullPolicy: &fullPolicy, DefaultTTL: &ttl}))
+	assert.Equal(10, linearClient.GetMaxItems())
+
+	tunables := linearClient.GetTunables()
+	assert.Equal(int64(1024), *tunables.MaxBytes)
+	assert.Equal("reject", *tunables.FullPolicy)
+	assert.Equal(ttl, *tunables.DefaultTTL)
+
+	audit := linearClient.AuditLog()
+	assert.Len(audit, 3)
+	assert.Equal("ops", audit[0].Who)
+	assert.Equal("maxItems", audit[0].Setting)
+	assert.Equal("0", audit[0].Old)
+	assert.Equal("10", audit[0].New)
+
+	// Unchanged settings are not audited
+	assert.Nil(linearClient.Tune("ops", Tunables{MaxItems: &maxItems}))
+	assert.Len(linearClient.AuditLog(), 3)
+
+	maxBytes := int64(0)
+	assert.True(errors.Is(linearClient.Tune("ops", Tunables{MaxBytes: &maxBytes, MaxItems: &maxItems}), ErrInvalidSize))
+	assert.Len(linearClient.AuditLog(), 3)
+}
+
+func TestControlHandler(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	linearClient := New(1024, false)
+	handler := ControlHandler(linearClient)
+
+	// Testing
+	request := httptest.NewRequest(http.MethodPatch, "/", strings.NewReader(`{"maxBytes": 2048, "defaultTTL": "30s"}`))
+	request.Header.Set("X-Linear-User", "alice")
+	response := httptest.NewRecorder()
+	handler.ServeHTTP(response, request)
+	assert.Equal(http.StatusOK, response.Code)
+
+	var body struct {
+		Tunables Tunables     `json:"tunables"`
+		Audit    []AuditEntry `json:"audit"`
+	}
+	assert.Nil(json.NewDecoder(response.Body).Decode(&body))
+	assert.Equal(int64(2048), *body.Tunables.MaxBytes)
+	assert.Equal(Duration(30*time.Second), *body.Tunables.DefaultTTL)
+	assert.Len(body.Audit, 2)
+	assert.Equal("alice", body.Audit[1].Who)
+	assert.Equal(int64(2048), linearClient.GetLinearSizes())
+
+	response = httptest.NewRecorder()
+	handler.ServeHTTP(response, httptest.NewRequest(http.MethodPatch, "/", strings.NewReader(`{"maxItems": -1}`)))
+	assert.Equal(http.StatusBadRequest, response.Code)
+
+	response = httptest.NewRecorder()
+	handler.ServeHTTP(response, httptest.NewRequest(http.MethodPatch, "/", strings.NewReader(`{"workers": 4}`)))
+	assert.Equal(http.StatusBadRequest, response.Code)
+
+	response = httptest.NewRecorder()
+	handler.ServeHTTP(response, httptest.NewRequest(http.MethodPatch, "/", strings.NewReader(strings.Repeat(" ", maxTunablesSize)+`{"maxItems": 1}`)))
+	assert.Equal(http.StatusBadRequest, response.Code)
+	assert.Equal(0, linearClient.GetMaxItems())
+
+	response = httptest.NewRecorder()
+	handler.ServeHTTP(response, httptest.NewRequest(http.MethodDelete, "/", nil))
+	assert.Equal(http.StatusMethodNotAllowed, response.Code)
+}
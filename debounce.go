@@ -0,0 +1,149 @@
+package linear
+
+import (
+	"errors"
+	"time"
+)
+
+// pendingPush hold the latest value waiting for its timer to deliver it
+type pendingPush struct {
+	value   interface{}
+	waiting bool
+	timer   *time.Timer
+}
+
+// Debounce coalesce rapid pushes of the same key, the latest value is delivered once no push came for wait
+// A key still in the linear when the value is delivered is updated in place
+func (l *Linear) Debounce(key string, value interface{}, wait time.Duration) error {
+
+	// Execution conditions
+	if l.IsClosed() {
+		return ErrClosed
+	}
+
+	// Argument validator
+	if key == "" && value == nil {
+		return ErrInvalidKey
+	}
+
+	if wait <= 0 {
+		return ErrInvalidArgument
+	}
+
+	l.pendingMux.Lock()
+	defer l.pendingMux.Unlock()
+
+	if l.debounced == nil {
+		l.debounced = map[string]*pendingPush{}
+	}
+
+	if previous, ok := l.debounced[key]; ok {
+		previous.timer.Stop()
+	}
+
+	p := &pendingPush{value: value}
+	l.debounced[key] = p
+	p.timer = time.AfterFunc(wait, func() {
+		l.pendingMux.Lock()
+		if l.debounced[key] != p {
+			l.pendingMux.Unlock()
+			return
+		}
+		delete(l.debounced, key)
+		l.pendingMux.Unlock()
+
+		l.deliver(key, p.value)
+	})
+
+	return nil
+}
+
+// Throttle push the key at most once every interval, the first value is pushed at once
+// and the latest value given during the interval is delivered when it ends
+func (l *Linear) Throttle(key string, value interface{}, interval time.Duration) error {
+
+	// Execution conditions
+	if l.IsClosed() {
+		return ErrClosed
+	}
+
+	// Argument validator
+	if key == "" && value == nil {
+		return ErrInvalidKey
+	}
+
+	if interval <= 0 {
+		return ErrInvalidArgument
+	}
+
+	l.pendingMux.Lock()
+
+	if l.throttled == nil {
+		l.throttled = map[string]*pendingPush{}
+	}
+
+	if p, ok := l.throttled[key]; ok {
+		p.value = value
+		p.waiting = true
+		l.pendingMux.Unlock()
+		return nil
+	}
+
+	p := &pendingPush{}
+	l.throttled[key] = p
+
+	var trailing func()
+	trailing = func() {
+		l.pendingMux.Lock()
+		if l.throttled[key] != p {
+			l.pendingMux.Unlock()
+			return
+		}
+
+		// Nothing came during the interval, the key is free again
+		if !p.waiting {
+			delete(l.throttled, key)
+			l.pendingMux.Unlock()
+			return
+		}
+
+		value := p.value
+		p.value, p.waiting = nil, false
+		p.timer = time.AfterFunc(interval, trailing)
+		l.pendingMux.Unlock()
+
+		l.deliver(key, value)
+	}
+	p.timer = time.AfterFunc(interval, trailing)
+	l.pendingMux.Unlock()
+
+	return l.Upsert(key, value, KeepPosition)
+}
+
+// deliver upsert a value whose timer fired and log when it fails
+func (l *Linear) deliver(key string, value interface{}) {
+	if err := l.Upsert(key, value, KeepPosition); err != nil && !errors.Is(err, ErrClosed) {
+		l.logger.Printf("linear: delayed push of %q failed: %v", key, err)
+	}
+}
+
+// stopPending drop the values still waiting to be debounced, throttled or aggregated
+func (l *Linear) stopPending() {
+
+	l.pendingMux.Lock()
+	defer l.pendingMux.Unlock()
+
+	for _, p := range l.debounced {
+		p.timer.Stop()
+	}
+
+	for _, p := range l.throttled {
+		p.timer.Stop()
+	}
+
+	for _, p := range l.aggregated {
+		p.timer.Stop()
+	}
+
+	l.debounced, l.throttled, l.aggregated = nil, nil, nil
+}
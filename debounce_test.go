@@ -0,0 +1,72 @@
+package linear
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDebounce(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	linearClient := New(1024, false)
+	defer linearClient.Close()
+
+	// Testing
+	assert.True(errors.Is(linearClient.Debounce("1", "a", 0), ErrInvalidArgument))
+
+	for _, value := range []string{"a", "b", "c"} {
+		assert.Nil(linearClient.Debounce("1", value, 20*time.Millisecond))
+	}
+	assert.Equal(0, linearClient.GetNumberOfKeys())
+
+	assert.Eventually(func() bool { return linearClient.GetNumberOfKeys() == 1 }, time.Second, time.Millisecond)
+	time.Sleep(40 * time.Millisecond)
+	assert.Equal(1, linearClient.GetNumberOfKeys())
+
+	value, err := linearClient.Read("1")
+	assert.Nil(err)
+	if value != "c" {
+		t.Errorf("Debounce failed, expected %v, got %v", "c", value)
+	}
+}
+
+func TestThrottle(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	linearClient := New(1024, false)
+	defer linearClient.Close()
+
+	// Testing
+	assert.Nil(linearClient.Throttle("1", "a", 30*time.Millisecond))
+	value, _ := linearClient.Read("1")
+	assert.Equal("a", value)
+
+	assert.Nil(linearClient.Throttle("1", "b", 30*time.Millisecond))
+	assert.Nil(linearClient.Throttle("1", "c", 30*time.Millisecond))
+	value, _ = linearClient.Read("1")
+	assert.Equal("a", value)
+
+	assert.Eventually(func() bool {
+		value, _ := linearClient.Read("1")
+		return value == "c"
+	}, time.Second, time.Millisecond)
+	assert.Equal(1, linearClient.GetNumberOfKeys())
+}
+
+func TestCloseDropsPending(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	linearClient := New(1024, false)
+	assert.Nil(linearClient.Debounce("1", "a", 10*time.Millisecond))
+
+	// Testing
+	assert.Nil(linearClient.Close())
+	assert.True(errors.Is(linearClient.Debounce("1", "a", time.Millisecond), ErrClosed))
+	assert.True(errors.Is(linearClient.Throttle("1", "a", time.Millisecond), ErrClosed))
+}
@@ -0,0 +1,5 @@
+package linear
+
+// MutationReporter is called by lineardebug builds when a stored value was mutated after it was pushed
+// A nil reporter makes the linear panic instead
+var MutationReporter func(key string, value interface{})
@@ -0,0 +1,16 @@
+//go:build !lineardebug
+// +build !lineardebug
+
+package linear
+
+// debugTrack is a no-op without the lineardebug build tag
+func (l *Linear) debugTrack(key string, value interface{}) {}
+
+// debugCheck is a no-op without the lineardebug build tag
+func (l *Linear) debugCheck(key string, value interface{}) {}
+
+// debugForget is a no-op without the lineardebug build tag
+func (l *Linear) debugForget(key string, value interface{}) {}
+
+// debugReset is a no-op without the lineardebug build tag
+func (l *Linear) debugReset() {}
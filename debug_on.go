@@ -0,0 +1,59 @@
+//go:build lineardebug
+// +build lineardebug
+
+package linear
+
+import (
+	"fmt"
+	"sync"
+)
+
+// debugHashes keep the hash of every stored value, keyed by linear instance and key
+var debugHashes = struct {
+	sync.Mutex
+	values map[*Linear]map[string]uint64
+}{values: map[*Linear]map[string]uint64{}}
+
+// debugTrack remember the hash of the value stored at key
+func (l *Linear) debugTrack(key string, value interface{}) {
+	debugHashes.Lock()
+	if debugHashes.values[l] == nil {
+		debugHashes.values[l] = map[string]uint64{}
+	}
+	debugHashes.values[l][key] = hashValue(value)
+	debugHashes.Unlock()
+}
+
+// debugCheck compare the value stored at key with the hash taken when it was stored
+func (l *Linear) debugCheck(key string, value interface{}) {
+	debugHashes.Lock()
+	hash, ok := debugHashes.values[l][key]
+	debugHashes.Unlock()
+
+	if !ok || hash == hashValue(value) {
+		return
+	}
+
+	if MutationReporter != nil {
+		MutationReporter(key, value)
+		return
+	}
+
+	panic(fmt.Sprintf("linear: value of key %q was mutated after it was stored", key))
+}
+
+// debugForget check the value stored at key one last time and drop its hash
+func (l *Linear) debugForget(key string, value interface{}) {
+	l.debugCheck(key, value)
+
+	debugHashes.Lock()
+	delete(debugHashes.values[l], key)
+	debugHashes.Unlock()
+}
+
+// debugReset drop the hashes of every key
+func (l *Linear) debugReset() {
+	debugHashes.Lock()
+	delete(debugHashes.values, l)
+	debugHashes.Unlock()
+}
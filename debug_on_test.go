@@ -0,0 +1,49 @@
+//go:build lineardebug
+// +build lineardebug
+
+package linear
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMutationDetection(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	linearClient := New(1024, false)
+	value := []string{"a"}
+	linearClient.Push("1", value)
+
+	// Testing
+	assert.NotPanics(func() { linearClient.Read("1") })
+
+	value[0] = "b"
+	assert.Panics(func() { linearClient.Read("1") })
+
+	var reported string
+	MutationReporter = func(key string, value interface{}) { reported = key }
+	defer func() { MutationReporter = nil }()
+
+	linearClient.Take()
+	assert.Equal(reported, "1")
+}
+
+func TestCloseDropsMutationHashes(t *testing.T) {
+
+	// Setting up
+	linearClient := New(1024, false)
+	linearClient.Push("1", []string{"a"})
+
+	// Testing
+	linearClient.Close()
+
+	debugHashes.Lock()
+	_, tracked := debugHashes.values[linearClient]
+	debugHashes.Unlock()
+	if tracked {
+		t.Errorf("Close failed, expected %v, got %v", false, tracked)
+	}
+}
@@ -0,0 +1,67 @@
+package linear
+
+// Delete remove every occurrence of the key and its item out of the linear
+func (l *Linear) Delete(key string) error {
+
+	// Execution conditions
+	if l.IsClosed() {
+		return ErrClosed
+	}
+
+	acquired := l.lock(lockDelete)
+	deleted := l.deleteKey(key, Deleted)
+	l.unlock(lockDelete, acquired)
+
+	if !deleted {
+		return newError("delete", key, ErrKeyNotFound)
+	}
+
+	return nil
+}
+
+// DeleteMany remove the keys and their items out of the linear under a single lock and return how many keys were deleted
+// The returned error reports the first key that does not exit, the other keys are still deleted
+func (l *Linear) DeleteMany(keys ...string) (int, error) {
+
+	// Execution conditions
+	if l.IsClosed() {
+		return 0, ErrClosed
+	}
+
+	var (
+		deleted int
+		err     error
+	)
+
+	l.mux.Lock()
+	for _, key := range keys {
+		if l.deleteKey(key, Deleted) {
+			deleted++
+		} else if err == nil {
+			err = newError("delete", key, ErrKeyNotFound)
+		}
+	}
+	l.mux.Unlock()
+
+	return deleted, err
+}
+
+// deleteKey remove every occurrence of key, spilled ones included, send eventType and report if there was any, caller must hold mux
+func (l *Linear) deleteKey(key string, eventType EventType) bool {
+
+	spilled, wasSpilled := l.deleteSpilled(key)
+	item, exits := l.items.Load(key)
+	if !exits {
+		if wasSpilled {
+			l.emit(eventType, key, spilled)
+		}
+		return wasSpilled
+	}
+
+	for n := l.keys.first(key); n != nil; n = l.keys.first(key) {
+		l.removeNode(n, item)
+	}
+	l.emit(eventType, key, item)
+
+	return true
+}
@@ -0,0 +1,46 @@
+package linear
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDelete(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	linearClient := New(1024, false)
+	linearClient.Push("1", "a")
+	linearClient.Push("2", "b")
+	linearClient.Push("1", "c")
+
+	// Testing
+	assert.Nil(linearClient.Delete("1"))
+	assert.Equal(linearClient.Getkeys(), []string{"2"})
+
+	assert.True(errors.Is(linearClient.Delete("1"), ErrKeyNotFound))
+	assert.Nil(linearClient.CheckSize())
+}
+
+func TestDeleteMany(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	linearClient := New(1024, false)
+	linearClient.Push("1", "a")
+	linearClient.Push("2", "b")
+	linearClient.Push("3", "c")
+
+	// Testing
+	deleted, err := linearClient.DeleteMany("1", "4", "3")
+	assert.Equal(deleted, 2)
+	assert.True(errors.Is(err, ErrKeyNotFound))
+	assert.Equal(linearClient.Getkeys(), []string{"2"})
+
+	deleted, err = linearClient.DeleteMany("2")
+	assert.Equal(deleted, 1)
+	assert.Nil(err)
+	assert.Equal(linearClient.GetLinearCurrentSize(), int64(0))
+}
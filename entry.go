@@ -0,0 +1,50 @@
+package linear
+
+import (
+	"time"
+)
+
+// Entry is one key occurrence of the linear with its value
+type Entry struct {
+	Key      string
+	Value    interface{}
+	Size     int64     // Bytes accounted for the occurrence, key and value
+	PushedAt time.Time // When the occurrence was pushed, or restored from a snapshot
+}
+
+// entryOf return the entry of n, caller must hold mux
+func (l *Linear) entryOf(n *node) Entry {
+	value, _ := l.items.Load(n.key)
+	return Entry{
+		Key:      n.key,
+		Value:    value,
+		Size:     calculateKeySize(n.key) + l.valueSizes[n.key],
+		PushedAt: time.Unix(0, n.pushed),
+	}
+}
+
+// nodePushed stamp the new occurrence n and add it to the priorities, caller must hold mux
+func (l *Linear) nodePushed(n *node) {
+	n.pushed = time.Now().UnixNano()
+	l.priorityPushed(n)
+}
+
+// Entries return up to limit entries from the front, every entry when limit is 0 or lower
+func (l *Linear) Entries(limit int) []Entry {
+
+	l.mux.RLock()
+	defer l.mux.RUnlock()
+
+	if limit <= 0 || limit > l.keys.len {
+		limit = l.keys.len
+	}
+
+	entries := make([]Entry, 0, limit)
+	for n := l.keys.head; n != nil && len(entries) < limit; n = n.next {
+		entry := l.entryOf(n)
+		entry.Value = l.resolve(entry.Value)
+		entries = append(entries, entry)
+	}
+
+	return entries
+}
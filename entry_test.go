@@ -0,0 +1,52 @@
+package linear
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEntries(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	linearClient := New(1024, false)
+	before := time.Now()
+	linearClient.Push("1", "a")
+	linearClient.Push("2", "b")
+	linearClient.Push("3", "c")
+
+	// Testing
+	entry, err := linearClient.PopEntry()
+	assert.Nil(err)
+	assert.Equal("3", entry.Key)
+	assert.Equal("c", entry.Value)
+	assert.Equal(calculateItemSize("3", "c"), entry.Size)
+	if entry.PushedAt.Before(before) || entry.PushedAt.After(time.Now()) {
+		t.Errorf("PopEntry failed, expected PushedAt after %v, got %v", before, entry.PushedAt)
+	}
+
+	entry, _ = linearClient.TakeEntry()
+	assert.Equal("1", entry.Key)
+
+	entry, err = linearClient.GetEntry("2")
+	assert.Nil(err)
+	assert.Equal("b", entry.Value)
+	assert.True(linearClient.IsEmpty())
+
+	_, err = linearClient.TakeEntry()
+	assert.True(errors.Is(err, ErrEmpty))
+	linearClient.Push("4", "d")
+	_, err = linearClient.GetEntry("5")
+	assert.True(errors.Is(err, ErrKeyNotFound))
+
+	linearClient.Push("5", "e")
+	linearClient.Push("4", "d")
+	entries := linearClient.Entries(0)
+	assert.Len(entries, 3)
+	assert.Equal("5", entries[1].Key)
+	assert.Equal(calculateItemSize("4", "d"), entries[2].Size)
+	assert.Len(linearClient.Entries(2), 2)
+}
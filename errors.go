@@ -0,0 +1,57 @@
+package linear
+
+import (
+	"errors"
+	"strconv"
+)
+
+var (
+	// ErrInvalidSize is returned when a linear size is not higher than 0
+	ErrInvalidSize = errors.New("linearSizes much higher than 0")
+	// ErrInvalidKey is returned when a key is empty
+	ErrInvalidKey = errors.New("key should not be empty")
+	// ErrInvalidValue is returned when a value doesn't have the type the operation needs
+	ErrInvalidValue = errors.New("value has an unsupported type")
+	// ErrInvalidArgument is returned when an argument other than the key or value is not valid
+	ErrInvalidArgument = errors.New("invalid argument")
+	// ErrEmpty is returned when the linear has no item to operate on
+	ErrEmpty = errors.New("linear is empty")
+	// ErrKeyNotFound is returned when the key does not exit in the linear
+	ErrKeyNotFound = errors.New("key does not exit")
+	// ErrKeyExists is returned when the key already exits in the linear
+	ErrKeyExists = errors.New("key already exits")
+	// ErrCapacityExceeded is returned when the item doesn't fit in the linear size
+	ErrCapacityExceeded = errors.New("linear doesn't have enough memory space")
+	// ErrFull is returned when the linear reached its maximum number of items and rejects new ones
+	ErrFull = errors.New("linear is full")
+	// ErrCircuitOpen is returned when the loaders failed too often and are not called until the WithLoaderBreaker cool-down ends
+	ErrCircuitOpen = errors.New("loader circuit is open")
+	// ErrClosed is returned when the linear is closed
+	ErrClosed = errors.New("linear is closed")
+	// ErrCorrupted is returned when serialized linear state fails validation
+	ErrCorrupted = errors.New("linear state is corrupted")
+	// ErrUnsupportedVersion is returned when serialized linear state has an unknown format version
+	ErrUnsupportedVersion = errors.New("linear state version is not supported")
+)
+
+// Error records the operation and key that caused an error, Err is one of the sentinel errors
+type Error struct {
+	Op  string
+	Key string
+	Err error
+}
+
+// newError return an *Error for the operation on key
+func newError(op, key string, err error) error {
+	return &Error{Op: op, Key: key, Err: err}
+}
+
+// Error return the error message
+func (e *Error) Error() string {
+	return e.Op + " " + strconv.Quote(e.Key) + ": " + e.Err.Error()
+}
+
+// Unwrap return the underlying sentinel error
+func (e *Error) Unwrap() error {
+	return e.Err
+}
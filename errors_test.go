@@ -0,0 +1,44 @@
+package linear
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrors(t *testing.T) {
+	assert := assert.New(t)
+
+	linearClient := New(64, false)
+
+	// Testing
+	_, err := linearClient.Pop()
+	assert.True(errors.Is(err, ErrEmpty))
+
+	_, err = linearClient.Take()
+	assert.True(errors.Is(err, ErrEmpty))
+
+	assert.True(errors.Is(linearClient.Push("", nil), ErrInvalidKey))
+
+	linearClient.Push("1", "a")
+
+	_, err = linearClient.Get("2")
+	assert.True(errors.Is(err, ErrKeyNotFound))
+
+	_, err = linearClient.Read("2")
+	assert.True(errors.Is(err, ErrKeyNotFound))
+
+	var linearErr *Error
+	assert.True(errors.As(err, &linearErr))
+	assert.Equal(linearErr.Op, "read")
+	assert.Equal(linearErr.Key, "2")
+	assert.Equal(err.Error(), `read "2": key does not exit`)
+
+	assert.True(errors.Is(linearClient.Update("2", "b"), ErrKeyNotFound))
+	assert.True(errors.Is(linearClient.Alias("1", "1"), ErrKeyExists))
+	assert.True(errors.Is(linearClient.PushReader("4", nil, 1), ErrInvalidArgument))
+
+	_, err = linearClient.PushContent(1)
+	assert.True(errors.Is(err, ErrInvalidValue))
+}
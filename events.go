@@ -0,0 +1,121 @@
+package linear
+
+import (
+	"sync/atomic"
+)
+
+// EventType is the kind of change an Event reports
+type EventType int
+
+const (
+	// Pushed is sent when an item is pushed
+	Pushed EventType = iota
+	// Updated is sent when the value of a key is replaced
+	Updated
+	// Popped is sent when an item is removed from the back
+	Popped
+	// Taken is sent when an item is removed from the front or by key
+	Taken
+	// Deleted is sent when a key is deleted
+	Deleted
+	// Evicted is sent when an item is removed to make room
+	Evicted
+	// Expired is sent when a key is removed once its TTL elapsed
+	Expired
+)
+
+// String return the name of the event type
+func (t EventType) String() string {
+	switch t {
+	case Pushed:
+		return "pushed"
+	case Updated:
+		return "updated"
+	case Popped:
+		return "popped"
+	case Taken:
+		return "taken"
+	case Deleted:
+		return "deleted"
+	case Evicted:
+		return "evicted"
+	case Expired:
+		return "expired"
+	}
+	return "unknown"
+}
+
+// Event is a change of the linear sent to subscribers
+type Event struct {
+	Type  EventType
+	Key   string
+	Value interface{}
+}
+
+// subscription is a channel receiving events
+type subscription struct {
+	events chan Event
+}
+
+// Subscribe return a channel receiving the events of the linear and the function ending the subscription
+// Events are sent without blocking, those that don't fit in buffer are dropped and counted in Stats.
+// The channel is closed by the returned function or by Close
+func (l *Linear) Subscribe(buffer int) (<-chan Event, func()) {
+
+	// Argument validator
+	if buffer < 0 {
+		buffer = 0
+	}
+
+	s := &subscription{events: make(chan Event, buffer)}
+
+	l.mux.Lock()
+	defer l.mux.Unlock()
+
+	// Execution conditions
+	if l.IsClosed() {
+		close(s.events)
+		return s.events, func() {}
+	}
+
+	l.subscribers = append(l.subscribers, s)
+
+	return s.events, func() {
+		l.mux.Lock()
+		defer l.mux.Unlock()
+
+		for i, subscribed := range l.subscribers {
+			if subscribed == s {
+				l.subscribers = append(l.subscribers[:i], l.subscribers[i+1:]...)
+				close(s.events)
+				return
+			}
+		}
+	}
+}
+
+// emit send the event to every subscriber that has room for it, caller must hold mux
+func (l *Linear) emit(eventType EventType, key string, value interface{}) {
+	if len(l.subscribers) > 0 {
+		value = l.resolve(value)
+	}
+	for _, s := range l.subscribers {
+		select {
+		case s.events <- Event{Type: eventType, Key: key, Value: value}:
+		default:
+			atomic.AddInt64(&l.stats.droppedEvents, 1)
+		}
+	}
+}
+
+// closeSubscribers close the channel of every subscriber
+func (l *Linear) closeSubscribers() {
+
+	l.mux.Lock()
+	defer l.mux.Unlock()
+
+	for _, s := range l.subscribers {
+		close(s.events)
+	}
+	l.subscribers = nil
+}
@@ -0,0 +1,79 @@
+package linear
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubscribe(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	linearClient, _ := NewWithOptions(WithMaxItems(3), WithWheelTick(time.Millisecond))
+	events, unsubscribe := linearClient.Subscribe(16)
+
+	// Testing
+	linearClient.Push("1", "a")
+	linearClient.Push("2", "b")
+	linearClient.Push("3", "c")
+	linearClient.Push("4", "d")
+	linearClient.Update("2", "B")
+	linearClient.Pop()
+	linearClient.Take()
+	linearClient.Delete("3")
+	linearClient.PushWithTTL("5", "e", time.Millisecond)
+
+	expected := []Event{
+		{Pushed, "1", "a"},
+		{Pushed, "2", "b"},
+		{Pushed, "3", "c"},
+		{Evicted, "1", "a"},
+		{Pushed, "4", "d"},
+		{Updated, "2", "B"},
+		{Popped, "4", "d"},
+		{Taken, "2", "B"},
+		{Deleted, "3", "c"},
+		{Pushed, "5", "e"},
+		{Expired, "5", "e"},
+	}
+	for _, event := range expected {
+		select {
+		case received := <-events:
+			assert.Equal(event, received)
+		case <-time.After(time.Second):
+			t.Fatalf("Subscribe failed, expected %v, got no event", event)
+		}
+	}
+
+	unsubscribe()
+	unsubscribe()
+	_, open := <-events
+	assert.False(open)
+	assert.Nil(linearClient.Push("6", "f"))
+	linearClient.Close()
+}
+
+func TestSubscribeDrops(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	linearClient := New(1024, false)
+	events, _ := linearClient.Subscribe(1)
+
+	// Testing
+	linearClient.Push("1", "a")
+	linearClient.Push("2", "b")
+	linearClient.Push("3", "c")
+	assert.Equal(int64(2), linearClient.Stats().DroppedEvents)
+
+	linearClient.Close()
+	assert.Equal(Event{Pushed, "1", "a"}, <-events)
+	_, open := <-events
+	assert.False(open)
+
+	closed, _ := linearClient.Subscribe(1)
+	_, open = <-closed
+	assert.False(open)
+}
@@ -0,0 +1,336 @@
+package linear
+
+import (
+	"container/heap"
+	"container/list"
+	"math/rand"
+	"sync"
+	"sync/atomic"
+)
+
+// EvictionPolicy choose the item evicted when the linear needs room for a new one
+// Accessed is called by readers without the linear lock, so implementations must be safe for concurrent use
+type EvictionPolicy interface {
+	// Pushed is called for every occurrence of a key added to the linear
+	Pushed(key string)
+	// Accessed is called when the value of a key is read or updated
+	Accessed(key string)
+	// Removed is called when the last occurrence of a key leaves the linear
+	Removed(key string)
+	// Victim return the key to evict, the front-most occurrence of it is removed
+	Victim() (string, bool)
+}
+
+// WithEvictionPolicy set the policy choosing the evicted items, items are evicted from the front by default
+func WithEvictionPolicy(policy EvictionPolicy) Option {
+	return func(l *Linear) {
+		l.eviction = policy
+	}
+}
+
+// evict remove an item chosen by the eviction policy to make room and report if it could, caller must hold mux
+// Borrowed keys are skipped in favour of the oldest key that isn't borrowed
+func (l *Linear) evict(front bool) bool {
+
+	oldest, newest := l.keys.head, l.keys.tail
+	if front {
+		oldest, newest = newest, oldest // Pushing to the front reverses the ages
+	}
+
+	var n *node
+	switch policy := l.eviction.(type) {
+	case nil, fifoPolicy:
+		n = oldest
+	case lifoPolicy:
+		n = newest
+	default:
+		n = l.keys.head // A victim not in the linear falls back to the front
+		if key, ok := policy.Victim(); ok {
+			if first := l.keys.first(key); first != nil {
+				n = first
+			}
+		}
+	}
+
+	// The priority order replaces the eviction policy
+	if l.priorities != nil {
+		n = l.priorities.last()
+	}
+
+	if n != nil && l.borrowed[n.key] > 0 {
+		n = nil
+		for candidate := l.keys.head; candidate != nil; candidate = candidate.next {
+			if l.borrowed[candidate.key] == 0 {
+				n = candidate
+				break
+			}
+		}
+	}
+
+	if n == nil {
+		return false
+	}
+
+	if l.spill != nil {
+		key := n.key
+		err := l.spillNode(n)
+		if err == nil {
+			return true
+		}
+		l.logger.Printf("linear: spilling %q failed, evicting it: %v", key, err)
+	}
+
+	l.evictNode(n)
+	return true
+}
+
+// evictNode remove n to make room, caller must hold mux
+func (l *Linear) evictNode(n *node) {
+	key := n.key
+	item, _ := l.items.Load(key)
+	l.removeNode(n, item)
+	l.emit(Evicted, key, item)
+	atomic.AddInt64(&l.stats.evictions, 1)
+}
+
+// evictionPushed tell the eviction policy a key occurrence was added, caller must hold mux
+func (l *Linear) evictionPushed(key string) {
+	if l.eviction != nil {
+		l.eviction.Pushed(key)
+	}
+}
+
+// evictionAccessed tell the eviction policy a key was used
+func (l *Linear) evictionAccessed(key string) {
+	if l.eviction != nil {
+		l.eviction.Accessed(key)
+	}
+}
+
+// evictionRemoved tell the eviction policy a key left, caller must hold mux
+func (l *Linear) evictionRemoved(key string) {
+	if l.eviction != nil {
+		l.eviction.Removed(key)
+	}
+}
+
+type fifoPolicy struct{}
+
+// FIFO evict the oldest item first, it is the default policy
+func FIFO() EvictionPolicy {
+	return fifoPolicy{}
+}
+
+func (fifoPolicy) Pushed(string)          {}
+func (fifoPolicy) Accessed(string)        {}
+func (fifoPolicy) Removed(string)         {}
+func (fifoPolicy) Victim() (string, bool) { return "", false }
+
+type lifoPolicy struct{}
+
+// LIFO evict the newest item first
+func LIFO() EvictionPolicy {
+	return lifoPolicy{}
+}
+
+func (lifoPolicy) Pushed(string)          {}
+func (lifoPolicy) Accessed(string)        {}
+func (lifoPolicy) Removed(string)         {}
+func (lifoPolicy) Victim() (string, bool) { return "", false }
+
+type lruPolicy struct {
+	mux     sync.Mutex
+	order   *list.List // Least recently used first
+	entries map[string]*list.Element
+}
+
+// LRU evict the least recently pushed or read key first
+func LRU() EvictionPolicy {
+	return &lruPolicy{order: list.New(), entries: map[string]*list.Element{}}
+}
+
+// Pushed mark the key as the most recently used
+func (p *lruPolicy) Pushed(key string) {
+	p.mux.Lock()
+	if e, ok := p.entries[key]; ok {
+		p.order.MoveToBack(e)
+	} else {
+		p.entries[key] = p.order.PushBack(key)
+	}
+	p.mux.Unlock()
+}
+
+// Accessed mark the key as the most recently used
+func (p *lruPolicy) Accessed(key string) {
+	p.mux.Lock()
+	if e, ok := p.entries[key]; ok {
+		p.order.MoveToBack(e)
+	}
+	p.mux.Unlock()
+}
+
+// Removed forget the key
+func (p *lruPolicy) Removed(key string) {
+	p.mux.Lock()
+	if e, ok := p.entries[key]; ok {
+		p.order.Remove(e)
+		delete(p.entries, key)
+	}
+	p.mux.Unlock()
+}
+
+// Victim return the least recently used key
+func (p *lruPolicy) Victim() (string, bool) {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+
+	if e := p.order.Front(); e != nil {
+		return e.Value.(string), true
+	}
+	return "", false
+}
+
+type lfuEntry struct {
+	key   string
+	count int64
+	seq   uint64 // Breaks ties, the oldest use first
+	index int
+}
+
+type lfuHeap []*lfuEntry
+
+func (h lfuHeap) Len() int { return len(h) }
+func (h lfuHeap) Less(i, j int) bool {
+	if h[i].count != h[j].count {
+		return h[i].count < h[j].count
+	}
+	return h[i].seq < h[j].seq
+}
+func (h lfuHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index, h[j].index = i, j
+}
+func (h *lfuHeap) Push(x interface{}) {
+	e := x.(*lfuEntry)
+	e.index = len(*h)
+	*h = append(*h, e)
+}
+func (h *lfuHeap) Pop() interface{} {
+	old := *h
+	e := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return e
+}
+
+type lfuPolicy struct {
+	mux     sync.Mutex
+	heap    lfuHeap
+	entries map[string]*lfuEntry
+	seq     uint64
+}
+
+// LFU evict the least frequently pushed or read key first, the least recently used among equals
+func LFU() EvictionPolicy {
+	return &lfuPolicy{entries: map[string]*lfuEntry{}}
+}
+
+// Pushed count a use of the key
+func (p *lfuPolicy) Pushed(key string) {
+	p.mux.Lock()
+	if !p.use(key) {
+		p.seq++
+		e := &lfuEntry{key: key, count: 1, seq: p.seq}
+		p.entries[key] = e
+		heap.Push(&p.heap, e)
+	}
+	p.mux.Unlock()
+}
+
+// Accessed count a use of the key
+func (p *lfuPolicy) Accessed(key string) {
+	p.mux.Lock()
+	p.use(key)
+	p.mux.Unlock()
+}
+
+// use count a use of a known key and report if it was known
+func (p *lfuPolicy) use(key string) bool {
+	e, ok := p.entries[key]
+	if ok {
+		p.seq++
+		e.count++
+		e.seq = p.seq
+		heap.Fix(&p.heap, e.index)
+	}
+	return ok
+}
+
+// Removed forget the key
+func (p *lfuPolicy) Removed(key string) {
+	p.mux.Lock()
+	if e, ok := p.entries[key]; ok {
+		heap.Remove(&p.heap, e.index)
+		delete(p.entries, key)
+	}
+	p.mux.Unlock()
+}
+
+// Victim return the least frequently used key
+func (p *lfuPolicy) Victim() (string, bool) {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+
+	if len(p.heap) == 0 {
+		return "", false
+	}
+	return p.heap[0].key, true
+}
+
+type randomPolicy struct {
+	mux   sync.Mutex
+	keys  []string
+	index map[string]int
+	rand  *rand.Rand
+}
+
+// Random evict a random key
+func Random(seed int64) EvictionPolicy {
+	return &randomPolicy{index: map[string]int{}, rand: rand.New(rand.NewSource(seed))}
+}
+
+// Pushed add the key to the candidates
+func (p *randomPolicy) Pushed(key string) {
+	p.mux.Lock()
+	if _, ok := p.index[key]; !ok {
+		p.index[key] = len(p.keys)
+		p.keys = append(p.keys, key)
+	}
+	p.mux.Unlock()
+}
+
+// Accessed does nothing, random eviction ignores uses
+func (p *randomPolicy) Accessed(string) {}
+
+// Removed remove the key from the candidates
+func (p *randomPolicy) Removed(key string) {
+	p.mux.Lock()
+	if i, ok := p.index[key]; ok {
+		last := len(p.keys) - 1
+		p.keys[i] = p.keys[last]
+		p.index[p.keys[i]] = i
+		p.keys = p.keys[:last]
+		delete(p.index, key)
+	}
+	p.mux.Unlock()
+}
+
+// Victim return a random key
+func (p *randomPolicy) Victim() (string, bool) {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+
+	if len(p.keys) == 0 {
+		return "", false
+	}
+	return p.keys[p.rand.Intn(len(p.keys))], true
+}
@@ -0,0 +1,76 @@
+package linear
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvictionPolicy(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	cases := map[string]struct {
+		policy   EvictionPolicy
+		expected []string
+	}{
+		"fifo": {FIFO(), []string{"2", "3", "4"}},
+		"lifo": {LIFO(), []string{"1", "2", "4"}},
+		"lru":  {LRU(), []string{"2", "3", "4"}},
+		"lfu":  {LFU(), []string{"1", "3", "4"}},
+	}
+
+	// Testing
+	for name, c := range cases {
+		linearClient, _ := NewWithOptions(WithMaxItems(3), WithEvictionPolicy(c.policy))
+		linearClient.Push("1", "a")
+		linearClient.Push("2", "b")
+		linearClient.Push("3", "c")
+		linearClient.Read("1")
+		linearClient.Read("1")
+		linearClient.Read("2")
+		linearClient.Read("3")
+
+		assert.Nil(linearClient.Push("4", "d"), name)
+		assert.Equal(c.expected, linearClient.Getkeys(), name)
+		assert.Equal(int64(1), linearClient.Stats().Evictions, name)
+		assert.Nil(linearClient.CheckSize(), name)
+	}
+}
+
+func TestRandomEviction(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	policy := Random(1)
+	linearClient, _ := NewWithOptions(WithMaxItems(10), WithEvictionPolicy(policy))
+
+	// Testing
+	for i := 0; i < 100; i++ {
+		assert.Nil(linearClient.Push(string(rune('a'+i%26))+string(rune('a'+i/26)), i))
+	}
+	assert.Equal(10, linearClient.GetNumberOfKeys())
+	assert.Len(policy.(*randomPolicy).keys, 10)
+	assert.Empty(linearClient.Verify())
+}
+
+func TestEvictionPolicyForgetsRemovedKeys(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	policy := LRU()
+	linearClient, _ := NewWithOptions(WithEvictionPolicy(policy))
+	linearClient.Push("1", "a")
+	linearClient.Push("1", "a")
+	linearClient.Push("2", "b")
+
+	// Testing
+	linearClient.Take()
+	assert.Len(policy.(*lruPolicy).entries, 2)
+	linearClient.Delete("1")
+	assert.Len(policy.(*lruPolicy).entries, 1)
+
+	key, ok := policy.Victim()
+	assert.True(ok)
+	assert.Equal("2", key)
+}
@@ -7,7 +7,11 @@ import (
 )
 
 func main() {
-	linearClient := linear.New(1024, false)
+	linearClient, err := linear.NewWithError(1024, false)
+	if err != nil {
+		log.Fatalln(err)
+	}
+
 	if err := linearClient.Push("1", "a"); err != nil {
 		log.Fatalln(err)
 	}
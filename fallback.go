@@ -0,0 +1,18 @@
+package linear
+
+// WithFallback consult fallback when Read misses, to serve defaults or last-known-good values from a secondary source
+// The fallback value is returned as is and is not pushed to the linear
+func WithFallback(fallback func(key string) (interface{}, bool)) Option {
+	return func(l *Linear) {
+		l.fallback = fallback
+	}
+}
+
+// readFallback return the fallback value of key if there is one
+func (l *Linear) readFallback(key string) (interface{}, bool) {
+	if l.fallback == nil {
+		return nil, false
+	}
+
+	return l.fallback(key)
+}
@@ -0,0 +1,41 @@
+package linear
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithFallback(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	linearClient := New(1024, false, WithFallback(func(key string) (interface{}, bool) {
+		if key == "default" {
+			return "z", true
+		}
+		return nil, false
+	}))
+
+	// Testing
+	value, err := linearClient.Read("default")
+	assert.Nil(err)
+	assert.Equal(value, "z")
+
+	linearClient.Push("1", "a")
+
+	value, err = linearClient.Read("1")
+	assert.Nil(err)
+	assert.Equal(value, "a")
+
+	value, err = linearClient.Read("default")
+	assert.Nil(err)
+	assert.Equal(value, "z")
+
+	value, err = linearClient.Read("2")
+	assert.True(errors.Is(err, ErrKeyNotFound))
+	assert.Nil(value)
+
+	assert.Equal(linearClient.GetNumberOfKeys(), 1)
+}
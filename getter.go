@@ -0,0 +1,124 @@
+package linear
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// Sink receive the bytes of a Getter, groupcache.Sink satisfies it
+type Sink interface {
+	SetBytes(b []byte) error
+}
+
+// Getter serve keys from the linear and load the missing ones once for all concurrent callers
+// Wrap it with groupcache.GetterFunc to use the linear as the local tier of a groupcache group:
+//
+//	groupcache.GetterFunc(func(ctx context.Context, key string, dest groupcache.Sink) error {
+//		return getter.Get(ctx, key, dest)
+//	})
+type Getter struct {
+	l       *Linear
+	load    func(ctx context.Context, key string) ([]byte, error)
+	flights flightGroup
+}
+
+// NewGetter return a getter over l, load fetches the keys missing from the linear
+func NewGetter(l *Linear, load func(ctx context.Context, key string) ([]byte, error)) (*Getter, error) {
+
+	// Argument validator
+	if l == nil || load == nil {
+		return nil, ErrInvalidArgument
+	}
+
+	return &Getter{l: l, load: load}, nil
+}
+
+// Get set dest to the value of the key, loading and pushing it when the linear doesn't hold it
+// Values must be []byte or string
+func (g *Getter) Get(ctx context.Context, key string, dest Sink) error {
+
+	value, err := g.fetch(ctx, key)
+	if err != nil {
+		return err
+	}
+
+	switch value := value.(type) {
+	case []byte:
+		return dest.SetBytes(value)
+	case string:
+		return dest.SetBytes([]byte(value))
+	}
+
+	return fmt.Errorf("%w: value of %q is %T, not bytes", ErrInvalidArgument, key, value)
+}
+
+// fetch return the value of the key from the linear or the loader
+func (g *Getter) fetch(ctx context.Context, key string) (interface{}, error) {
+
+	if value, err := g.l.Read(key); err == nil {
+		return value, nil
+	} else if !errors.Is(err, ErrKeyNotFound) && !errors.Is(err, ErrEmpty) {
+		return nil, err
+	}
+
+	return g.flights.do(key, func() (interface{}, error) {
+
+		// Loaded by a call that finished since the read
+		if value, err := g.l.Read(key); err == nil {
+			return value, nil
+		}
+
+		value, err := g.load(ctx, key)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := g.l.Upsert(key, value, KeepPosition); err != nil {
+			return nil, err
+		}
+
+		return value, nil
+	})
+}
+
+// flightCall is a load in progress or done
+type flightCall struct {
+	done  chan struct{}
+	value interface{}
+	err   error
+}
+
+// flightGroup run one call per key at a time, concurrent callers of the same key share its result
+type flightGroup struct {
+	mux   sync.Mutex
+	calls map[string]*flightCall
+}
+
+// do run fn for key unless a call for key is in progress, in which case it waits for its result
+func (f *flightGroup) do(key string, fn func() (interface{}, error)) (interface{}, error) {
+
+	f.mux.Lock()
+	if call, ok := f.calls[key]; ok {
+		f.mux.Unlock()
+		<-call.done
+		return call.value, call.err
+	}
+
+	if f.calls == nil {
+		f.calls = map[string]*flightCall{}
+	}
+	call := &flightCall{done: make(chan struct{})}
+	f.calls[key] = call
+	f.mux.Unlock()
+
+	call.value, call.err = fn()
+
+	f.mux.Lock()
+	delete(f.calls, key)
+	f.mux.Unlock()
+	close(call.done)
+
+	return call.value, call.err
+}
@@ -0,0 +1,75 @@
+package linear
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// byteSink keep the bytes it is set to
+type byteSink struct {
+	b []byte
+}
+
+func (s *byteSink) SetBytes(b []byte) error {
+	s.b = append([]byte(nil), b...)
+	return nil
+}
+
+func TestGetter(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	linearClient := New(1024, false)
+	var loads int32
+	release := make(chan struct{})
+	getter, err := NewGetter(linearClient, func(ctx context.Context, key string) ([]byte, error) {
+		atomic.AddInt32(&loads, 1)
+		<-release
+		if key == "missing" {
+			return nil, ErrKeyNotFound
+		}
+		return []byte("value of " + key), nil
+	})
+	assert.Nil(err)
+
+	// Testing
+	var wg sync.WaitGroup
+	sinks := make([]byteSink, 10)
+	for i := range sinks {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			assert.Nil(getter.Get(context.Background(), "a", &sinks[i]))
+		}(i)
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if n := atomic.LoadInt32(&loads); n != 1 {
+		t.Errorf("Get failed, expected %v, got %v", 1, n)
+	}
+	for i := range sinks {
+		assert.Equal("value of a", string(sinks[i].b))
+	}
+
+	// Served from the linear
+	var sink byteSink
+	assert.Nil(getter.Get(context.Background(), "a", &sink))
+	assert.Equal(int32(1), atomic.LoadInt32(&loads))
+
+	assert.True(errors.Is(getter.Get(context.Background(), "missing", &sink), ErrKeyNotFound))
+
+	linearClient.Push("number", 1)
+	assert.True(errors.Is(getter.Get(context.Background(), "number", &sink), ErrInvalidArgument))
+
+	_, err = NewGetter(linearClient, nil)
+	assert.True(errors.Is(err, ErrInvalidArgument))
+}
@@ -0,0 +1,110 @@
+package linear
+
+import (
+	"sort"
+	"time"
+)
+
+// historyTopKeys is the number of largest keys recorded in every history entry
+const historyTopKeys = 5
+
+// HistoryEntry is a summary of the linear at one point in time
+type HistoryEntry struct {
+	At      time.Time
+	Items   int
+	Bytes   int64
+	Stats   Stats
+	TopKeys []string // Largest keys first
+}
+
+// WithHistory record a summary of the linear every interval, keeping the last size summaries for History
+func WithHistory(interval time.Duration, size int) Option {
+	return func(l *Linear) {
+		l.historyInterval = interval
+		l.history = make([]HistoryEntry, 0, size)
+		l.historySize = size
+	}
+}
+
+// History return the recorded summaries, oldest first
+func (l *Linear) History() []HistoryEntry {
+
+	l.historyMux.Lock()
+	defer l.historyMux.Unlock()
+
+	history := make([]HistoryEntry, 0, len(l.history))
+	history = append(history, l.history[l.historyNext:]...)
+	history = append(history, l.history[:l.historyNext]...)
+
+	return history
+}
+
+// startHistory record a summary every historyInterval in the background
+func (l *Linear) startHistory() {
+	l.startWorker(func(done <-chan struct{}) {
+		ticker := time.NewTicker(l.historyInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-done:
+				return
+			case now := <-ticker.C:
+				l.record(l.summary(now))
+			}
+		}
+	})
+}
+
+// summary return the summary of the linear at now
+func (l *Linear) summary(now time.Time) HistoryEntry {
+
+	l.mux.RLock()
+	entry := HistoryEntry{
+		At:    now,
+		Items: l.keys.len,
+		Bytes: l.linearCurrentSize,
+	}
+
+	type keySize struct {
+		key  string
+		size int64
+	}
+	top := make([]keySize, 0, historyTopKeys+1)
+	for key, valueSize := range l.valueSizes {
+		size := calculateKeySize(key) + valueSize
+		if len(top) == historyTopKeys && size <= top[len(top)-1].size {
+			continue
+		}
+		i := sort.Search(len(top), func(i int) bool { return top[i].size < size })
+		top = append(top, keySize{})
+		copy(top[i+1:], top[i:])
+		top[i] = keySize{key, size}
+		if len(top) > historyTopKeys {
+			top = top[:historyTopKeys]
+		}
+	}
+	l.mux.RUnlock()
+
+	entry.Stats = l.Stats()
+	for _, item := range top {
+		entry.TopKeys = append(entry.TopKeys, item.key)
+	}
+
+	return entry
+}
+
+// record add entry to the ring, overwriting the oldest one once it is full
+func (l *Linear) record(entry HistoryEntry) {
+
+	l.historyMux.Lock()
+	defer l.historyMux.Unlock()
+
+	if len(l.history) < l.historySize {
+		l.history = append(l.history, entry)
+		return
+	}
+
+	l.history[l.historyNext] = entry
+	l.historyNext = (l.historyNext + 1) % l.historySize
+}
@@ -0,0 +1,56 @@
+package linear
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHistory(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	linearClient, err := NewWithOptions(WithHistory(time.Hour, 3))
+	assert.Nil(err)
+	defer linearClient.Close()
+
+	for i, key := range []string{"a", "b", "c", "d", "e", "f"} {
+		linearClient.Push(key, strings.Repeat("x", i))
+	}
+
+	// Testing
+	assert.Empty(linearClient.History())
+
+	start := time.Now()
+	for i := 0; i < 4; i++ {
+		linearClient.record(linearClient.summary(start.Add(time.Duration(i) * time.Second)))
+	}
+
+	history := linearClient.History()
+	assert.Len(history, 3)
+	assert.Equal(start.Add(time.Second), history[0].At)
+	assert.Equal(start.Add(3*time.Second), history[2].At)
+	assert.Equal(6, history[2].Items)
+	assert.Equal(linearClient.GetLinearCurrentSize(), history[2].Bytes)
+	assert.Equal([]string{"f", "e", "d", "c", "b"}, history[2].TopKeys)
+	assert.Equal(int64(6), history[2].Stats.Pushes)
+
+	_, err = NewWithOptions(WithHistory(time.Second, 0))
+	assert.True(errors.Is(err, ErrInvalidArgument))
+}
+
+func TestHistoryWorker(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	linearClient, _ := NewWithOptions(WithHistory(time.Millisecond, 2))
+	linearClient.Push("1", "a")
+
+	// Testing
+	assert.Eventually(func() bool { return len(linearClient.History()) == 2 }, time.Second, time.Millisecond)
+	assert.Nil(linearClient.Close())
+	assert.Equal(0, linearClient.Goroutines())
+}
@@ -0,0 +1,125 @@
+package httpserver
+
+import (
+	"embed"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang-common-packages/linear"
+)
+
+// adminValueLength is the length values are cut to in the admin entries
+const adminValueLength = 200
+
+//go:embed admin/index.html
+var adminAssets embed.FS
+
+// adminEntry is one row of the admin entries
+type adminEntry struct {
+	Key   string `json:"key"`
+	Size  int64  `json:"size"`
+	Age   string `json:"age"`
+	Value string `json:"value"`
+}
+
+// AdminHandler return the handler of a web page listing the entries and counters of l, with buttons to peek, take,
+// pop and delete items, mount it under a prefix with http.StripPrefix
+//
+//	GET    /                 the page
+//	GET    /api/entries      the front entries, ?limit=n, 1000 by default
+//	GET    /api/stats        the linear counters
+//	GET    /api/peek         the front item
+//	POST   /api/take         take the front item
+//	POST   /api/pop          pop the back item
+//	DELETE /api/items/{key}  delete every occurrence of the key
+func AdminHandler(l *linear.Linear) http.Handler {
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/" {
+			http.NotFound(w, r)
+			return
+		}
+		page, _ := adminAssets.ReadFile("admin/index.html")
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write(page)
+	})
+
+	mux.HandleFunc("/api/entries", func(w http.ResponseWriter, r *http.Request) {
+		limit := 1000
+		if value := r.URL.Query().Get("limit"); value != "" {
+			var err error
+			if limit, err = strconv.Atoi(value); err != nil || limit <= 0 {
+				http.Error(w, "limit must be a positive integer", http.StatusBadRequest)
+				return
+			}
+		}
+
+		now := time.Now()
+		entries := l.Entries(limit)
+		rows := make([]adminEntry, 0, len(entries))
+		for _, entry := range entries {
+			value := fmt.Sprint(entry.Value)
+			if len(value) > adminValueLength {
+				value = value[:adminValueLength] + "…"
+			}
+			rows = append(rows, adminEntry{
+				Key:   entry.Key,
+				Size:  entry.Size,
+				Age:   now.Sub(entry.PushedAt).Round(time.Millisecond).String(),
+				Value: value,
+			})
+		}
+		writeJSON(w, rows)
+	})
+
+	mux.HandleFunc("/api/stats", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, l.Stats())
+	})
+
+	mux.HandleFunc("/api/peek", func(w http.ResponseWriter, r *http.Request) {
+		key, value, err := l.PeekFront()
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		writeJSON(w, Item{Key: key, Value: value})
+	})
+
+	removal := func(remove func() (linear.Entry, error)) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodPost {
+				methodNotAllowed(w, http.MethodPost)
+				return
+			}
+
+			entry, err := remove()
+			if err != nil {
+				writeError(w, err)
+				return
+			}
+			writeJSON(w, Item{Key: entry.Key, Value: entry.Value})
+		}
+	}
+	mux.Handle("/api/take", removal(l.TakeEntry))
+	mux.Handle("/api/pop", removal(l.PopEntry))
+
+	mux.HandleFunc("/api/items/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete {
+			methodNotAllowed(w, http.MethodDelete)
+			return
+		}
+
+		if err := l.Delete(strings.TrimPrefix(r.URL.Path, "/api/items/")); err != nil {
+			writeError(w, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	return mux
+}
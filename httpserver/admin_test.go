@@ -0,0 +1,59 @@
+package httpserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang-common-packages/linear"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdminHandler(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	l, _ := linear.NewWithOptions()
+	defer l.Close()
+	l.Push("1", "a")
+	l.Push("2", strings.Repeat("b", 300))
+	l.Push("3", "c")
+	handler := Handler(l)
+
+	do := func(method, target string) *httptest.ResponseRecorder {
+		response := httptest.NewRecorder()
+		handler.ServeHTTP(response, httptest.NewRequest(method, target, nil))
+		return response
+	}
+
+	// Testing
+	response := do(http.MethodGet, "/admin/")
+	assert.Equal(http.StatusOK, response.Code)
+	assert.Contains(response.Body.String(), "api/entries")
+
+	response = do(http.MethodGet, "/admin/api/entries?limit=2")
+	var entries []adminEntry
+	assert.Nil(json.Unmarshal(response.Body.Bytes(), &entries))
+	assert.Len(entries, 2)
+	assert.Equal("1", entries[0].Key)
+	assert.Equal(l.Entries(1)[0].Size, entries[0].Size)
+	assert.Equal(adminValueLength+len("…"), len(entries[1].Value))
+	assert.Equal(http.StatusBadRequest, do(http.MethodGet, "/admin/api/entries?limit=x").Code)
+
+	assert.JSONEq(`{"key": "1", "value": "a"}`, do(http.MethodGet, "/admin/api/peek").Body.String())
+	assert.Equal(http.StatusMethodNotAllowed, do(http.MethodGet, "/admin/api/take").Code)
+	assert.JSONEq(`{"key": "1", "value": "a"}`, do(http.MethodPost, "/admin/api/take").Body.String())
+	assert.JSONEq(`{"key": "3", "value": "c"}`, do(http.MethodPost, "/admin/api/pop").Body.String())
+
+	assert.Equal(http.StatusNoContent, do(http.MethodDelete, "/admin/api/items/2").Code)
+	assert.Equal(http.StatusNotFound, do(http.MethodDelete, "/admin/api/items/2").Code)
+	assert.True(l.IsEmpty())
+
+	var stats linear.Stats
+	assert.Nil(json.Unmarshal(do(http.MethodGet, "/admin/api/stats").Body.Bytes(), &stats))
+	if stats.Pushes != 3 {
+		t.Errorf("AdminHandler failed, expected %v, got %v", 3, stats.Pushes)
+	}
+}
@@ -0,0 +1,153 @@
+// Package httpserver exposes a linear over HTTP with JSON bodies
+//
+//	POST   /items        push the {"key": ..., "value": ...} body
+//	GET    /items/{key}  read the value of the key
+//	DELETE /items/front  take the front item
+//	DELETE /items/back   pop the back item
+//	GET    /stats        return the linear counters
+//	GET    /ws           upgrade to the WebSocket protocol of Message
+//	GET    /admin/       the admin page of AdminHandler
+//	GET    /openapi.json the OpenAPI document of OpenAPIHandler
+//
+// NewTenantHandler serves the item routes to several applications sharing the linear under their own key prefix
+package httpserver
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/golang-common-packages/linear"
+)
+
+// maxBodySize is the largest push body accepted
+const maxBodySize = 1 << 20
+
+// Item is a key and value pair of the JSON bodies
+type Item struct {
+	Key   string      `json:"key"`
+	Value interface{} `json:"value"`
+}
+
+// Serve listen on addr and serve l until the listener fails
+func Serve(l *linear.Linear, addr string) error {
+	return http.ListenAndServe(addr, Handler(l))
+}
+
+// Handler return the HTTP handler exposing l, values are decoded as the generic JSON types
+func Handler(l *linear.Linear) http.Handler {
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/items", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			methodNotAllowed(w, http.MethodPost)
+			return
+		}
+
+		item, ok := decodeItem(w, r)
+		if !ok {
+			return
+		}
+
+		// With the Block full policy the push stops waiting once the client is gone
+		if err := l.PushContext(r.Context(), item.Key, item.Value); err != nil {
+			writeError(w, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	mux.HandleFunc("/items/", func(w http.ResponseWriter, r *http.Request) {
+		key := strings.TrimPrefix(r.URL.Path, "/items/")
+
+		switch {
+		case r.Method == http.MethodGet:
+			value, err := l.Read(key)
+			if err != nil {
+				writeError(w, err)
+				return
+			}
+			writeJSON(w, Item{Key: key, Value: value})
+		case r.Method == http.MethodDelete && (key == "front" || key == "back"):
+			take := l.TakeEntry
+			if key == "back" {
+				take = l.PopEntry
+			}
+
+			entry, err := take()
+			if err != nil {
+				writeError(w, err)
+				return
+			}
+			writeJSON(w, Item{Key: entry.Key, Value: entry.Value})
+		case r.Method == http.MethodDelete:
+			http.NotFound(w, r)
+		default:
+			methodNotAllowed(w, http.MethodGet)
+		}
+	})
+
+	mux.HandleFunc("/stats", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			methodNotAllowed(w, http.MethodGet)
+			return
+		}
+		writeJSON(w, l.Stats())
+	})
+
+	mux.Handle("/ws", WebSocketHandler(l))
+	mux.Handle("/admin/", http.StripPrefix("/admin", AdminHandler(l)))
+	mux.Handle("/openapi.json", OpenAPIHandler())
+
+	return mux
+}
+
+// decodeItem decode the push body of r, a body that fails is answered with a bad request
+func decodeItem(w http.ResponseWriter, r *http.Request) (Item, bool) {
+
+	var item Item
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBodySize))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&item); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return Item{}, false
+	}
+
+	return item, true
+}
+
+// writeJSON write v as the JSON response body
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
+
+// writeError write the status matching err
+func writeError(w http.ResponseWriter, err error) {
+	http.Error(w, err.Error(), statusOf(err))
+}
+
+// statusOf return the HTTP status of a linear error
+func statusOf(err error) int {
+	switch {
+	case errors.Is(err, linear.ErrKeyNotFound), errors.Is(err, linear.ErrEmpty):
+		return http.StatusNotFound
+	case errors.Is(err, linear.ErrInvalidKey), errors.Is(err, linear.ErrInvalidValue), errors.Is(err, linear.ErrInvalidArgument):
+		return http.StatusBadRequest
+	case errors.Is(err, linear.ErrFull), errors.Is(err, linear.ErrCapacityExceeded):
+		return http.StatusInsufficientStorage
+	case errors.Is(err, linear.ErrClosed), errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return http.StatusServiceUnavailable
+	}
+	return http.StatusInternalServerError
+}
+
+// methodNotAllowed answer a request whose method the route doesn't serve
+func methodNotAllowed(w http.ResponseWriter, allow string) {
+	w.Header().Set("Allow", allow)
+	http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+}
@@ -0,0 +1,80 @@
+package httpserver
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-common-packages/linear"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	l, _ := linear.NewWithOptions(linear.WithMaxItems(2), linear.WithFullPolicy(linear.Reject))
+	handler := Handler(l)
+
+	do := func(method, target, body string) *httptest.ResponseRecorder {
+		response := httptest.NewRecorder()
+		handler.ServeHTTP(response, httptest.NewRequest(method, target, strings.NewReader(body)))
+		return response
+	}
+
+	// Testing
+	assert.Equal(http.StatusCreated, do(http.MethodPost, "/items", `{"key": "1", "value": "a"}`).Code)
+	assert.Equal(http.StatusCreated, do(http.MethodPost, "/items", `{"key": "2", "value": {"n": 2}}`).Code)
+	assert.Equal(http.StatusInsufficientStorage, do(http.MethodPost, "/items", `{"key": "3", "value": "c"}`).Code)
+	assert.Equal(http.StatusBadRequest, do(http.MethodPost, "/items", `{"key": "3", "other": "c"}`).Code)
+	assert.Equal(http.StatusMethodNotAllowed, do(http.MethodGet, "/items", "").Code)
+
+	response := do(http.MethodGet, "/items/2", "")
+	assert.Equal(http.StatusOK, response.Code)
+	assert.JSONEq(`{"key": "2", "value": {"n": 2}}`, response.Body.String())
+	assert.Equal(http.StatusNotFound, do(http.MethodGet, "/items/3", "").Code)
+
+	response = do(http.MethodDelete, "/items/back", "")
+	assert.Equal(http.StatusOK, response.Code)
+	assert.JSONEq(`{"key": "2", "value": {"n": 2}}`, response.Body.String())
+
+	response = do(http.MethodDelete, "/items/front", "")
+	assert.Equal(http.StatusOK, response.Code)
+	assert.JSONEq(`{"key": "1", "value": "a"}`, response.Body.String())
+	assert.Equal(http.StatusNotFound, do(http.MethodDelete, "/items/front", "").Code)
+	assert.Equal(http.StatusNotFound, do(http.MethodDelete, "/items/1", "").Code)
+
+	response = do(http.MethodGet, "/stats", "")
+	assert.Equal(http.StatusOK, response.Code)
+	var stats linear.Stats
+	assert.Nil(json.Unmarshal(response.Body.Bytes(), &stats))
+	if stats.Pushes != 2 {
+		t.Errorf("Handler failed, expected %v, got %v", 2, stats.Pushes)
+	}
+
+	l.Close()
+	assert.Equal(http.StatusServiceUnavailable, do(http.MethodGet, "/items/1", "").Code)
+}
+
+func TestHandlerBlock(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	l, _ := linear.NewWithOptions(linear.WithMaxItems(1), linear.WithFullPolicy(linear.Block))
+	defer l.Close()
+	handler := Handler(l)
+	assert.Nil(l.Push("1", "a"))
+
+	// Testing
+	// The push waiting for room stops with the request
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	response := httptest.NewRecorder()
+	handler.ServeHTTP(response, httptest.NewRequest(http.MethodPost, "/items", strings.NewReader(`{"key": "2", "value": "b"}`)).WithContext(ctx))
+	assert.Equal(http.StatusServiceUnavailable, response.Code)
+	assert.Equal([]string{"1"}, l.Getkeys())
+}
@@ -0,0 +1,24 @@
+package httpserver
+
+import (
+	_ "embed"
+	"net/http"
+)
+
+// openAPIDocument describe the routes of Handler and NewTenantHandler, keep it in step with them
+//
+//go:embed openapi.json
+var openAPIDocument []byte
+
+// OpenAPIHandler return the handler of the OpenAPI document describing the routes, so client SDKs can be generated
+// Handler and NewTenantHandler serve it at /openapi.json
+func OpenAPIHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			methodNotAllowed(w, http.MethodGet)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(openAPIDocument)
+	})
+}
@@ -0,0 +1,51 @@
+package httpserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang-common-packages/linear"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOpenAPIHandler(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	tenants, _ := NewTenantHandler(linear.New(1024, false), Tenant{Name: "a", APIKey: "a-key", Prefix: "a:"})
+
+	// Testing
+	for _, handler := range []http.Handler{Handler(linear.New(1024, false)), tenants} {
+		response := httptest.NewRecorder()
+		handler.ServeHTTP(response, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+		assert.Equal(http.StatusOK, response.Code)
+		assert.Equal("application/json", response.Header().Get("Content-Type"))
+
+		var document struct {
+			OpenAPI string                                `json:"openapi"`
+			Paths   map[string]map[string]json.RawMessage `json:"paths"`
+		}
+		assert.Nil(json.Unmarshal(response.Body.Bytes(), &document))
+		assert.Equal("3.0.3", document.OpenAPI)
+
+		// Every documented route is served
+		for path, operations := range document.Paths {
+			for method := range operations {
+				if path == "/ws" || path == "/items/{key}" {
+					continue
+				}
+				response := httptest.NewRecorder()
+				handler.ServeHTTP(response, httptest.NewRequest(http.MethodOptions, path, nil))
+				if response.Code == http.StatusNotFound {
+					t.Errorf("OpenAPIHandler failed, expected %v, got %v", "a route for "+method+" "+path, response.Code)
+				}
+			}
+		}
+	}
+
+	response := httptest.NewRecorder()
+	OpenAPIHandler().ServeHTTP(response, httptest.NewRequest(http.MethodPost, "/openapi.json", nil))
+	assert.Equal(http.StatusMethodNotAllowed, response.Code)
+}
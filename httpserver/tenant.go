@@ -0,0 +1,262 @@
+package httpserver
+
+import (
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/golang-common-packages/linear"
+)
+
+// APIKeyHeader is the request header carrying the API key of a tenant
+const APIKeyHeader = "X-API-Key"
+
+// Tenant is an application sharing the linear, its keys are stored under Prefix and hidden from the other tenants
+type Tenant struct {
+	Name     string
+	APIKey   string
+	Prefix   string
+	MaxItems int // Items the tenant may hold, 0 means no quota
+}
+
+// TenantStats is a point in time copy of the counters of a tenant
+type TenantStats struct {
+	Items    int   `json:"items"`
+	Requests int64 `json:"requests"`
+	Pushes   int64 `json:"pushes"`
+	Reads    int64 `json:"reads"`
+	Takes    int64 `json:"takes"`
+	Rejected int64 `json:"rejected"` // Pushes over the quota
+	Errors   int64 `json:"errors"`
+}
+
+// tenantState hold the counters of a tenant, they are accessed atomically
+type tenantState struct {
+	Tenant
+	pushMux  sync.Mutex // Serialize the quota check and the push
+	requests int64
+	pushes   int64
+	reads    int64
+	takes    int64
+	rejected int64
+	errors   int64
+}
+
+// TenantHandler is the HTTP handler exposing a linear to several tenants, see NewTenantHandler
+type TenantHandler struct {
+	l       *linear.Linear
+	tenants []*tenantState
+	mux     *http.ServeMux
+}
+
+// NewTenantHandler return the HTTP handler exposing l to tenants, every request carries the API key of its tenant in the
+// X-API-Key header and only sees the keys under the tenant prefix, without the prefix
+//
+//	POST   /items        push the {"key": ..., "value": ...} body, 507 once the tenant holds MaxItems items
+//	GET    /items/{key}  read the value of the key
+//	DELETE /items/front  take the front item of the tenant
+//	DELETE /items/back   take the back item of the tenant, its key loses its front-most occurrence
+//	GET    /stats        return the counters of the tenant
+//	GET    /openapi.json the OpenAPI document of OpenAPIHandler, without API key
+//
+// API keys and names must be set and unique, and no prefix may start another one, so tenants never share a key
+func NewTenantHandler(l *linear.Linear, tenants ...Tenant) (*TenantHandler, error) {
+
+	// Argument validator
+	if len(tenants) == 0 {
+		return nil, linear.ErrInvalidArgument
+	}
+
+	h := &TenantHandler{l: l, mux: http.NewServeMux()}
+	for i, tenant := range tenants {
+		if tenant.Name == "" || tenant.APIKey == "" || tenant.Prefix == "" || tenant.MaxItems < 0 {
+			return nil, fmt.Errorf("%w: tenant %q", linear.ErrInvalidArgument, tenant.Name)
+		}
+		for _, other := range tenants[:i] {
+			if other.Name == tenant.Name || other.APIKey == tenant.APIKey ||
+				strings.HasPrefix(other.Prefix, tenant.Prefix) || strings.HasPrefix(tenant.Prefix, other.Prefix) {
+				return nil, fmt.Errorf("%w: tenants %q and %q overlap", linear.ErrInvalidArgument, other.Name, tenant.Name)
+			}
+		}
+		h.tenants = append(h.tenants, &tenantState{Tenant: tenant})
+	}
+
+	h.mux.HandleFunc("/items", h.authenticated(h.push))
+	h.mux.HandleFunc("/items/", h.authenticated(h.item))
+	h.mux.HandleFunc("/stats", h.authenticated(func(w http.ResponseWriter, r *http.Request, tenant *tenantState) {
+		if r.Method != http.MethodGet {
+			methodNotAllowed(w, http.MethodGet)
+			return
+		}
+		writeJSON(w, h.stats(tenant))
+	}))
+	h.mux.Handle("/openapi.json", OpenAPIHandler())
+
+	return h, nil
+}
+
+// ServeHTTP serve the request of a tenant
+func (h *TenantHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mux.ServeHTTP(w, r)
+}
+
+// Stats return the counters of every tenant by name
+func (h *TenantHandler) Stats() map[string]TenantStats {
+
+	stats := make(map[string]TenantStats, len(h.tenants))
+	for _, tenant := range h.tenants {
+		stats[tenant.Name] = h.stats(tenant)
+	}
+
+	return stats
+}
+
+// stats return the counters of tenant
+func (h *TenantHandler) stats(tenant *tenantState) TenantStats {
+	return TenantStats{
+		Items:    h.items(tenant),
+		Requests: atomic.LoadInt64(&tenant.requests),
+		Pushes:   atomic.LoadInt64(&tenant.pushes),
+		Reads:    atomic.LoadInt64(&tenant.reads),
+		Takes:    atomic.LoadInt64(&tenant.takes),
+		Rejected: atomic.LoadInt64(&tenant.rejected),
+		Errors:   atomic.LoadInt64(&tenant.errors),
+	}
+}
+
+// authenticated answer the requests without a known API key with 401 and pass the others to handle with their tenant
+func (h *TenantHandler) authenticated(handle func(http.ResponseWriter, *http.Request, *tenantState)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		// Every tenant is compared, so the time taken doesn't tell how much of an API key matched
+		apiKey := []byte(r.Header.Get(APIKeyHeader))
+		var found *tenantState
+		for _, tenant := range h.tenants {
+			if subtle.ConstantTimeCompare(apiKey, []byte(tenant.APIKey)) == 1 {
+				found = tenant
+			}
+		}
+
+		if found == nil {
+			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+			return
+		}
+
+		atomic.AddInt64(&found.requests, 1)
+		handle(w, r, found)
+	}
+}
+
+// push push the body of r under the tenant prefix
+func (h *TenantHandler) push(w http.ResponseWriter, r *http.Request, tenant *tenantState) {
+
+	if r.Method != http.MethodPost {
+		methodNotAllowed(w, http.MethodPost)
+		return
+	}
+
+	item, ok := decodeItem(w, r)
+	if !ok {
+		return
+	}
+
+	tenant.pushMux.Lock()
+	defer tenant.pushMux.Unlock()
+
+	if tenant.MaxItems > 0 && h.items(tenant) >= tenant.MaxItems {
+		atomic.AddInt64(&tenant.rejected, 1)
+		http.Error(w, fmt.Sprintf("tenant %q holds its quota of %d items", tenant.Name, tenant.MaxItems), http.StatusInsufficientStorage)
+		return
+	}
+
+	if err := h.l.PushContext(r.Context(), tenant.Prefix+item.Key, item.Value); err != nil {
+		h.writeError(w, tenant, err)
+		return
+	}
+
+	atomic.AddInt64(&tenant.pushes, 1)
+	w.WriteHeader(http.StatusCreated)
+}
+
+// item read the key of r or take the front or back item of the tenant
+func (h *TenantHandler) item(w http.ResponseWriter, r *http.Request, tenant *tenantState) {
+
+	key := strings.TrimPrefix(r.URL.Path, "/items/")
+
+	switch {
+	case r.Method == http.MethodGet:
+		value, err := h.l.Read(tenant.Prefix + key)
+		if err != nil {
+			h.writeError(w, tenant, err)
+			return
+		}
+		atomic.AddInt64(&tenant.reads, 1)
+		writeJSON(w, Item{Key: key, Value: value})
+	case r.Method == http.MethodDelete && (key == "front" || key == "back"):
+		entry, err := h.take(tenant, key == "back")
+		if err != nil {
+			h.writeError(w, tenant, err)
+			return
+		}
+		atomic.AddInt64(&tenant.takes, 1)
+		writeJSON(w, Item{Key: strings.TrimPrefix(entry.Key, tenant.Prefix), Value: entry.Value})
+	case r.Method == http.MethodDelete:
+		http.NotFound(w, r)
+	default:
+		methodNotAllowed(w, http.MethodGet)
+	}
+}
+
+// take remove the front-most or back-most item of the tenant
+func (h *TenantHandler) take(tenant *tenantState, back bool) (linear.Entry, error) {
+
+	walk := h.l.RangeOrdered
+	if back {
+		walk = h.l.RangeReverse
+	}
+
+	for {
+		var key string
+		walk(func(k string, _ interface{}) bool {
+			if strings.HasPrefix(k, tenant.Prefix) {
+				key = k
+				return false
+			}
+			return true
+		})
+
+		if key == "" {
+			return linear.Entry{}, linear.ErrEmpty
+		}
+
+		// Another request may take the key between the walk and the removal
+		entry, err := h.l.GetEntry(key)
+		if !errors.Is(err, linear.ErrKeyNotFound) {
+			return entry, err
+		}
+	}
+}
+
+// items return the number of items of the tenant
+func (h *TenantHandler) items(tenant *tenantState) int {
+
+	var items int
+	h.l.RangeOrdered(func(key string, _ interface{}) bool {
+		if strings.HasPrefix(key, tenant.Prefix) {
+			items++
+		}
+		return true
+	})
+
+	return items
+}
+
+// writeError count the error of the tenant and write its status
+func (h *TenantHandler) writeError(w http.ResponseWriter, tenant *tenantState, err error) {
+	atomic.AddInt64(&tenant.errors, 1)
+	writeError(w, err)
+}
@@ -0,0 +1,76 @@
+package httpserver
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang-common-packages/linear"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTenantHandler(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	_, err := NewTenantHandler(linear.New(1024, false))
+	assert.True(errors.Is(err, linear.ErrInvalidArgument))
+	_, err = NewTenantHandler(linear.New(1024, false), Tenant{Name: "a", APIKey: "1", Prefix: "app:"}, Tenant{Name: "b", APIKey: "2", Prefix: "app:b:"})
+	assert.True(errors.Is(err, linear.ErrInvalidArgument))
+
+	l, _ := linear.NewWithOptions()
+	handler, err := NewTenantHandler(l,
+		Tenant{Name: "orders", APIKey: "orders-key", Prefix: "orders:", MaxItems: 2},
+		Tenant{Name: "billing", APIKey: "billing-key", Prefix: "billing:"},
+	)
+	assert.Nil(err)
+
+	do := func(apiKey, method, target, body string) *httptest.ResponseRecorder {
+		request := httptest.NewRequest(method, target, strings.NewReader(body))
+		if apiKey != "" {
+			request.Header.Set(APIKeyHeader, apiKey)
+		}
+		response := httptest.NewRecorder()
+		handler.ServeHTTP(response, request)
+		return response
+	}
+
+	// Testing
+	assert.Equal(http.StatusUnauthorized, do("", http.MethodGet, "/items/1", "").Code)
+	assert.Equal(http.StatusUnauthorized, do("other-key", http.MethodGet, "/items/1", "").Code)
+
+	assert.Equal(http.StatusCreated, do("orders-key", http.MethodPost, "/items", `{"key": "1", "value": "o1"}`).Code)
+	assert.Equal(http.StatusCreated, do("billing-key", http.MethodPost, "/items", `{"key": "1", "value": "b1"}`).Code)
+	assert.Equal(http.StatusCreated, do("orders-key", http.MethodPost, "/items", `{"key": "2", "value": "o2"}`).Code)
+	assert.Equal([]string{"orders:1", "billing:1", "orders:2"}, l.Getkeys())
+
+	// The quota counts the items of the tenant only
+	assert.Equal(http.StatusInsufficientStorage, do("orders-key", http.MethodPost, "/items", `{"key": "3", "value": "o3"}`).Code)
+	assert.Equal(http.StatusCreated, do("billing-key", http.MethodPost, "/items", `{"key": "2", "value": "b2"}`).Code)
+
+	response := do("billing-key", http.MethodGet, "/items/1", "")
+	assert.Equal(http.StatusOK, response.Code)
+	assert.JSONEq(`{"key": "1", "value": "b1"}`, response.Body.String())
+
+	response = do("billing-key", http.MethodDelete, "/items/back", "")
+	assert.JSONEq(`{"key": "2", "value": "b2"}`, response.Body.String())
+	response = do("orders-key", http.MethodDelete, "/items/front", "")
+	assert.JSONEq(`{"key": "1", "value": "o1"}`, response.Body.String())
+	response = do("orders-key", http.MethodDelete, "/items/front", "")
+	assert.JSONEq(`{"key": "2", "value": "o2"}`, response.Body.String())
+	assert.Equal(http.StatusNotFound, do("orders-key", http.MethodDelete, "/items/front", "").Code)
+	assert.Equal([]string{"billing:1"}, l.Getkeys())
+
+	response = do("orders-key", http.MethodGet, "/stats", "")
+	assert.Equal(http.StatusOK, response.Code)
+	var stats TenantStats
+	assert.Nil(json.Unmarshal(response.Body.Bytes(), &stats))
+	assert.Equal(TenantStats{Items: 0, Requests: 7, Pushes: 2, Takes: 2, Rejected: 1, Errors: 1}, stats)
+
+	if billing := handler.Stats()["billing"]; billing.Items != 1 || billing.Reads != 1 {
+		t.Errorf("Stats failed, expected %v, got %v", "1 item and 1 read", billing)
+	}
+}
@@ -0,0 +1,396 @@
+package httpserver
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/golang-common-packages/linear"
+)
+
+// WebSocket opcodes of RFC 6455
+const (
+	opText  = 0x1
+	opClose = 0x8
+	opPing  = 0x9
+	opPong  = 0xa
+)
+
+// websocketGUID is appended to the client key to compute the handshake accept key
+const websocketGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+// maxReadAhead bound the messages read while the session handles a previous one, a client going over it is disconnected
+const maxReadAhead = 64
+
+// errFrameTooLarge is returned for messages over maxBodySize
+var errFrameTooLarge = errors.New("websocket message too large")
+
+// Message is a JSON text message of the WebSocket protocol
+//
+// The client sends:
+//
+//	{"op": "push", "key": ..., "value": ...}  push the item, answered by "pushed" or "error"
+//	{"op": "consume", "window": n}            start the delivery of the front items, at most n unacknowledged at a time
+//	{"op": "ack", "id": n}                    acknowledge the delivered item n, making room in the window
+//
+// The server sends:
+//
+//	{"op": "pushed", "key": ...}
+//	{"op": "item", "id": n, "key": ..., "value": ...}
+//	{"op": "error", "key": ..., "error": ...}
+//
+// Items delivered but not acknowledged when the connection closes are pushed back to the front in their order
+type Message struct {
+	Op     string      `json:"op"`
+	ID     uint64      `json:"id,omitempty"`
+	Key    string      `json:"key,omitempty"`
+	Value  interface{} `json:"value,omitempty"`
+	Window int         `json:"window,omitempty"`
+	Error  string      `json:"error,omitempty"`
+}
+
+// WebSocketHandler return the handler upgrading requests to the WebSocket protocol of Message
+func WebSocketHandler(l *linear.Linear) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrade(w, r)
+		if err != nil {
+			return
+		}
+
+		s := &session{l: l, conn: conn, pending: map[uint64]linear.Entry{}}
+		s.serve()
+	})
+}
+
+// upgrade complete the WebSocket handshake and take over the connection of r
+func upgrade(w http.ResponseWriter, r *http.Request) (*wsConn, error) {
+
+	key := r.Header.Get("Sec-WebSocket-Key")
+	if r.Method != http.MethodGet || !headerContains(r.Header, "Connection", "upgrade") ||
+		!headerContains(r.Header, "Upgrade", "websocket") || r.Header.Get("Sec-WebSocket-Version") != "13" || key == "" {
+		http.Error(w, "websocket handshake expected", http.StatusBadRequest)
+		return nil, errors.New("not a websocket handshake")
+	}
+
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		http.Error(w, "websocket not supported", http.StatusInternalServerError)
+		return nil, errors.New("response can't be hijacked")
+	}
+
+	netConn, rw, err := hijacker.Hijack()
+	if err != nil {
+		return nil, err
+	}
+
+	rw.WriteString("HTTP/1.1 101 Switching Protocols\r\nUpgrade: websocket\r\nConnection: Upgrade\r\n")
+	rw.WriteString("Sec-WebSocket-Accept: " + acceptKey(key) + "\r\n\r\n")
+	if err := rw.Flush(); err != nil {
+		netConn.Close()
+		return nil, err
+	}
+
+	return &wsConn{conn: netConn, r: rw.Reader, w: rw.Writer}, nil
+}
+
+// acceptKey return the handshake accept key of the client key
+func acceptKey(key string) string {
+	sum := sha1.Sum([]byte(key + websocketGUID))
+	return base64.StdEncoding.EncodeToString(sum[:])
+}
+
+// headerContains check if a comma separated header has token, ignoring case
+func headerContains(header http.Header, name, token string) bool {
+	for _, value := range header.Values(name) {
+		for _, part := range strings.Split(value, ",") {
+			if strings.EqualFold(strings.TrimSpace(part), token) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// wsConn is a WebSocket connection, writes are serialized
+type wsConn struct {
+	conn     net.Conn
+	r        *bufio.Reader
+	w        *bufio.Writer
+	writeMux sync.Mutex
+	masked   bool // Mask the written frames, as clients must
+}
+
+// readMessage return the next data message, answering pings and joining fragments
+func (c *wsConn) readMessage() ([]byte, error) {
+
+	var message []byte
+	for {
+		fin, op, payload, err := c.readFrame()
+		if err != nil {
+			return nil, err
+		}
+
+		switch op {
+		case opPing:
+			if err := c.writeFrame(opPong, payload); err != nil {
+				return nil, err
+			}
+			continue
+		case opPong:
+			continue
+		case opClose:
+			c.writeFrame(opClose, nil)
+			return nil, io.EOF
+		}
+
+		if len(message)+len(payload) > maxBodySize {
+			return nil, errFrameTooLarge
+		}
+		message = append(message, payload...)
+		if fin {
+			return message, nil
+		}
+	}
+}
+
+// readFrame read one frame and unmask its payload
+func (c *wsConn) readFrame() (bool, byte, []byte, error) {
+
+	header := make([]byte, 2)
+	if _, err := io.ReadFull(c.r, header); err != nil {
+		return false, 0, nil, err
+	}
+
+	fin, op, masked := header[0]&0x80 != 0, header[0]&0x0f, header[1]&0x80 != 0
+	length := uint64(header[1] & 0x7f)
+	switch length {
+	case 126:
+		extended := make([]byte, 2)
+		if _, err := io.ReadFull(c.r, extended); err != nil {
+			return false, 0, nil, err
+		}
+		length = uint64(binary.BigEndian.Uint16(extended))
+	case 127:
+		extended := make([]byte, 8)
+		if _, err := io.ReadFull(c.r, extended); err != nil {
+			return false, 0, nil, err
+		}
+		length = binary.BigEndian.Uint64(extended)
+	}
+
+	if length > maxBodySize {
+		return false, 0, nil, errFrameTooLarge
+	}
+
+	var mask [4]byte
+	if masked {
+		if _, err := io.ReadFull(c.r, mask[:]); err != nil {
+			return false, 0, nil, err
+		}
+	}
+
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(c.r, payload); err != nil {
+		return false, 0, nil, err
+	}
+
+	if masked {
+		for i := range payload {
+			payload[i] ^= mask[i%4]
+		}
+	}
+
+	return fin, op, payload, nil
+}
+
+// writeFrame write payload as a single final frame
+func (c *wsConn) writeFrame(op byte, payload []byte) error {
+
+	c.writeMux.Lock()
+	defer c.writeMux.Unlock()
+
+	header := []byte{0x80 | op, 0}
+	switch length := len(payload); {
+	case length < 126:
+		header[1] = byte(length)
+	case length <= 0xffff:
+		header[1] = 126
+		header = append(header, 0, 0)
+		binary.BigEndian.PutUint16(header[2:], uint16(length))
+	default:
+		header[1] = 127
+		header = append(header, make([]byte, 8)...)
+		binary.BigEndian.PutUint64(header[2:], uint64(length))
+	}
+
+	if c.masked {
+		header[1] |= 0x80
+		var mask [4]byte
+		rand.Read(mask[:])
+		header = append(header, mask[:]...)
+		masked := make([]byte, len(payload))
+		for i := range payload {
+			masked[i] = payload[i] ^ mask[i%4]
+		}
+		payload = masked
+	}
+
+	c.w.Write(header)
+	c.w.Write(payload)
+	return c.w.Flush()
+}
+
+// writeMessage write m as a text message
+func (c *wsConn) writeMessage(m Message) error {
+
+	payload, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+
+	return c.writeFrame(opText, payload)
+}
+
+// session is the state of one WebSocket client
+type session struct {
+	l       *linear.Linear
+	conn    *wsConn
+	mux     sync.Mutex
+	nextID  uint64
+	pending map[uint64]linear.Entry // Delivered and not acknowledged yet
+	credits chan struct{}           // Room left in the delivery window
+	done    sync.WaitGroup
+}
+
+// serve handle the messages of the client until the connection closes, then requeue the unacknowledged items
+func (s *session) serve() {
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer func() {
+		cancel()
+		s.conn.conn.Close()
+		s.done.Wait()
+		s.requeue(ctx)
+	}()
+
+	// Messages are read ahead, so a closed connection cancels ctx while a push waits for room
+	messages := make(chan []byte, maxReadAhead)
+	s.done.Add(1)
+	go func() {
+		defer s.done.Done()
+		defer close(messages)
+		defer cancel()
+
+		for {
+			payload, err := s.conn.readMessage()
+			if err != nil {
+				return
+			}
+
+			select {
+			case messages <- payload:
+			default:
+				s.conn.writeMessage(Message{Op: "error", Error: "too many messages waiting"})
+				return
+			}
+		}
+	}()
+
+	for payload := range messages {
+		if ctx.Err() != nil {
+			return
+		}
+
+		var m Message
+		if err := json.Unmarshal(payload, &m); err != nil {
+			s.conn.writeMessage(Message{Op: "error", Error: err.Error()})
+			continue
+		}
+
+		switch m.Op {
+		case "push":
+			if err := s.l.PushContext(ctx, m.Key, m.Value); err != nil {
+				s.conn.writeMessage(Message{Op: "error", Key: m.Key, Error: err.Error()})
+				continue
+			}
+			s.conn.writeMessage(Message{Op: "pushed", Key: m.Key})
+		case "consume":
+			if m.Window <= 0 || s.credits != nil {
+				s.conn.writeMessage(Message{Op: "error", Error: "consume needs a positive window, once per connection"})
+				continue
+			}
+			s.credits = make(chan struct{}, m.Window)
+			s.done.Add(1)
+			go s.deliver(ctx)
+		case "ack":
+			s.mux.Lock()
+			_, ok := s.pending[m.ID]
+			delete(s.pending, m.ID)
+			s.mux.Unlock()
+			if ok {
+				<-s.credits
+			}
+		default:
+			s.conn.writeMessage(Message{Op: "error", Error: "unknown op " + m.Op})
+		}
+	}
+}
+
+// deliver send the front items to the client while its window has room
+func (s *session) deliver(ctx context.Context) {
+	defer s.done.Done()
+
+	for {
+		select {
+		case s.credits <- struct{}{}:
+		case <-ctx.Done():
+			return
+		}
+
+		entry, err := s.l.TakeEntryWait(ctx)
+		if err != nil {
+			return
+		}
+
+		s.mux.Lock()
+		s.nextID++
+		id := s.nextID
+		s.pending[id] = entry
+		s.mux.Unlock()
+
+		// A failed write leaves the item pending, it is requeued when the connection closes
+		if err := s.conn.writeMessage(Message{Op: "item", ID: id, Key: entry.Key, Value: entry.Value}); err != nil {
+			return
+		}
+	}
+}
+
+// requeue push the unacknowledged items back to the front, keeping their order
+// ctx is done, so a push doesn't wait for room with the Block full policy, items that fail are logged and dropped
+func (s *session) requeue(ctx context.Context) {
+
+	ids := make([]uint64, 0, len(s.pending))
+	for id := range s.pending {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] > ids[j] })
+
+	for _, id := range ids {
+		entry := s.pending[id]
+		if err := s.l.PushFrontContext(ctx, entry.Key, entry.Value); err != nil {
+			log.Printf("httpserver: requeueing the unacknowledged %q failed, it is dropped: %v", entry.Key, err)
+		}
+	}
+}
@@ -0,0 +1,180 @@
+package httpserver
+
+import (
+	"bufio"
+	"encoding/json"
+	"log"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-common-packages/linear"
+	"github.com/stretchr/testify/assert"
+)
+
+// dialWebSocket open a client connection to the /ws endpoint of server
+func dialWebSocket(t *testing.T, server *httptest.Server) *wsConn {
+
+	netConn, err := net.Dial("tcp", strings.TrimPrefix(server.URL, "http://"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	key := "dGhlIHNhbXBsZSBub25jZQ=="
+	netConn.Write([]byte("GET /ws HTTP/1.1\r\nHost: linear\r\nUpgrade: websocket\r\nConnection: Upgrade\r\n" +
+		"Sec-WebSocket-Key: " + key + "\r\nSec-WebSocket-Version: 13\r\n\r\n"))
+
+	r := bufio.NewReader(netConn)
+	response, err := http.ReadResponse(r, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if response.StatusCode != http.StatusSwitchingProtocols || response.Header.Get("Sec-WebSocket-Accept") != acceptKey(key) {
+		t.Fatalf("dialWebSocket failed, expected %v, got %v", http.StatusSwitchingProtocols, response.Status)
+	}
+
+	netConn.SetDeadline(time.Now().Add(5 * time.Second))
+	return &wsConn{conn: netConn, r: r, w: bufio.NewWriter(netConn), masked: true}
+}
+
+// readTestMessage return the next message of the server
+func readTestMessage(t *testing.T, conn *wsConn) Message {
+
+	payload, err := conn.readMessage()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var m Message
+	if err := json.Unmarshal(payload, &m); err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+func TestAcceptKey(t *testing.T) {
+	// Example of RFC 6455
+	if got := acceptKey("dGhlIHNhbXBsZSBub25jZQ=="); got != "s3pPLMBiTxaQ9kYGzzhZRbK+xOo=" {
+		t.Errorf("acceptKey failed, expected %v, got %v", "s3pPLMBiTxaQ9kYGzzhZRbK+xOo=", got)
+	}
+}
+
+func TestWebSocketHandler(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	l, _ := linear.NewWithOptions()
+	defer l.Close()
+	server := httptest.NewServer(Handler(l))
+	defer server.Close()
+
+	response, _ := http.Get(server.URL + "/ws")
+	assert.Equal(http.StatusBadRequest, response.StatusCode)
+
+	producer := dialWebSocket(t, server)
+	defer producer.conn.Close()
+
+	// Testing
+	for _, key := range []string{"1", "2", "3"} {
+		assert.Nil(producer.writeMessage(Message{Op: "push", Key: key, Value: key}))
+		assert.Equal(Message{Op: "pushed", Key: key}, readTestMessage(t, producer))
+	}
+	assert.Nil(producer.writeMessage(Message{Op: "push"}))
+	assert.Equal("error", readTestMessage(t, producer).Op)
+
+	// The window holds the delivery of the third item until an ack
+	consumer := dialWebSocket(t, server)
+	assert.Nil(consumer.writeMessage(Message{Op: "consume", Window: 2}))
+	first, second := readTestMessage(t, consumer), readTestMessage(t, consumer)
+	assert.Equal(Message{Op: "item", ID: 1, Key: "1", Value: "1"}, first)
+	assert.Equal(Message{Op: "item", ID: 2, Key: "2", Value: "2"}, second)
+	assert.Equal([]string{"3"}, l.Getkeys())
+
+	assert.Nil(consumer.writeMessage(Message{Op: "ack", ID: 1}))
+	assert.Equal(Message{Op: "item", ID: 3, Key: "3", Value: "3"}, readTestMessage(t, consumer))
+
+	// Pings are answered while consuming
+	assert.Nil(consumer.writeFrame(opPing, []byte("ping")))
+	fin, op, payload, err := consumer.readFrame()
+	assert.Nil(err)
+	assert.True(fin)
+	assert.Equal(byte(opPong), op)
+	assert.Equal("ping", string(payload))
+
+	// The unacknowledged items go back to the front once the consumer leaves
+	assert.Nil(consumer.writeFrame(opClose, nil))
+	consumer.conn.Close()
+	assert.Eventually(func() bool {
+		return len(l.Getkeys()) == 2
+	}, time.Second, time.Millisecond)
+	assert.Equal([]string{"2", "3"}, l.Getkeys())
+}
+
+func TestWebSocketBlock(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	l, _ := linear.NewWithOptions(linear.WithMaxItems(1), linear.WithFullPolicy(linear.Block))
+	defer l.Close()
+	server := httptest.NewServer(Handler(l))
+	defer server.Close()
+	assert.Nil(l.Push("1", "a"))
+
+	// Testing
+	// A push waiting for room stops once its connection closes
+	producer := dialWebSocket(t, server)
+	assert.Nil(producer.writeMessage(Message{Op: "push", Key: "2", Value: "b"}))
+	time.Sleep(20 * time.Millisecond)
+	producer.conn.Close()
+	time.Sleep(50 * time.Millisecond)
+
+	item, err := l.Take()
+	assert.Nil(err)
+	assert.Equal("a", item)
+	time.Sleep(20 * time.Millisecond)
+	assert.True(l.IsEmpty())
+}
+
+// logWriter send every log line to a channel
+type logWriter chan string
+
+func (w logWriter) Write(p []byte) (int, error) {
+	w <- string(p)
+	return len(p), nil
+}
+
+func TestWebSocketRequeueFailure(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	l, _ := linear.NewWithOptions(linear.WithMaxItems(1), linear.WithFullPolicy(linear.Reject))
+	defer l.Close()
+	server := httptest.NewServer(Handler(l))
+	defer server.Close()
+
+	logs := make(logWriter, 1)
+	log.SetOutput(logs)
+	defer log.SetOutput(os.Stderr)
+
+	assert.Nil(l.Push("1", "a"))
+	consumer := dialWebSocket(t, server)
+	assert.Nil(consumer.writeMessage(Message{Op: "consume", Window: 1}))
+	assert.Equal(Message{Op: "item", ID: 1, Key: "1", Value: "a"}, readTestMessage(t, consumer))
+
+	// Testing
+	// The item taking the room of the unacknowledged one makes its requeue fail, which is logged
+	assert.Nil(l.Push("2", "b"))
+	consumer.conn.Close()
+
+	select {
+	case line := <-logs:
+		assert.Contains(line, `"1"`)
+	case <-time.After(time.Second):
+		t.Errorf("requeue failed, expected %v, got %v", "a log line", "none")
+	}
+	assert.Equal([]string{"2"}, l.Getkeys())
+}
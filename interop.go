@@ -0,0 +1,52 @@
+package linear
+
+import (
+	"sort"
+	"sync"
+)
+
+// FromMap push the items to the linear under a single lock, the keys of order first and in that order,
+// then the other keys in ascending order. It returns the error of every key that could not be pushed, nil when all of them were
+func (l *Linear) FromMap(items map[string]interface{}, order []string) map[string]error {
+
+	// Execution conditions
+	if l.IsClosed() {
+		errs := make(map[string]error, len(items))
+		for key := range items {
+			errs[key] = ErrClosed
+		}
+		return errs
+	}
+
+	var errs map[string]error
+	ordered := make(map[string]bool, len(order))
+	keys := make([]string, 0, len(items))
+	for _, key := range order {
+		if _, ok := items[key]; !ok {
+			if errs == nil {
+				errs = map[string]error{}
+			}
+			errs[key] = newError("push", key, ErrKeyNotFound)
+			continue
+		}
+		ordered[key] = true
+		keys = append(keys, key)
+	}
+
+	rest := make([]string, 0, len(items)-len(ordered))
+	for key := range items {
+		if !ordered[key] {
+			rest = append(rest, key)
+		}
+	}
+	sort.Strings(rest)
+
+	return l.pushKeys(append(keys, rest...), items, errs)
+}
+
+// PopulateSyncMap store every item of the linear in dst
+func (l *Linear) PopulateSyncMap(dst *sync.Map) {
+	for key, value := range l.GetItemsMap() {
+		dst.Store(key, value)
+	}
+}
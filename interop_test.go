@@ -0,0 +1,48 @@
+package linear
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromMap(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	linearClient := New(1024, false)
+	items := map[string]interface{}{"a": 1, "b": 2, "c": 3, "d": 4}
+
+	// Testing
+	errs := linearClient.FromMap(items, []string{"c", "x", "a"})
+	assert.Len(errs, 1)
+	assert.True(errors.Is(errs["x"], ErrKeyNotFound))
+	assert.Equal([]string{"c", "a", "b", "d"}, linearClient.Getkeys())
+	assert.Nil(linearClient.CheckSize())
+
+	linearClient.Close()
+	assert.True(errors.Is(linearClient.FromMap(items, nil)["a"], ErrClosed))
+}
+
+func TestPopulateSyncMap(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	linearClient := New(1024, false)
+	linearClient.Push("1", "a")
+	linearClient.Push("2", "b")
+	var dst sync.Map
+	dst.Store("3", "c")
+
+	// Testing
+	linearClient.PopulateSyncMap(&dst)
+
+	items := map[interface{}]interface{}{}
+	dst.Range(func(key, value interface{}) bool {
+		items[key] = value
+		return true
+	})
+	assert.Equal(map[interface{}]interface{}{"1": "a", "2": "b", "3": "c"}, items)
+}
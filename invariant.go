@@ -0,0 +1,99 @@
+package linear
+
+import (
+	"fmt"
+	"time"
+)
+
+// WithDriftCheck recompute the total size of the items every interval and report when it drifts from the tracked size
+// A nil report function logs the drift, the check stops on Close
+func WithDriftCheck(interval time.Duration, report func(computed, tracked int64)) Option {
+	return func(l *Linear) {
+		l.driftInterval = interval
+		l.driftReport = report
+	}
+}
+
+// startDriftCheck run the drift check configured by WithDriftCheck in the background
+func (l *Linear) startDriftCheck() {
+
+	report := l.driftReport
+	if report == nil {
+		report = func(computed, tracked int64) {
+			l.logger.Printf("linear: size drift detected, computed %d bytes, tracked %d bytes", computed, tracked)
+		}
+	}
+
+	l.startWorker(func(done <-chan struct{}) {
+		ticker := time.NewTicker(l.driftInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				if computed, tracked := l.sizes(); computed != tracked {
+					report(computed, tracked)
+				}
+			}
+		}
+	})
+}
+
+// CheckSize recompute the total size of the items and return an error when it differs from the tracked size
+func (l *Linear) CheckSize() error {
+
+	if computed, tracked := l.sizes(); computed != tracked {
+		return fmt.Errorf("size drift detected, computed %d bytes, tracked %d bytes", computed, tracked)
+	}
+
+	return nil
+}
+
+// sizes return the size recomputed from the items and the tracked linearCurrentSize
+func (l *Linear) sizes() (int64, int64) {
+
+	l.mux.RLock()
+	defer l.mux.RUnlock()
+
+	return l.computeSize(), l.linearCurrentSize
+}
+
+// computeSize return the size recomputed from the items, caller must hold mux
+func (l *Linear) computeSize() int64 {
+	return l.sumSizes(func(key string) int64 {
+		value, _ := l.items.Load(key)
+		return l.valueSize(key, value)
+	})
+}
+
+// trackedSize return the size summed from the tracked value sizes, caller must hold mux
+func (l *Linear) trackedSize() int64 {
+	return l.sumSizes(func(key string) int64 {
+		return l.valueSizes[key]
+	})
+}
+
+// sumSizes return the size of the keys with the value sizes given by valueSize, caller must hold mux
+func (l *Linear) sumSizes(valueSize func(key string) int64) int64 {
+
+	var computed int64
+	counted := map[*int]bool{}
+	for key, occurrences := range l.keys.index {
+		valueCount := int64(len(occurrences))
+
+		// Shared values are accounted once for the whole group
+		if group, ok := l.shared[key]; ok {
+			valueCount--
+			if !counted[group] {
+				counted[group] = true
+				valueCount++
+			}
+		}
+
+		computed += int64(len(occurrences))*calculateKeySize(key) + valueCount*valueSize(key)
+	}
+
+	return computed
+}
@@ -0,0 +1,53 @@
+package linear
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckSize(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	linearClient := New(1024, false)
+	linearClient.Push("1", "a")
+	linearClient.Push("2", "b")
+	linearClient.Alias("3", "1")
+	linearClient.Get("2")
+
+	// Testing
+	assert.Nil(linearClient.CheckSize())
+
+	linearClient.linearCurrentSize++
+	assert.NotNil(linearClient.CheckSize())
+}
+
+func TestWithDriftCheck(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	reports := make(chan int64, 1)
+	linearClient := New(1024, false, WithDriftCheck(time.Millisecond, func(computed, tracked int64) {
+		select {
+		case reports <- tracked - computed:
+		default:
+		}
+	}))
+	linearClient.Push("1", "a")
+
+	linearClient.mux.Lock()
+	linearClient.linearCurrentSize += 8
+	linearClient.mux.Unlock()
+
+	// Testing
+	select {
+	case drift := <-reports:
+		assert.Equal(drift, int64(8))
+	case <-time.After(time.Second):
+		t.Errorf("WithDriftCheck failed, expected %v, got %v", "a report", "nothing")
+	}
+
+	assert.Nil(linearClient.Close())
+}
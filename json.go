@@ -0,0 +1,89 @@
+package linear
+
+import (
+	"encoding/json"
+)
+
+// jsonState is the JSON encoding of a linear, items keep the linear order
+type jsonState struct {
+	LinearSizes int64      `json:"linearSizes"`
+	SizeChecker bool       `json:"sizeChecker"`
+	Items       []jsonItem `json:"items"`
+}
+
+// jsonItem is a key and value pair of the JSON encoding
+type jsonItem struct {
+	Key   string      `json:"key"`
+	Value interface{} `json:"value"`
+}
+
+// MarshalJSON encode the linear size, the size checker and the items from front to back as key and value pairs
+func (l *Linear) MarshalJSON() ([]byte, error) {
+
+	// Execution conditions
+	if l.IsClosed() {
+		return nil, ErrClosed
+	}
+
+	l.mux.RLock()
+	state := jsonState{
+		LinearSizes: l.linearSizes,
+		SizeChecker: l.sizeChecker,
+		Items:       make([]jsonItem, 0, l.keys.len),
+	}
+	for n := l.keys.head; n != nil; n = n.next {
+		value, _ := l.items.Load(n.key)
+		state.Items = append(state.Items, jsonItem{Key: n.key, Value: l.resolve(value)})
+	}
+	l.mux.RUnlock()
+
+	return json.Marshal(&state)
+}
+
+// UnmarshalJSON replace the content of the linear with the encoded items and apply the encoded linear size and size checker
+// The linear must come from a constructor, values are decoded as the generic JSON types
+func (l *Linear) UnmarshalJSON(data []byte) error {
+
+	// Execution conditions
+	if l.mux == nil {
+		return ErrInvalidArgument
+	}
+
+	if l.IsClosed() {
+		return ErrClosed
+	}
+
+	var encoded jsonState
+	if err := json.Unmarshal(data, &encoded); err != nil {
+		return err
+	}
+
+	// Argument validator
+	if encoded.LinearSizes <= 0 {
+		return ErrInvalidSize
+	}
+
+	state := snapshotState{
+		Keys:   make([]string, 0, len(encoded.Items)),
+		Values: make(map[string]interface{}, len(encoded.Items)),
+	}
+	for _, item := range encoded.Items {
+		state.Keys = append(state.Keys, item.Key)
+		if _, ok := state.Values[item.Key]; !ok {
+			state.Values[item.Key] = item.Value // A duplicated key keeps its first value, as Push does
+		}
+	}
+
+	l.mux.Lock()
+	defer l.mux.Unlock()
+
+	l.restoreState(&state)
+	l.linearSizes = encoded.LinearSizes
+	l.sizeChecker = encoded.SizeChecker
+
+	l.linearCurrentSize = l.computeSize()
+	l.publishCounters()
+	l.notifyPushed()
+
+	return l.compactLog()
+}
@@ -0,0 +1,40 @@
+package linear
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarshalJSON(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	linearClient := New(1024, true)
+	linearClient.Push("2", "b")
+	linearClient.Push("1", 1)
+	linearClient.Push("3", []interface{}{"c"})
+	linearClient.Push("2", "x")
+
+	// Testing
+	data, err := json.Marshal(linearClient)
+	assert.Nil(err)
+	assert.JSONEq(`{"linearSizes":1024,"sizeChecker":true,"items":[
+		{"key":"2","value":"b"},{"key":"1","value":1},{"key":"3","value":["c"]},{"key":"2","value":"b"}]}`, string(data))
+
+	restored := New(1, false)
+	assert.Nil(json.Unmarshal(data, restored))
+	assert.Equal([]string{"2", "1", "3", "2"}, restored.Getkeys())
+	assert.Equal(int64(1024), restored.GetLinearSizes())
+	assert.Nil(restored.CheckSize())
+
+	value, _ := restored.Read("1")
+	if value != float64(1) {
+		t.Errorf("UnmarshalJSON failed, expected %v, got %v", float64(1), value)
+	}
+
+	assert.True(errors.Is(json.Unmarshal([]byte(`{"linearSizes":0}`), restored), ErrInvalidSize))
+	assert.True(errors.Is(json.Unmarshal(data, &Linear{}), ErrInvalidArgument))
+}
@@ -0,0 +1,174 @@
+package linear
+
+import (
+	"context"
+)
+
+// streamBatch is the number of keys StreamKeys reads under one lock
+const streamBatch = 1024
+
+// StreamKeys send the keys from front to back on the returned channel, which is closed once all keys are sent,
+// ctx is done or the linear is closed. The keys are read in batches under the lock instead of copied at once, the first
+// batch on call, so keys pushed, moved or removed while streaming may or may not be sent
+func (l *Linear) StreamKeys(ctx context.Context) <-chan string {
+
+	var cursor keyCursor
+	l.mux.RLock()
+	keys := l.nextKeys(&cursor, streamBatch)
+	l.mux.RUnlock()
+
+	stream := make(chan string)
+
+	started := l.startWorker(func(done <-chan struct{}) {
+		defer close(stream)
+
+		for len(keys) > 0 {
+			for _, key := range keys {
+				select {
+				case stream <- key:
+				case <-ctx.Done():
+					return
+				case <-done:
+					return
+				}
+			}
+
+			l.mux.RLock()
+			keys = l.nextKeys(&cursor, streamBatch)
+			l.mux.RUnlock()
+		}
+	})
+
+	if !started {
+		close(stream)
+	}
+
+	return stream
+}
+
+// keyCursor is the position of StreamKeys in the list between two batches
+type keyCursor struct {
+	last nodeRef // Last node read
+	next nodeRef // Node following last when it was read
+	read int
+}
+
+// nodeRef is a node with the key and push time it had, which tell it apart from the node recycled since
+type nodeRef struct {
+	n      *node
+	key    string
+	pushed int64
+}
+
+// refOf return the reference of n, nil n included
+func refOf(n *node) nodeRef {
+	if n == nil {
+		return nodeRef{}
+	}
+	return nodeRef{n: n, key: n.key, pushed: n.pushed}
+}
+
+// linked check the node of ref is still in the list as it was, caller must hold mux
+func (l *Linear) linked(ref nodeRef) bool {
+	if ref.n == nil || ref.n.key != ref.key || ref.n.pushed != ref.pushed {
+		return false
+	}
+	for _, occurrence := range l.keys.index[ref.key] {
+		if occurrence == ref.n {
+			return true
+		}
+	}
+	return false
+}
+
+// nextKeys return up to limit keys following the cursor and move it past them, caller must hold mux
+// When the last node read and the one following it were both removed meanwhile, the walk resumes at the same
+// position from the front
+func (l *Linear) nextKeys(cursor *keyCursor, limit int) []string {
+
+	n := l.keys.head
+	switch {
+	case cursor.read == 0:
+	case l.linked(cursor.last):
+		n = cursor.last.n.next
+	case l.linked(cursor.next):
+		n = cursor.next.n
+	default:
+		for i := 0; i < cursor.read && n != nil; i++ {
+			n = n.next
+		}
+	}
+
+	keys := make([]string, 0, limit)
+	for ; n != nil && len(keys) < limit; n = n.next {
+		keys = append(keys, n.key)
+		cursor.last, cursor.next = refOf(n), refOf(n.next)
+	}
+	cursor.read += len(keys)
+
+	return keys
+}
+
+// KeysOrdered return a copy of the keys from front to back
+func (l *Linear) KeysOrdered() []string {
+	return l.Getkeys()
+}
+
+// KeysReversed return a copy of the keys from back to front
+func (l *Linear) KeysReversed() []string {
+
+	l.mux.RLock()
+	keys := l.keys.reversed()
+	l.mux.RUnlock()
+
+	return keys
+}
+
+// GetItemsMap return a copy of the items as a plain map, changing it doesn't affect the linear
+func (l *Linear) GetItemsMap() map[string]interface{} {
+
+	l.mux.RLock()
+	defer l.mux.RUnlock()
+
+	items := make(map[string]interface{}, len(l.keys.index))
+	for key := range l.keys.index {
+		if value, ok := l.items.Load(key); ok {
+			items[key] = l.resolve(value)
+		}
+	}
+
+	return items
+}
+
+// RangeOrdered call fn for every item from front to back until fn returns false
+// It iterates over a snapshot taken on call, so fn may change the linear
+func (l *Linear) RangeOrdered(fn func(key string, value interface{}) bool) {
+	l.rangeSnapshot(false, fn)
+}
+
+// RangeReverse call fn for every item from back to front until fn returns false
+// It iterates over a snapshot taken on call, so fn may change the linear
+func (l *Linear) RangeReverse(fn func(key string, value interface{}) bool) {
+	l.rangeSnapshot(true, fn)
+}
+
+// rangeSnapshot call fn with the keys and values taken together under the lock
+func (l *Linear) rangeSnapshot(reverse bool, fn func(key string, value interface{}) bool) {
+
+	l.mux.RLock()
+	keys := l.keys.slice()
+	if reverse {
+		keys = l.keys.reversed()
+	}
+	values := make([]interface{}, len(keys))
+	for i, key := range keys {
+		values[i], _ = l.loadItem(key)
+	}
+	l.mux.RUnlock()
+
+	for i, key := range keys {
+		if !fn(key, values[i]) {
+			return
+		}
+	}
+}
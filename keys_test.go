@@ -0,0 +1,125 @@
+package linear
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStreamKeys(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	linearClient := New(1024, false)
+	linearClient.Push("1", "a")
+	linearClient.Push("2", "b")
+	linearClient.Push("3", "c")
+
+	// Testing
+	var keys []string
+	stream := linearClient.StreamKeys(context.Background())
+	linearClient.Take()
+	for key := range stream {
+		keys = append(keys, key)
+	}
+
+	assert.Equal(keys, []string{"1", "2", "3"})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream = linearClient.StreamKeys(ctx)
+	<-stream
+	cancel()
+	for range stream {
+	}
+
+	assert.Nil(linearClient.Close())
+	assert.Equal(linearClient.Goroutines(), 0)
+}
+
+func TestKeysOrdered(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	linearClient := New(1024, false)
+	linearClient.Push("1", "a")
+	linearClient.Push("2", "b")
+	linearClient.Push("3", "c")
+
+	// Testing
+	keys := linearClient.KeysOrdered()
+	assert.Equal([]string{"1", "2", "3"}, keys)
+	assert.Equal([]string{"3", "2", "1"}, linearClient.KeysReversed())
+
+	keys[0] = "9"
+	assert.Equal([]string{"1", "2", "3"}, linearClient.Getkeys())
+
+	items := linearClient.GetItemsMap()
+	assert.Equal(map[string]interface{}{"1": "a", "2": "b", "3": "c"}, items)
+
+	delete(items, "1")
+	if _, exits := linearClient.IsExits("1"); !exits {
+		t.Errorf("GetItemsMap failed, expected %v, got %v", true, exits)
+	}
+}
+
+func TestRangeOrdered(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	linearClient := New(1024, false)
+	linearClient.Push("1", "a")
+	linearClient.Push("2", "b")
+	linearClient.Push("3", "c")
+
+	// Testing
+	var values []interface{}
+	linearClient.RangeOrdered(func(key string, value interface{}) bool {
+		values = append(values, value)
+		linearClient.Delete(key)
+		return true
+	})
+	assert.Equal([]interface{}{"a", "b", "c"}, values)
+	assert.True(linearClient.IsEmpty())
+
+	linearClient.Push("1", "a")
+	linearClient.Push("2", "b")
+	linearClient.Push("3", "c")
+
+	var keys []string
+	linearClient.RangeReverse(func(key string, value interface{}) bool {
+		keys = append(keys, key)
+		return len(keys) < 2
+	})
+	assert.Equal([]string{"3", "2"}, keys)
+}
+
+func TestStreamKeysBatches(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	linearClient := New(1<<24, false)
+	total := 2*streamBatch + 10
+	for i := 0; i < total; i++ {
+		linearClient.Push(strconv.Itoa(i), i)
+	}
+
+	// Testing
+	stream := linearClient.StreamKeys(context.Background())
+	var keys []string
+	for key := range stream {
+		keys = append(keys, key)
+
+		// Removing the last key of a batch resumes the next one after it
+		if len(keys) == streamBatch {
+			linearClient.Delete(key)
+		}
+	}
+
+	if len(keys) != total {
+		t.Errorf("StreamKeys failed, expected %v, got %v", total, len(keys))
+	}
+	assert.Equal(strconv.Itoa(streamBatch), keys[streamBatch])
+	assert.Equal(strconv.Itoa(total-1), keys[len(keys)-1])
+}
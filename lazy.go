@@ -0,0 +1,82 @@
+package linear
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// lazyValue is a value stored encoded by WithLazyDecode, it is decoded on first use
+type lazyValue struct {
+	key   string // Key whose codec encoded the value, aliases share it
+	data  []byte
+	once  sync.Once
+	value interface{}
+}
+
+// WithLazyDecode store the pushed and updated values of at least minBytes encoded by the codec of their key, and decode
+// them on first use, trading CPU for memory for large values that are rarely read. The decoded value is then kept with
+// the encoding, while the key stays accounted at the size of the encoding. Byte slices and values that fail to encode are stored as is.
+// Stats counts the decodes and the uses served by a value decoded already
+func WithLazyDecode(minBytes int64) Option {
+	return func(l *Linear) {
+		l.lazyMinBytes = minBytes
+	}
+}
+
+// lazyStore return the value to store for key and its size, encoded when it is large enough for lazy decoding
+func (l *Linear) lazyStore(key string, value interface{}, valueSize int64) (interface{}, int64) {
+
+	// Execution conditions
+	if l.lazyMinBytes <= 0 || valueSize < l.lazyMinBytes || value == nil {
+		return value, valueSize
+	}
+
+	// Bytes are encoded already, and Borrow hands them out without copy
+	if _, ok := value.([]byte); ok {
+		return value, valueSize
+	}
+
+	data, err := l.encodeValue(key, value)
+	if err != nil {
+		return value, valueSize
+	}
+
+	lazy := &lazyValue{key: key, data: data}
+	return lazy, l.valueSize(key, lazy)
+}
+
+// resolve return the value of a stored item, decoding it on first use when it is lazy
+func (l *Linear) resolve(item interface{}) interface{} {
+
+	lazy, ok := item.(*lazyValue)
+	if !ok {
+		return item
+	}
+
+	decoded := false
+	lazy.once.Do(func() {
+		decoded = true
+		value, err := l.decodeValue(lazy.key, lazy.data)
+		if err != nil {
+			l.logger.Printf("linear: decoding the lazy value of %q failed: %v", lazy.key, err)
+		}
+		lazy.value = value
+	})
+
+	if decoded {
+		atomic.AddInt64(&l.stats.lazyDecodes, 1)
+	} else {
+		atomic.AddInt64(&l.stats.lazyHits, 1)
+	}
+
+	return lazy.value
+}
+
+// loadItem return the value of the key, decoded when it is lazy
+func (l *Linear) loadItem(key string) (interface{}, bool) {
+	item, ok := l.items.Load(key)
+	if !ok {
+		return nil, false
+	}
+	return l.resolve(item), true
+}
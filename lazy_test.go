@@ -0,0 +1,88 @@
+package linear
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithLazyDecode(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	_, err := NewWithOptions(WithLazyDecode(-1))
+	assert.True(errors.Is(err, ErrInvalidArgument))
+
+	registry := NewTypeRegistry()
+	assert.Nil(registry.Register("label", label{}))
+	opts := []Option{WithCodec(registry.Codec(nil)), WithPrefixCodec("json:", JSONCodec{}), WithLazyDecode(32)}
+	linearClient, _ := NewWithOptions(opts...)
+	large := strings.Repeat("a", 64)
+	linearClient.Push("1", label{large})
+	linearClient.Push("2", large)
+	linearClient.Push("3", "b")
+	linearClient.Push("4", []byte(large))
+	linearClient.Push("json:1", label{large})
+	linearClient.Push("5", point{1, 2})
+
+	// Testing
+	item, _ := linearClient.items.Load("1")
+	lazy, ok := item.(*lazyValue)
+	if !ok {
+		t.Errorf("WithLazyDecode failed, expected %v, got %T", "*lazyValue", item)
+		return
+	}
+	assert.Equal(calculateValueSize(lazy.data), linearClient.valueSizes["1"])
+	assert.Nil(linearClient.CheckSize())
+
+	// Small values and bytes are stored as is
+	item, _ = linearClient.items.Load("3")
+	assert.Equal("b", item)
+	item, _ = linearClient.items.Load("5")
+	assert.Equal(point{1, 2}, item)
+	item, _ = linearClient.items.Load("4")
+	assert.Equal([]byte(large), item)
+
+	// The first read decodes, the next ones use the decoded value
+	value, _ := linearClient.Read("1")
+	assert.Equal(label{large}, value)
+	value, _ = linearClient.Read("1")
+	assert.Equal(label{large}, value)
+	stats := linearClient.Stats()
+	assert.Equal(int64(1), stats.LazyDecodes)
+	assert.Equal(int64(1), stats.LazyHits)
+
+	// Every key decodes with its own codec, aliases share the decoding
+	assert.Nil(linearClient.Alias("json:2", "1"))
+	value, _ = linearClient.Read("json:2")
+	assert.Equal(label{large}, value)
+	value, _ = linearClient.Read("json:1")
+	assert.Equal(map[string]interface{}{"Name": large}, value)
+
+	// Updates are stored encoded too
+	assert.Nil(linearClient.Update("2", strings.Repeat("c", 64)))
+	item, _ = linearClient.items.Load("2")
+	if _, ok := item.(*lazyValue); !ok {
+		t.Errorf("Update failed, expected %v, got %T", "*lazyValue", item)
+	}
+	assert.Nil(linearClient.CheckSize())
+
+	// Snapshots write the encoding of the values not decoded yet
+	var buf bytes.Buffer
+	assert.Nil(linearClient.Snapshot(&buf))
+	restored, _ := NewWithOptions(opts...)
+	assert.Nil(restored.Restore(bytes.NewReader(buf.Bytes())))
+	value, _ = restored.Read("2")
+	assert.Equal(strings.Repeat("c", 64), value)
+	value, _ = restored.Read("json:1")
+	assert.Equal(map[string]interface{}{"Name": large}, value)
+
+	// Removed values come out decoded
+	value, _ = linearClient.Get("2")
+	assert.Equal(strings.Repeat("c", 64), value)
+	linearClient.ResetStats()
+	assert.Equal(int64(0), linearClient.Stats().LazyDecodes)
+}
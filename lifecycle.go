@@ -0,0 +1,104 @@
+package linear
+
+import (
+	"sync/atomic"
+)
+
+// startWorker run fn in a background goroutine until the linear is closed, it reports false without running fn once
+// the linear is closed
+func (l *Linear) startWorker(fn func(done <-chan struct{})) bool {
+
+	l.mux.Lock()
+	defer l.mux.Unlock()
+
+	return l.startWorkerLocked(fn)
+}
+
+// startWorkerLocked is startWorker, caller must hold mux
+func (l *Linear) startWorkerLocked(fn func(done <-chan struct{})) bool {
+
+	// Close marks the linear closed under mux, so a worker added here is one it waits for
+	if l.IsClosed() {
+		return false
+	}
+
+	l.workers.Add(1)
+	atomic.AddInt32(&l.goroutines, 1)
+	go func() {
+		defer l.workers.Done()
+		defer atomic.AddInt32(&l.goroutines, -1)
+		fn(l.done)
+	}()
+
+	return true
+}
+
+// startBackground start the background work enabled by the options
+func (l *Linear) startBackground() {
+	if l.driftInterval > 0 {
+		l.startDriftCheck()
+	}
+
+	if l.persistInterval > 0 {
+		l.startPersistence()
+	}
+
+	if l.historyInterval > 0 {
+		l.startHistory()
+	}
+
+	if l.walCompactInterval > 0 {
+		l.startCompaction()
+	}
+}
+
+// Goroutines return the number of background goroutines the linear is running
+func (l *Linear) Goroutines() int {
+	return int(atomic.LoadInt32(&l.goroutines))
+}
+
+// Close stop the background work of the linear and wait for it to return, then write the persistence files if any
+// Every later operation, Close included, returns ErrClosed
+func (l *Linear) Close() error {
+
+	// Execution conditions
+	l.mux.Lock()
+	closing := atomic.CompareAndSwapInt32(&l.closed, 0, 1)
+	l.mux.Unlock()
+
+	if !closing {
+		return ErrClosed
+	}
+
+	l.stopPending()
+	close(l.done)
+	l.workers.Wait()
+	l.closeSubscribers()
+
+	var err error
+	if l.wal != nil {
+		err = l.closeLog()
+	}
+
+	if l.persistPath != "" {
+		if persistErr := l.persist(); err == nil {
+			err = persistErr
+		}
+	}
+
+	if l.spill != nil {
+		if spillErr := l.closeSpill(); err == nil {
+			err = spillErr
+		}
+	}
+
+	// The mutation hashes are kept per instance, so they would outlive it
+	l.debugReset()
+
+	return err
+}
+
+// IsClosed check if the linear was closed
+func (l *Linear) IsClosed() bool {
+	return atomic.LoadInt32(&l.closed) == 1
+}
@@ -0,0 +1,62 @@
+package linear
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClose(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	linearClient := New(1024, false)
+	linearClient.Push("1", "a")
+
+	// Testing
+	assert.False(linearClient.IsClosed())
+	assert.Nil(linearClient.Close())
+	assert.True(linearClient.IsClosed())
+
+	assert.True(errors.Is(linearClient.Close(), ErrClosed))
+	assert.True(errors.Is(linearClient.Push("2", "b"), ErrClosed))
+	assert.True(errors.Is(linearClient.Update("1", "b"), ErrClosed))
+	assert.True(errors.Is(linearClient.Delete("1"), ErrClosed))
+	assert.True(errors.Is(linearClient.Snapshot(&bytes.Buffer{}), ErrClosed))
+
+	_, err := linearClient.Read("1")
+	assert.True(errors.Is(err, ErrClosed))
+
+	_, err = linearClient.Take()
+	assert.True(errors.Is(err, ErrClosed))
+
+	_, err = linearClient.TakeWait(context.Background())
+	assert.True(errors.Is(err, ErrClosed))
+
+	_, _, err = linearClient.PeekFront()
+	assert.True(errors.Is(err, ErrClosed))
+
+	errs := linearClient.PushAll(map[string]interface{}{"3": "c"})
+	assert.True(errors.Is(errs["3"], ErrClosed))
+}
+
+func TestStartWorkerAfterClose(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	linearClient := New(1024, false)
+	assert.Nil(linearClient.Close())
+
+	// Testing
+	// A worker started late is never added to the workers Close waited for
+	ran := false
+	assert.False(linearClient.startWorker(func(done <-chan struct{}) { ran = true }))
+	assert.False(ran)
+	assert.Equal(0, linearClient.Goroutines())
+
+	_, open := <-linearClient.StreamKeys(context.Background())
+	assert.False(open)
+}
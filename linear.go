@@ -1,230 +1,740 @@
 package linear
 
 import (
+	"context"
 	"errors"
 	"log"
+	"math"
+	"os"
 	"sync"
-	"unsafe"
+	"sync/atomic"
+	"time"
 )
 
 // Linear contains all the private properties
 type Linear struct {
-	items             *sync.Map
-	keys              []string
-	sizeChecker       bool
-	linearSizes       int64 // bytes
-	linearCurrentSize int64 // bytes
-	mux               *sync.RWMutex
+	approxLen          int64 // Accessed atomically, kept first for 64-bit alignment
+	approxSize         int64
+	stats              statsCounters
+	lockStats          [lockOps]lockCounters
+	items              *sync.Map
+	keys               *keyList
+	sizeChecker        bool
+	linearSizes        int64 // bytes
+	linearCurrentSize  int64 // bytes
+	valueSizes         map[string]int64
+	refs               map[string]int
+	shared             map[string]*int
+	checksums          *checksums
+	checksumMux        sync.Mutex
+	borrowed           map[string]int
+	borrowEpoch        int // Changed by restores, so earlier borrows release nothing
+	clone              func(interface{}) interface{}
+	sizeFunc           func(key string, value interface{}) int64
+	codec              Codec
+	prefixCodecs       []prefixCodec
+	lazyMinBytes       int64
+	spill              *spillTier
+	initialCapacity    int
+	growthPolicy       GrowthPolicy
+	fallback           func(key string) (interface{}, bool)
+	eviction           EvictionPolicy
+	priorities         *priorityQueue
+	pushPriority       int
+	audit              []AuditEntry
+	lockMetrics        bool
+	contention         *contentionTracker
+	subscribers        []*subscription
+	auditMux           sync.Mutex
+	logger             Logger
+	maxItems           int
+	shardCount         int
+	fullPolicy         FullPolicy
+	blockTimeout       time.Duration
+	driftInterval      time.Duration
+	driftReport        func(computed, tracked int64)
+	pushed             chan struct{}
+	room               chan struct{}
+	debounced          map[string]*pendingPush
+	throttled          map[string]*pendingPush
+	aggregated         map[string]*pendingPush
+	aggregateWindow    time.Duration
+	aggregateMerge     func(key string, current, next interface{}) interface{}
+	wheel              *timerWheel
+	wheelTick          time.Duration
+	expiries           map[string]*wheelTimer
+	expiryClock        ExpiryClock
+	defaultTTL         time.Duration
+	slidingTTL         bool
+	refresh            *refresher
+	breaker            *loaderBreaker
+	persistPath        string
+	persistInterval    time.Duration
+	persistMux         sync.Mutex
+	openPath           string
+	wal                *os.File
+	walPath            string
+	walSeq             uint64
+	walCompactInterval time.Duration
+	restoreWorkers     int
+	restoreProgress    func(RestoreProgress)
+	history            []HistoryEntry
+	historyNext        int
+	historySize        int
+	historyInterval    time.Duration
+	historyMux         sync.Mutex
+	pendingMux         sync.Mutex
+	mux                *sync.RWMutex
+	done               chan struct{}
+	workers            sync.WaitGroup
+	goroutines         int32
+	closed             int32
 }
 
-// New return new linear instance
-func New(maxSize int64, sizeChecker bool) *Linear {
+// New return new linear instance, it exits the process on invalid arguments, use NewWithError to handle them
+func New(maxSize int64, sizeChecker bool, opts ...Option) *Linear {
 
-	// Argument validator
-	if maxSize <= 0 {
-		log.Fatalln("linearSizes much higher than 0")
+	currentLinear, err := NewWithError(maxSize, sizeChecker, opts...)
+	if err != nil {
+		log.Fatalln(err)
 	}
 
+	return currentLinear
+}
+
+// NewWithError return new linear instance or ErrInvalidSize when maxSize is not higher than 0
+func NewWithError(maxSize int64, sizeChecker bool, opts ...Option) (*Linear, error) {
+	return NewWithOptions(append([]Option{WithMaxBytes(maxSize), WithSizeChecker(sizeChecker)}, opts...)...)
+}
+
+// NewWithOptions return new linear instance configured by opts, without WithMaxBytes the linear size is unbounded
+func NewWithOptions(opts ...Option) (*Linear, error) {
+
 	currentLinear := Linear{
-		keys:              []string{},
 		items:             &sync.Map{},
-		sizeChecker:       sizeChecker,
-		linearSizes:       maxSize,
+		linearSizes:       math.MaxInt64,
 		linearCurrentSize: 0,
+		valueSizes:        map[string]int64{},
+		refs:              map[string]int{},
+		shared:            map[string]*int{},
+		logger:            log.Default(),
 		mux:               &sync.RWMutex{},
+		done:              make(chan struct{}),
+	}
+
+	for _, opt := range opts {
+		opt(&currentLinear)
+	}
+
+	// Argument validator
+	if currentLinear.linearSizes <= 0 {
+		return nil, ErrInvalidSize
+	}
+
+	if currentLinear.maxItems < 0 || currentLinear.shardCount != 0 || currentLinear.fullPolicy < EvictOldest || currentLinear.fullPolicy > Block || currentLinear.blockTimeout < 0 {
+		return nil, ErrInvalidArgument
 	}
 
-	return &currentLinear
+	if currentLinear.wheelTick < 0 || currentLinear.defaultTTL < 0 || currentLinear.expiryClock < Monotonic || currentLinear.expiryClock > WallClock {
+		return nil, ErrInvalidArgument
+	}
+
+	if currentLinear.persistInterval < 0 || (currentLinear.persistInterval > 0 && currentLinear.persistPath == "") {
+		return nil, ErrInvalidArgument
+	}
+
+	if currentLinear.historyInterval < 0 || currentLinear.historySize < 0 || (currentLinear.historyInterval > 0 && currentLinear.historySize == 0) {
+		return nil, ErrInvalidArgument
+	}
+
+	if currentLinear.walCompactInterval < 0 || currentLinear.restoreWorkers < 0 || (currentLinear.walCompactInterval > 0 && currentLinear.walPath == "") {
+		return nil, ErrInvalidArgument
+	}
+
+	if currentLinear.contention != nil && !currentLinear.contention.init() {
+		return nil, ErrInvalidArgument
+	}
+
+	if currentLinear.checksums != nil && (currentLinear.checksums.rate <= 0 || currentLinear.checksums.rate > 1) {
+		return nil, ErrInvalidArgument
+	}
+
+	if currentLinear.refresh != nil && (currentLinear.refresh.load == nil || currentLinear.refresh.fraction <= 0 || currentLinear.refresh.fraction >= 1) {
+		return nil, ErrInvalidArgument
+	}
+
+	for i, prefixed := range currentLinear.prefixCodecs {
+		for _, other := range currentLinear.prefixCodecs[:i] {
+			if other.prefix == prefixed.prefix {
+				return nil, ErrInvalidArgument
+			}
+		}
+		if prefixed.prefix == "" || prefixed.codec == nil {
+			return nil, ErrInvalidArgument
+		}
+	}
+
+	if currentLinear.lazyMinBytes < 0 {
+		return nil, ErrInvalidArgument
+	}
+
+	if currentLinear.breaker != nil && (currentLinear.breaker.failures <= 0 || currentLinear.breaker.coolDown <= 0) {
+		return nil, ErrInvalidArgument
+	}
+
+	// Snapshots and the append log leave spilled items out, so they would be lost on restart
+	if currentLinear.spill != nil && (currentLinear.spill.dir == "" || currentLinear.spill.maxBytes <= 0 || currentLinear.persistPath != "" || currentLinear.walPath != "") {
+		return nil, ErrInvalidArgument
+	}
+
+	if currentLinear.aggregateWindow < 0 || (currentLinear.aggregateWindow > 0 && currentLinear.aggregateMerge == nil) {
+		return nil, ErrInvalidArgument
+	}
+
+	currentLinear.keys = newKeyList(currentLinear.initialCapacity, currentLinear.growthPolicy)
+
+	if currentLinear.spill != nil {
+		if err := currentLinear.openSpill(); err != nil {
+			return nil, err
+		}
+	}
+
+	if currentLinear.openPath != "" {
+		if err := currentLinear.openFile(); err != nil {
+			return nil, err
+		}
+	}
+
+	if currentLinear.walPath != "" {
+		if err := currentLinear.openLog(); err != nil {
+			return nil, err
+		}
+	}
+
+	currentLinear.startBackground()
+
+	return &currentLinear, nil
 }
 
 // Push item to the linear with key
+// Pushing a key that already exits keeps the stored value and adds the key once more, use Upsert to replace it
 func (l *Linear) Push(key string, value interface{}) error {
+	return l.pushTo(key, value, false)
+}
+
+// PushContext push item to the linear with key like Push, with the Block full policy it stops waiting for room once ctx is done
+func (l *Linear) PushContext(ctx context.Context, key string, value interface{}) error {
+	return l.pushWait(ctx, key, value, false)
+}
+
+// PushBack is Push, it names the end when the linear is used as a deque
+func (l *Linear) PushBack(key string, value interface{}) error {
+	return l.pushTo(key, value, false)
+}
+
+// PushFront push item to the front of the linear with key, Take returns it first
+// Under size pressure FIFO evicts the oldest item, now at the back, and LIFO the newest one, now at the front
+func (l *Linear) PushFront(key string, value interface{}) error {
+	return l.pushTo(key, value, true)
+}
+
+// PushFrontContext push item to the front of the linear with key like PushFront, with the Block full policy it stops
+// waiting for room once ctx is done
+func (l *Linear) PushFrontContext(ctx context.Context, key string, value interface{}) error {
+	return l.pushWait(ctx, key, value, true)
+}
+
+// pushTo push item to the front or the back of the linear
+func (l *Linear) pushTo(key string, value interface{}, front bool) error {
+	ctx, cancel := l.blockContext()
+	defer cancel()
+	return l.pushWait(ctx, key, value, front)
+}
+
+// blockContext return the context of the pushes without one, it is done once the WithBlockTimeout timeout elapsed
+func (l *Linear) blockContext() (context.Context, context.CancelFunc) {
+	if l.blockTimeout > 0 {
+		return context.WithTimeout(context.Background(), l.blockTimeout)
+	}
+	return context.Background(), func() {}
+}
+
+// pushWait push item to the front or the back of the linear, waiting for room with the Block full policy
+func (l *Linear) pushWait(ctx context.Context, key string, value interface{}, front bool) error {
+	_, err := l.pushEvicting(ctx, key, value, front)
+	return err
+}
+
+// pushEvicting push item like pushWait and report if making room for it evicted an item
+func (l *Linear) pushEvicting(ctx context.Context, key string, value interface{}, front bool) (bool, error) {
+
+	// Execution conditions
+	if l.IsClosed() {
+		return false, ErrClosed
+	}
 
 	// Argument validator
 	if key == "" && value == nil {
-		return errors.New("key and value should not be empty")
+		return false, ErrInvalidKey
+	}
+
+	if l.clone != nil {
+		value = l.clone(value)
+	}
+
+	valueSize := l.valueSize(key, value)
+
+	// Evictions happen under mux, so the ones counted while holding it are made by this push
+	evicted := false
+	err := l.withRoom(ctx, lockPush, func() error {
+		evictions := atomic.LoadInt64(&l.stats.evictions)
+		err := l.pushTTL(key, value, valueSize, front)
+		evicted = evicted || atomic.LoadInt64(&l.stats.evictions) > evictions
+		return err
+	})
+
+	return evicted, err
+}
+
+// withRoom run push under the lock of op, with the Block full policy it waits for room and runs push again while it
+// fails with ErrFull
+func (l *Linear) withRoom(ctx context.Context, op lockOp, push func() error) error {
+
+	for {
+		acquired := l.lock(op)
+		err := push()
+
+		if l.fullPolicy != Block || !errors.Is(err, ErrFull) {
+			l.unlock(op, acquired)
+			return err
+		}
+
+		room := l.roomChan()
+		l.unlock(op, acquired)
+
+		select {
+		case <-room:
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-l.done:
+			return ErrClosed
+		}
 	}
+}
+
+// push store the item at the back of the linear after making room for it and start the default TTL, caller must hold mux
+func (l *Linear) push(key string, value interface{}, valueSize int64) error {
+	return l.pushTTL(key, value, valueSize, false)
+}
+
+// pushTTL store the item at the front or the back of the linear like pushEnd and start the default TTL of its key
+// Every push path goes through it but the replay of the append log, caller must hold mux
+func (l *Linear) pushTTL(key string, value interface{}, valueSize int64, front bool) error {
+
+	if err := l.pushEnd(key, value, valueSize, front); err != nil {
+		return err
+	}
+
+	if l.defaultTTL > 0 {
+		l.setExpiry(key, l.defaultTTL)
+	}
+
+	return nil
+}
+
+// pushEnd store the item at the front or the back of the linear after making room for it, caller must hold mux
+func (l *Linear) pushEnd(key string, value interface{}, valueSize int64, front bool) error {
+
+	keySize := calculateKeySize(key)
+	stored := value
+	if _, loaded := l.items.Load(key); loaded {
+		valueSize = l.valueSizes[key] // The key keeps its stored value
+	} else {
+		stored, valueSize = l.lazyStore(key, value, valueSize)
+	}
+	itemSize := keySize + valueSize
 
-	itemSize := int64(unsafe.Sizeof(key)) + int64(unsafe.Sizeof(value))
 	if itemSize > l.linearSizes {
-		return errors.New("linear doesn't have enough memory space")
+		return newError("push", key, ErrCapacityExceeded)
 	}
 
 	// Clean space for new item
-	if l.sizeChecker {
-		for l.linearCurrentSize+itemSize > l.linearSizes {
-			if _, err := l.Take(); err != nil {
-				return err
-			}
-		}
+	if err := l.makeRoom("push", key, itemSize, front); err != nil {
+		return err
 	}
 
-	l.items.LoadOrStore(key, value)
-	l.mux.Lock()
+	actual, loaded := l.items.LoadOrStore(key, stored)
+	if loaded {
+		itemSize = keySize + l.valueSizes[key]
+	} else {
+		itemSize = keySize + valueSize
+		l.valueSizes[key] = valueSize
+	}
+
+	l.debugTrack(key, actual)
+	l.checksumTrack(key, actual)
 	l.linearCurrentSize += itemSize
-	l.keys = append(l.keys, key)
-	l.mux.Unlock()
+	if front {
+		l.nodePushed(l.keys.pushFront(key))
+	} else {
+		l.nodePushed(l.keys.pushBack(key))
+	}
+	l.evictionPushed(key)
+	l.countWrite(key)
+	l.publishCounters()
+	l.notifyPushed()
+	l.emit(Pushed, key, actual)
+	l.logRecord(walRecord{Op: walPush, Key: key, Value: value, Front: front})
+	atomic.AddInt64(&l.stats.pushes, 1)
 
 	return nil
 }
 
 // Pop return and remove the last item out of the linear
 func (l *Linear) Pop() (interface{}, error) {
+	entry, err := l.PopEntry()
+	return entry.Value, err
+}
+
+// PopEntry return and remove the last item out of the linear with its key
+func (l *Linear) PopEntry() (Entry, error) {
 
 	// Execution conditions
-	if l.IsEmpty() {
-		return nil, errors.New("linear is empty")
+	if l.IsClosed() {
+		return Entry{}, ErrClosed
 	}
 
-	lastItemIndex := len(l.keys) - 1
-	item, ok := l.items.Load(l.keys[lastItemIndex])
-	if !ok {
-		return nil, nil
+	if l.IsEmpty() && !l.hasSpilled() {
+		return Entry{}, ErrEmpty
 	}
 
-	itemSize := int64(unsafe.Sizeof(item)) + int64(unsafe.Sizeof(l.keys[lastItemIndex]))
+	acquired := l.lock(lockPop)
+	last := l.backNode()
+	if last == nil && l.spill != nil {
+		entry, err := l.takeSpilled(false)
+		if err == nil {
+			l.emit(Popped, entry.Key, entry.Value)
+		}
+		l.unlock(lockPop, acquired)
+		return entry, err
+	}
+	if last == nil {
+		l.unlock(lockPop, acquired)
+		return Entry{}, ErrEmpty
+	}
 
-	l.items.Delete(l.keys[lastItemIndex])
-	l.mux.Lock()
-	l.linearCurrentSize -= itemSize
-	l.keys = removeItemByIndex(l.keys, lastItemIndex) //Update keys slice after remove that key out of the map
-	l.mux.Unlock()
+	entry := l.entryOf(last)
+	l.removeNode(last, entry.Value)
+	entry.Value = l.resolve(entry.Value)
+	l.emit(Popped, entry.Key, entry.Value)
+	l.unlock(lockPop, acquired)
 
-	return item, nil
+	return entry, nil
 }
 
 // Take return and remove the first item out of the linear
 func (l *Linear) Take() (interface{}, error) {
+	entry, err := l.TakeEntry()
+	return entry.Value, err
+}
+
+// TakeEntry return and remove the first item out of the linear with its key
+func (l *Linear) TakeEntry() (Entry, error) {
 
 	// Execution conditions
-	if l.IsEmpty() {
-		return nil, errors.New("can't take, because linear is empty")
+	if l.IsClosed() {
+		return Entry{}, ErrClosed
 	}
 
-	item, ok := l.items.Load(l.keys[0])
-	if !ok {
-		return nil, nil
+	if l.IsEmpty() && !l.hasSpilled() {
+		return Entry{}, ErrEmpty
 	}
 
-	itemSize := int64(unsafe.Sizeof(item)) + int64(unsafe.Sizeof(l.keys[0]))
+	acquired := l.lock(lockTake)
 
-	l.items.Delete(l.keys[0])
-	l.mux.Lock()
-	l.linearCurrentSize -= itemSize
-	l.keys = removeItemByIndex(l.keys, 0) //Update keys slice after remove that key/value from items map
-	l.mux.Unlock()
+	// Spilled items are older than the ones in memory
+	if l.hasSpilled() {
+		entry, err := l.takeSpilled(true)
+		if err == nil {
+			l.emit(Taken, entry.Key, entry.Value)
+		}
+		l.unlock(lockTake, acquired)
+		return entry, err
+	}
+
+	first := l.frontNode()
+	if first == nil {
+		l.unlock(lockTake, acquired)
+		return Entry{}, ErrEmpty
+	}
+
+	entry := l.entryOf(first)
+	l.removeNode(first, entry.Value)
+	entry.Value = l.resolve(entry.Value)
+	l.emit(Taken, entry.Key, entry.Value)
+	l.unlock(lockTake, acquired)
 
-	return item, nil
+	return entry, nil
 }
 
 // Get method return and remove the item by key out of the linear
 func (l *Linear) Get(key string) (interface{}, error) {
+	entry, err := l.GetEntry(key)
+	return entry.Value, err
+}
+
+// GetEntry return and remove the front-most occurrence of the key out of the linear with its item
+func (l *Linear) GetEntry(key string) (Entry, error) {
 
 	// Execution conditions
-	if l.IsEmpty() {
-		return nil, errors.New("linear is empty")
+	if l.IsClosed() {
+		return Entry{}, ErrClosed
 	}
 
-	var (
-		wg             sync.WaitGroup
-		item           interface{}
-		itemExits      bool
-		itemIndex      int
-		itemIndexExits bool
-	)
-
-	wg.Add(2)
-	go func() {
-		item, itemExits = l.items.Load(key)
-		wg.Done()
-	}()
-
-	go func() {
-		itemIndex, itemIndexExits = findIndexByItem(key, l.keys)
-		wg.Done()
-	}()
-	wg.Wait()
+	if l.IsEmpty() && !l.hasSpilled() {
+		l.countLookup(false)
+		return Entry{}, ErrEmpty
+	}
 
-	if !itemExits || !itemIndexExits {
-		return nil, nil
+	acquired := l.lock(lockGet)
+	n := l.keys.first(key)
+	if _, itemExits := l.items.Load(key); !itemExits || n == nil {
+		if l.hasSpilled() && len(l.spill.keys[key]) > 0 {
+			entry, err := l.takeSpillRecord(l.spill.keys[key][0])
+			if err == nil {
+				l.emit(Taken, key, entry.Value)
+			}
+			l.unlock(lockGet, acquired)
+			l.countLookup(err == nil)
+			return entry, err
+		}
+		l.unlock(lockGet, acquired)
+		l.countLookup(false)
+		return Entry{}, newError("get", key, ErrKeyNotFound)
 	}
 
-	l.items.Delete(key)
-	l.mux.Lock()
-	l.linearCurrentSize -= int64(unsafe.Sizeof(item))
-	l.keys = removeItemByIndex(l.keys, itemIndex) //Update keys slice after remove that key from items map
-	l.mux.Unlock()
+	entry := l.entryOf(n)
+	l.removeNode(n, entry.Value)
+	entry.Value = l.resolve(entry.Value)
+	l.emit(Taken, key, entry.Value)
+	l.unlock(lockGet, acquired)
+	l.countLookup(true)
 
-	return item, nil
+	return entry, nil
 }
 
 // Read method return the item by key from linear without remove it
 func (l *Linear) Read(key string) (interface{}, error) {
 
 	// Execution conditions
+	if l.IsClosed() {
+		return nil, ErrClosed
+	}
+
 	if l.IsEmpty() {
-		return nil, errors.New("linear is empty")
+		if item, ok := l.readSpilled(key); ok {
+			l.countLookup(true)
+			return item, nil
+		}
+		l.countLookup(false)
+		if item, ok := l.readFallback(key); ok {
+			return item, nil
+		}
+		return nil, ErrEmpty
 	}
 
 	item, ok := l.items.Load(key)
 	if !ok {
-		return nil, nil
+		if item, ok = l.readSpilled(key); ok {
+			l.countLookup(true)
+			return item, nil
+		}
+	}
+	l.countLookup(ok)
+	if !ok {
+		if item, ok = l.readFallback(key); ok {
+			return item, nil
+		}
+		return nil, newError("read", key, ErrKeyNotFound)
 	}
 
-	return item, nil
+	l.debugCheck(key, item)
+	l.checksumCheck(key, item)
+	l.evictionAccessed(key)
+	l.slide(key)
+	l.refreshAhead(key)
+
+	return l.resolve(item), nil
+}
+
+// UpdateOptions decide what an update does to the lifetime and the access stats of the key
+type UpdateOptions struct {
+	ResetTTL bool // Restart the TTL of the key from now, otherwise its deadline is kept
+	Touch    bool // Count the update as an access of the key for the eviction policy
 }
 
 // Update reassign value to the key
+// The key keeps its expiry deadline and the update counts as an access, use UpdateWithOptions to change either
 func (l *Linear) Update(key string, value interface{}) error {
+	return l.UpdateWithOptions(key, value, UpdateOptions{Touch: true})
+}
+
+// UpdateWithOptions reassign value to the key, opts decide if its TTL restarts and if the update counts as an access
+// Spilled keys have no TTL and no access stats, so opts don't apply to them
+func (l *Linear) UpdateWithOptions(key string, value interface{}, opts UpdateOptions) error {
+
+	// Execution conditions
+	if l.IsClosed() {
+		return ErrClosed
+	}
 
 	// Argument validator
 	if key == "" && value == nil {
-		return errors.New("key and value should not be empty")
+		return ErrInvalidKey
 	}
 
 	// Execution conditions
-	if l.IsEmpty() {
-		return errors.New("linear is empty")
+	if l.IsEmpty() && !l.hasSpilled() {
+		return ErrEmpty
+	}
+
+	newValueSize := l.valueSize(key, value)
+	if calculateKeySize(key)+newValueSize > l.GetLinearSizes() {
+		return newError("update", key, ErrCapacityExceeded)
+	}
+
+	if l.clone != nil {
+		value = l.clone(value)
 	}
 
-	newItemSize := int64(unsafe.Sizeof(key)) + int64(unsafe.Sizeof(value))
-	if newItemSize > l.linearSizes || l.IsEmpty() {
-		return errors.New("linear is empty or not enough space")
+	acquired := l.lock(lockUpdate)
+	err := l.replace(key, value, newValueSize, opts.Touch)
+	if t, ok := l.expiries[key]; ok && err == nil && opts.ResetTTL {
+		l.setExpiry(key, t.ttl)
 	}
+	l.unlock(lockUpdate, acquired)
 
-	currentSize, exits := l.IsExits(key)
-	if !exits {
-		return errors.New("key does not exit")
+	if err != nil {
+		return err
+	}
+	atomic.AddInt64(&l.stats.updates, 1)
+
+	return nil
+}
+
+// update replace the value of the key and count it as an access, caller must hold mux
+func (l *Linear) update(key string, value interface{}, newValueSize int64) error {
+	return l.replace(key, value, newValueSize, true)
+}
+
+// replace replace the value of the key, touch tells the eviction policy it was accessed, caller must hold mux
+func (l *Linear) replace(key string, value interface{}, newValueSize int64, touch bool) error {
+
+	if _, exits := l.items.Load(key); !exits {
+		if l.hasSpilled() && len(l.spill.keys[key]) > 0 {
+			return l.updateSpilled(key, value, newValueSize)
+		}
+		return newError("update", key, ErrKeyNotFound)
+	}
+
+	logged := value
+	value, newValueSize = l.lazyStore(key, value, newValueSize)
+
+	occurrences := int64(len(l.keys.index[key]))
+	oldValueSize := l.valueSizes[key]
+	delta := occurrences * (newValueSize - oldValueSize)
+
+	// The key stops sharing its value with its aliases
+	if group, ok := l.shared[key]; ok {
+		delete(l.shared, key)
+		*group--
+		if *group > 0 {
+			delta += oldValueSize // The aliases keep accounting the shared value
+		}
 	}
 
 	l.items.Store(key, value)
-	l.mux.Lock()
-	l.linearCurrentSize -= currentSize
-	l.linearCurrentSize += newItemSize
-	l.mux.Unlock()
+	l.debugTrack(key, value)
+	l.checksumTrack(key, value)
+	l.valueSizes[key] = newValueSize
+	if touch {
+		l.evictionAccessed(key)
+	}
+	l.countWrite(key)
+	l.emit(Updated, key, value)
+	l.linearCurrentSize += delta
+	l.publishCounters()
+	l.logRecord(walRecord{Op: walUpdate, Key: key, Value: logged})
 
 	return nil
 }
 
-// Range the LinearClient
+// Range the LinearClient in no particular order, use RangeOrdered to follow the linear order
 func (l *Linear) Range(fn func(key, value interface{}) bool) {
-	l.items.Range(fn)
+	l.items.Range(func(key, value interface{}) bool {
+		return fn(key, l.resolve(value))
+	})
 }
 
 // IsExits check key exits or not and return size and status
 func (l *Linear) IsExits(key string) (int64, bool) {
 
-	value, exits := l.items.Load(key)
-	if !exits {
+	if _, exits := l.items.Load(key); !exits {
 		return 0, false
 	}
 
-	return int64(unsafe.Sizeof(key)) + int64(unsafe.Sizeof(value)), true
+	l.mux.RLock()
+	valueSize := l.valueSizes[key]
+	l.mux.RUnlock()
+
+	return calculateKeySize(key) + valueSize, true
+}
+
+// removeNode unlink n from the keys and delete its item once no other occurrence of the key is left, caller must hold mux
+func (l *Linear) removeNode(n *node, item interface{}) {
+	key := n.key
+	l.notifyRoom()
+	if l.wal != nil {
+		l.logRecord(walRecord{Op: walRemove, Key: key, Occurrence: l.keys.occurrence(n)})
+	}
+	l.priorityRemoved(n)
+	l.keys.remove(n)
+	if l.keys.contains(key) {
+		l.linearCurrentSize -= calculateKeySize(key) + l.valueSizes[key]
+		l.publishCounters()
+		return
+	}
+
+	l.debugForget(key, item)
+	l.checksumForget(key)
+	l.evictionRemoved(key)
+	l.items.Delete(key)
+	l.linearCurrentSize -= l.releaseItem(key)
+	l.publishCounters()
+}
+
+// releaseItem drop the references held by the key and return the size it frees, caller must hold mux
+func (l *Linear) releaseItem(key string) int64 {
+	valueSize := l.valueSizes[key]
+	delete(l.valueSizes, key)
+	delete(l.refs, key)
+	l.cancelExpiry(key)
+
+	size := calculateKeySize(key)
+	if group, ok := l.shared[key]; ok {
+		delete(l.shared, key)
+		*group--
+		if *group > 0 {
+			return size // Other keys still hold the value
+		}
+	}
+
+	return size + valueSize
 }
 
 // IsEmpty check linear size
 func (l *Linear) IsEmpty() bool {
-	return len(l.keys) == 0
+	return l.GetNumberOfKeys() == 0
 }
 
 // GetItems return the map contain items
@@ -232,19 +742,34 @@ func (l *Linear) GetItems() *sync.Map {
 	return l.items
 }
 
-// Getkeys return the list of key
+// Getkeys return a copy of the list of key from front to back
 func (l *Linear) Getkeys() []string {
-	return l.keys
+
+	l.mux.RLock()
+	keys := l.keys.slice()
+	l.mux.RUnlock()
+
+	return keys
 }
 
 // GetNumberOfKeys return the number of keys
 func (l *Linear) GetNumberOfKeys() int {
-	return len(l.keys)
+
+	l.mux.RLock()
+	numberOfKeys := l.keys.len
+	l.mux.RUnlock()
+
+	return numberOfKeys
 }
 
 // GetLinearSizes return the linear size
 func (l *Linear) GetLinearSizes() int64 {
-	return l.linearSizes
+
+	l.mux.RLock()
+	linearSizes := l.linearSizes
+	l.mux.RUnlock()
+
+	return linearSizes
 }
 
 // SetLinearSizes change the linear size with new value
@@ -252,11 +777,12 @@ func (l *Linear) SetLinearSizes(linearSizes int64) error {
 
 	// Argument validator
 	if linearSizes <= 0 {
-		return errors.New("linearSizes much higher than 0")
+		return ErrInvalidSize
 	}
 
 	l.mux.Lock()
 	l.linearSizes = linearSizes
+	l.notifyRoom()
 	l.mux.Unlock()
 
 	return nil
@@ -1,6 +1,8 @@
 package linear
 
 import (
+	"errors"
+	"strconv"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -185,6 +187,36 @@ func TestUpdate(t *testing.T) {
 	assert.Equal(linearClient.GetNumberOfKeys(), 3)
 }
 
+func TestLargeOrdering(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	linearClient := New(1<<30, false)
+	for i := 0; i < 100000; i++ {
+		linearClient.Push(strconv.Itoa(i), i)
+	}
+
+	// Testing
+	for i := 0; i < 100000; i += 1000 {
+		value, _ := linearClient.Get(strconv.Itoa(i))
+		assert.Equal(value, i)
+	}
+
+	for i := 0; i < 100000; i++ {
+		if i%1000 == 0 {
+			continue
+		}
+
+		value, err := linearClient.Take()
+		if err != nil || value != i {
+			t.Fatalf("Take failed, expected %v, got %v (%v)", i, value, err)
+		}
+	}
+
+	assert.True(linearClient.IsEmpty())
+	assert.Equal(linearClient.GetLinearCurrentSize(), int64(0))
+}
+
 func BenchmarkPush(b *testing.B) {
 
 	linearClient := New(1000000, true)
@@ -206,3 +238,94 @@ func BenchmarkRead(b *testing.B) {
 		linearClient.Read("1")
 	}
 }
+
+func BenchmarkGetDrain(b *testing.B) {
+
+	// Setting up
+	keys := make([]string, 10000)
+	for i := range keys {
+		keys[i] = strconv.Itoa(i)
+	}
+
+	// Run a keyed drain of the whole linear b.N times
+	for n := 0; n < b.N; n++ {
+		b.StopTimer()
+		linearClient := New(1<<30, false)
+		for _, key := range keys {
+			linearClient.Push(key, key)
+		}
+		b.StartTimer()
+
+		for i := len(keys) - 1; i >= 0; i-- {
+			linearClient.Get(keys[i/2+(i%2)*(len(keys)/2)])
+		}
+	}
+}
+
+func BenchmarkTakeDrain(b *testing.B) {
+
+	// Setting up
+	keys := make([]string, 100000)
+	for i := range keys {
+		keys[i] = strconv.Itoa(i)
+	}
+
+	// Run a front drain of the whole linear b.N times
+	for n := 0; n < b.N; n++ {
+		b.StopTimer()
+		linearClient := New(1<<30, false)
+		for _, key := range keys {
+			linearClient.Push(key, key)
+		}
+		b.StartTimer()
+
+		for !linearClient.IsEmpty() {
+			linearClient.Take()
+		}
+	}
+}
+
+func TestNewWithError(t *testing.T) {
+	assert := assert.New(t)
+
+	// Testing
+	linearClient, err := NewWithError(1024, false)
+	assert.Nil(err)
+	assert.NotNil(linearClient)
+
+	_, err = NewWithError(0, false)
+	assert.True(errors.Is(err, ErrInvalidSize))
+
+	assert.True(errors.Is(linearClient.SetLinearSizes(-1), ErrInvalidSize))
+}
+
+func TestPushFront(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	linearClient, _ := NewWithOptions(WithMaxItems(3))
+	linearClient.PushBack("b", 2)
+	linearClient.PushFront("a", 1)
+	linearClient.PushBack("c", 3)
+
+	// Testing
+	assert.Equal([]string{"a", "b", "c"}, linearClient.Getkeys())
+
+	// The back is the oldest end for a push to the front
+	assert.Nil(linearClient.PushFront("z", 0))
+	if keys := linearClient.Getkeys(); len(keys) != 3 || keys[0] != "z" || keys[2] != "b" {
+		t.Errorf("PushFront failed, expected %v, got %v", []string{"z", "a", "b"}, keys)
+	}
+
+	item, _ := linearClient.Take()
+	assert.Equal(0, item)
+	item, _ = linearClient.Pop()
+	assert.Equal(2, item)
+
+	lifoClient, _ := NewWithOptions(WithMaxItems(2), WithEvictionPolicy(LIFO()))
+	lifoClient.PushFront("a", 1)
+	lifoClient.PushFront("b", 2)
+	lifoClient.PushFront("c", 3)
+	assert.Equal([]string{"c", "a"}, lifoClient.Getkeys())
+	assert.Nil(linearClient.CheckSize())
+}
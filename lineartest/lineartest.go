@@ -0,0 +1,33 @@
+// Package lineartest provides helpers to check linear instances from user test suites
+package lineartest
+
+import (
+	"errors"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/golang-common-packages/linear"
+)
+
+// leakTimeout is how long AssertNoLeaks waits for the goroutines to exit
+const leakTimeout = time.Second
+
+// AssertNoLeaks close l and fail the test unless the number of running goroutines drops back to before within a second
+// before is runtime.NumGoroutine() taken before l was created, tests running in parallel must not start goroutines meanwhile
+func AssertNoLeaks(t testing.TB, l *linear.Linear, before int) {
+	t.Helper()
+
+	if err := l.Close(); err != nil && !errors.Is(err, linear.ErrClosed) {
+		t.Errorf("Close failed, expected %v, got %v", nil, err)
+	}
+
+	deadline := time.Now().Add(leakTimeout)
+	for runtime.NumGoroutine() > before && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+
+	if n := runtime.NumGoroutine(); n > before {
+		t.Errorf("linear leaked goroutines, expected %v, got %v", before, n)
+	}
+}
@@ -0,0 +1,46 @@
+package lineartest
+
+import (
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/golang-common-packages/linear"
+	"github.com/stretchr/testify/assert"
+)
+
+// failRecorder is a testing.TB recording failures instead of reporting them
+type failRecorder struct {
+	testing.TB
+	failed bool
+}
+
+func (r *failRecorder) Helper() {}
+
+func (r *failRecorder) Errorf(format string, args ...interface{}) {
+	r.failed = true
+}
+
+func TestAssertNoLeaks(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	before := runtime.NumGoroutine()
+	linearClient := linear.New(1024, false, linear.WithDriftCheck(time.Millisecond, nil))
+	assert.Equal(linearClient.Goroutines(), 1)
+
+	// Testing
+	AssertNoLeaks(t, linearClient, before)
+	assert.Equal(linearClient.Goroutines(), 0)
+
+	// A goroutine left running is reported
+	before = runtime.NumGoroutine()
+	leaking := linear.New(1024, false)
+	stop := make(chan struct{})
+	go func() { <-stop }()
+
+	recorder := &failRecorder{TB: t}
+	AssertNoLeaks(recorder, leaking, before)
+	close(stop)
+	assert.True(recorder.failed)
+}
@@ -0,0 +1,407 @@
+package lineartest
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-common-packages/linear"
+)
+
+// Subject is the method set the model describes, *linear.Linear implements it
+type Subject interface {
+	Push(key string, value interface{}) error
+	PushFront(key string, value interface{}) error
+	Pop() (interface{}, error)
+	Take() (interface{}, error)
+	Get(key string) (interface{}, error)
+	Read(key string) (interface{}, error)
+	Update(key string, value interface{}) error
+	Delete(key string) error
+	Getkeys() []string
+	GetNumberOfKeys() int
+	IsEmpty() bool
+}
+
+// Model is a sequential reference of the linear without byte limits, TTL or eviction policy
+// It keeps the occurrences of the keys from front to back and one value per key, like the linear does
+type Model struct {
+	keys       []string
+	values     map[string]interface{}
+	maxItems   int
+	fullPolicy linear.FullPolicy
+}
+
+// NewModel return an empty model, maxItems and fullPolicy mirror WithMaxItems and WithFullPolicy, Block is not modelled
+func NewModel(maxItems int, fullPolicy linear.FullPolicy) *Model {
+	return &Model{values: map[string]interface{}{}, maxItems: maxItems, fullPolicy: fullPolicy}
+}
+
+// Push item to the model with key
+func (m *Model) Push(key string, value interface{}) error {
+	return m.push(key, value, false)
+}
+
+// PushFront push item to the front of the model with key
+func (m *Model) PushFront(key string, value interface{}) error {
+	return m.push(key, value, true)
+}
+
+// push store the item at the front or the back of the model after making room for it
+func (m *Model) push(key string, value interface{}, front bool) error {
+
+	// Argument validator
+	if key == "" && value == nil {
+		return linear.ErrInvalidKey
+	}
+
+	// Pushing to the front reverses the ages, so the oldest item is at the back
+	for m.maxItems > 0 && len(m.keys) >= m.maxItems {
+		if m.fullPolicy != linear.EvictOldest {
+			return linear.ErrFull
+		}
+		if front {
+			m.removeAt(len(m.keys) - 1)
+		} else {
+			m.removeAt(0)
+		}
+	}
+
+	if _, exits := m.values[key]; !exits {
+		m.values[key] = value
+	}
+
+	if front {
+		m.keys = append([]string{key}, m.keys...)
+	} else {
+		m.keys = append(m.keys, key)
+	}
+
+	return nil
+}
+
+// Pop return and remove the last item out of the model
+func (m *Model) Pop() (interface{}, error) {
+
+	// Execution conditions
+	if len(m.keys) == 0 {
+		return nil, linear.ErrEmpty
+	}
+
+	return m.removeAt(len(m.keys) - 1), nil
+}
+
+// Take return and remove the first item out of the model
+func (m *Model) Take() (interface{}, error) {
+
+	// Execution conditions
+	if len(m.keys) == 0 {
+		return nil, linear.ErrEmpty
+	}
+
+	return m.removeAt(0), nil
+}
+
+// Get return and remove the front-most occurrence of the key out of the model
+func (m *Model) Get(key string) (interface{}, error) {
+
+	// Execution conditions
+	if len(m.keys) == 0 {
+		return nil, linear.ErrEmpty
+	}
+
+	for i := range m.keys {
+		if m.keys[i] == key {
+			return m.removeAt(i), nil
+		}
+	}
+
+	return nil, linear.ErrKeyNotFound
+}
+
+// Read return the item by key from the model without remove it
+func (m *Model) Read(key string) (interface{}, error) {
+
+	// Execution conditions
+	if len(m.keys) == 0 {
+		return nil, linear.ErrEmpty
+	}
+
+	value, exits := m.values[key]
+	if !exits {
+		return nil, linear.ErrKeyNotFound
+	}
+
+	return value, nil
+}
+
+// Update reassign value to the key
+func (m *Model) Update(key string, value interface{}) error {
+
+	// Argument validator
+	if key == "" && value == nil {
+		return linear.ErrInvalidKey
+	}
+
+	// Execution conditions
+	if len(m.keys) == 0 {
+		return linear.ErrEmpty
+	}
+
+	if _, exits := m.values[key]; !exits {
+		return linear.ErrKeyNotFound
+	}
+	m.values[key] = value
+
+	return nil
+}
+
+// Delete remove every occurrence of the key and its item out of the model
+func (m *Model) Delete(key string) error {
+
+	if _, exits := m.values[key]; !exits {
+		return linear.ErrKeyNotFound
+	}
+
+	keys := m.keys[:0]
+	for _, k := range m.keys {
+		if k != key {
+			keys = append(keys, k)
+		}
+	}
+	m.keys = keys
+	delete(m.values, key)
+
+	return nil
+}
+
+// Getkeys return a copy of the list of key from front to back
+func (m *Model) Getkeys() []string {
+	return append([]string{}, m.keys...)
+}
+
+// GetNumberOfKeys return the number of keys
+func (m *Model) GetNumberOfKeys() int {
+	return len(m.keys)
+}
+
+// IsEmpty check model size
+func (m *Model) IsEmpty() bool {
+	return len(m.keys) == 0
+}
+
+// removeAt remove the occurrence at i and return its value, the value is dropped with the last occurrence of its key
+func (m *Model) removeAt(i int) interface{} {
+
+	key := m.keys[i]
+	value := m.values[key]
+	m.keys = append(m.keys[:i], m.keys[i+1:]...)
+
+	for _, k := range m.keys {
+		if k == key {
+			return value
+		}
+	}
+	delete(m.values, key)
+
+	return value
+}
+
+// OpKind is an operation of Subject
+type OpKind int
+
+const (
+	OpPush OpKind = iota
+	OpPushFront
+	OpPop
+	OpTake
+	OpGet
+	OpRead
+	OpUpdate
+	OpDelete
+	opKinds
+)
+
+// opNames are the names of the operations, in OpKind order
+var opNames = [...]string{"Push", "PushFront", "Pop", "Take", "Get", "Read", "Update", "Delete"}
+
+// Op is an operation with its arguments
+type Op struct {
+	Kind  OpKind
+	Key   string
+	Value interface{}
+}
+
+// String return the operation as a call
+func (op Op) String() string {
+	switch op.Kind {
+	case OpPush, OpPushFront, OpUpdate:
+		return fmt.Sprintf("%s(%q, %v)", opNames[op.Kind], op.Key, op.Value)
+	case OpGet, OpRead, OpDelete:
+		return fmt.Sprintf("%s(%q)", opNames[op.Kind], op.Key)
+	default:
+		return opNames[op.Kind] + "()"
+	}
+}
+
+// RandomOps return n operations on up to keys distinct keys, values are small integers or nil
+func RandomOps(r *rand.Rand, n, keys int) []Op {
+
+	ops := make([]Op, n)
+	for i := range ops {
+		op := Op{Kind: OpKind(r.Intn(int(opKinds))), Key: "k" + strconv.Itoa(r.Intn(keys))}
+		switch {
+		case r.Intn(50) == 0:
+			op.Key = "" // Along a nil value, the invalid key
+		case r.Intn(20) == 0:
+			op.Value = nil
+		default:
+			op.Value = r.Intn(100)
+		}
+		ops[i] = op
+	}
+
+	return ops
+}
+
+// Result is what an operation returned and the keys it left
+type Result struct {
+	Value interface{}
+	Err   error
+	Keys  []string
+}
+
+// Apply run op on s and return its result
+func Apply(s Subject, op Op) Result {
+
+	var result Result
+	switch op.Kind {
+	case OpPush:
+		result.Err = s.Push(op.Key, op.Value)
+	case OpPushFront:
+		result.Err = s.PushFront(op.Key, op.Value)
+	case OpPop:
+		result.Value, result.Err = s.Pop()
+	case OpTake:
+		result.Value, result.Err = s.Take()
+	case OpGet:
+		result.Value, result.Err = s.Get(op.Key)
+	case OpRead:
+		result.Value, result.Err = s.Read(op.Key)
+	case OpUpdate:
+		result.Err = s.Update(op.Key, op.Value)
+	case OpDelete:
+		result.Err = s.Delete(op.Key)
+	}
+	result.Keys = s.Getkeys()
+
+	return result
+}
+
+// diff describe how got differs from the model result expected, or return "" when they match
+// The subject errors match the sentinel errors of the model by errors.Is
+func diff(got, expected Result) string {
+
+	if (got.Err == nil) != (expected.Err == nil) || !errors.Is(got.Err, expected.Err) {
+		return fmt.Sprintf("error, expected %v, got %v", expected.Err, got.Err)
+	}
+
+	if !reflect.DeepEqual(got.Value, expected.Value) {
+		return fmt.Sprintf("value, expected %v, got %v", expected.Value, got.Value)
+	}
+
+	if strings.Join(got.Keys, ",") != strings.Join(expected.Keys, ",") {
+		return fmt.Sprintf("keys, expected %v, got %v", expected.Keys, got.Keys)
+	}
+
+	return ""
+}
+
+// Replay apply ops to s and m in order and return the index of the first operation whose results differ with the
+// difference, or -1 when all match
+func Replay(s Subject, m *Model, ops []Op) (int, string) {
+
+	for i, op := range ops {
+		if difference := diff(Apply(s, op), Apply(m, op)); difference != "" {
+			return i, difference
+		}
+
+		if s.GetNumberOfKeys() != m.GetNumberOfKeys() || s.IsEmpty() != m.IsEmpty() {
+			return i, fmt.Sprintf("number of keys, expected %v, got %v", m.GetNumberOfKeys(), s.GetNumberOfKeys())
+		}
+	}
+
+	return -1, ""
+}
+
+// ModelConfig set the sequences CheckModel replays, zero fields take their default
+type ModelConfig struct {
+	Seed      int64 // Seed of the sequences, from the clock when 0
+	Sequences int   // Sequences replayed, 100 by default
+	Steps     int   // Operations per sequence, 200 by default
+	Keys      int   // Distinct keys, 8 by default so keys repeat
+}
+
+// CheckModel replay random sequences against a new subject and a new model each and fail t on the first sequence they
+// disagree on, after shrinking it to the operations needed to reproduce the difference
+func CheckModel(t testing.TB, newSubject func() Subject, newModel func() *Model, config ModelConfig) {
+	t.Helper()
+
+	if config.Seed == 0 {
+		config.Seed = time.Now().UnixNano()
+	}
+	if config.Sequences <= 0 {
+		config.Sequences = 100
+	}
+	if config.Steps <= 0 {
+		config.Steps = 200
+	}
+	if config.Keys <= 0 {
+		config.Keys = 8
+	}
+
+	r := rand.New(rand.NewSource(config.Seed))
+	fails := func(ops []Op) bool {
+		step, _ := Replay(newSubject(), newModel(), ops)
+		return step >= 0
+	}
+
+	for i := 0; i < config.Sequences; i++ {
+		ops := RandomOps(r, config.Steps, config.Keys)
+		step, _ := Replay(newSubject(), newModel(), ops)
+		if step < 0 {
+			continue
+		}
+
+		ops = shrink(ops[:step+1], fails)
+		step, difference := Replay(newSubject(), newModel(), ops)
+
+		calls := make([]string, len(ops))
+		for i := range ops {
+			calls[i] = ops[i].String()
+		}
+		t.Errorf("model check failed (seed %d) at %s, %s, after:\n%s", config.Seed, ops[step], difference, strings.Join(calls, "\n"))
+		return
+	}
+}
+
+// shrink remove the operations of a failing sequence that are not needed for it to fail
+func shrink(ops []Op, fails func([]Op) bool) []Op {
+
+	for removed := true; removed; {
+		removed = false
+		for i := len(ops) - 1; i >= 0; i-- {
+			candidate := append(append([]Op{}, ops[:i]...), ops[i+1:]...)
+			if fails(candidate) {
+				ops, removed = candidate, true
+			}
+		}
+	}
+
+	return ops
+}
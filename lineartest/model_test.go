@@ -0,0 +1,99 @@
+package lineartest
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/golang-common-packages/linear"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestModel(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	model := NewModel(3, linear.EvictOldest)
+	assert.Nil(model.Push("a", 1))
+	assert.Nil(model.Push("b", 2))
+	assert.Nil(model.Push("a", 3))
+
+	// Testing
+	item, err := model.Read("a")
+	assert.Nil(err)
+	if item != 1 {
+		t.Errorf("Read failed, expected %v, got %v", 1, item)
+	}
+
+	assert.Nil(model.Push("c", 4))
+	assert.Equal([]string{"b", "a", "c"}, model.Getkeys())
+
+	item, err = model.Take()
+	assert.Nil(err)
+	assert.Equal(2, item)
+	assert.Nil(model.Delete("a"))
+	assert.Equal([]string{"c"}, model.Getkeys())
+
+	_, err = model.Get("a")
+	assert.Equal(linear.ErrKeyNotFound, err)
+	assert.Equal(linear.ErrInvalidKey, model.Push("", nil))
+}
+
+func TestCheckModel(t *testing.T) {
+
+	// Testing
+	configs := []struct {
+		maxItems   int
+		fullPolicy linear.FullPolicy
+	}{
+		{0, linear.EvictOldest},
+		{5, linear.EvictOldest},
+		{5, linear.Reject},
+	}
+
+	for _, config := range configs {
+		CheckModel(t, func() Subject {
+			linearClient, err := linear.NewWithOptions(linear.WithMaxItems(config.maxItems), linear.WithFullPolicy(config.fullPolicy))
+			if err != nil {
+				t.Fatalf("NewWithOptions failed, expected %v, got %v", nil, err)
+			}
+			return linearClient
+		}, func() *Model {
+			return NewModel(config.maxItems, config.fullPolicy)
+		}, ModelConfig{Seed: 1})
+	}
+}
+
+// lifoTake is a linear whose Take returns the last item, as a refactor breaking it would
+type lifoTake struct {
+	*linear.Linear
+}
+
+func (l lifoTake) Take() (interface{}, error) {
+	return l.Pop()
+}
+
+func TestReplay(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	newSubject := func() Subject { return lifoTake{linear.New(1024, false)} }
+	ops := RandomOps(rand.New(rand.NewSource(1)), 200, 8)
+
+	// Testing
+	step, difference := Replay(newSubject(), NewModel(0, linear.EvictOldest), ops)
+	if step < 0 {
+		t.Errorf("Replay failed, expected a difference, got %v", step)
+	}
+	assert.NotEmpty(difference)
+
+	shrunk := shrink(ops[:step+1], func(ops []Op) bool {
+		step, _ := Replay(newSubject(), NewModel(0, linear.EvictOldest), ops)
+		return step >= 0
+	})
+	if len(shrunk) > 3 {
+		t.Errorf("shrink failed, expected at most %v operations, got %v", 3, shrunk)
+	}
+
+	step, _ = Replay(linear.New(1024, false), NewModel(0, linear.EvictOldest), ops)
+	assert.Equal(-1, step)
+}
@@ -0,0 +1,217 @@
+package linear
+
+// node hold one occurrence of a key in the keys list
+type node struct {
+	key    string
+	pushed int64 // Unix nanoseconds
+	prev   *node
+	next   *node
+}
+
+// GrowthPolicy return how many key nodes to allocate once the current capacity is used up
+type GrowthPolicy func(capacity int) int
+
+// defaultGrowthPolicy double the capacity, between 16 and 65536 nodes per allocation
+func defaultGrowthPolicy(capacity int) int {
+	switch {
+	case capacity < 16:
+		return 16
+	case capacity > 1<<16:
+		return 1 << 16
+	}
+	return capacity
+}
+
+// keyList is a doubly linked list of keys with an index from key to its occurrences, from front to back
+// Nodes are allocated in chunks following the growth policy and recycled on remove
+type keyList struct {
+	head     *node
+	tail     *node
+	index    map[string][]*node
+	len      int
+	chunk    []node
+	free     []*node
+	capacity int
+	grow     GrowthPolicy
+}
+
+// newKeyList return an empty key list with room for capacity keys
+func newKeyList(capacity int, grow GrowthPolicy) *keyList {
+	if grow == nil {
+		grow = defaultGrowthPolicy
+	}
+
+	kl := &keyList{index: make(map[string][]*node, capacity), grow: grow}
+	if capacity > 0 {
+		kl.chunk = make([]node, capacity)
+		kl.capacity = capacity
+	}
+
+	return kl
+}
+
+// alloc return an unlinked node for key
+func (kl *keyList) alloc(key string) *node {
+	if last := len(kl.free) - 1; last >= 0 {
+		n := kl.free[last]
+		kl.free = kl.free[:last]
+		n.key = key
+		return n
+	}
+
+	if len(kl.chunk) == 0 {
+		size := kl.grow(kl.capacity)
+		if size < 1 {
+			size = 1
+		}
+		kl.chunk = make([]node, size)
+		kl.capacity += size
+	}
+
+	n := &kl.chunk[0]
+	kl.chunk = kl.chunk[1:]
+	n.key = key
+	return n
+}
+
+// pushBack add key at the back of the list
+func (kl *keyList) pushBack(key string) *node {
+	n := kl.alloc(key)
+	kl.linkBack(n)
+	kl.index[key] = append(kl.index[key], n)
+	kl.len++
+	return n
+}
+
+// pushFront add key at the front of the list
+func (kl *keyList) pushFront(key string) *node {
+	n := kl.alloc(key)
+	kl.linkFront(n)
+	kl.index[key] = append([]*node{n}, kl.index[key]...)
+	kl.len++
+	return n
+}
+
+// remove unlink n from the list and recycle it
+func (kl *keyList) remove(n *node) {
+	kl.detach(n)
+	n.key, n.pushed = "", 0
+	kl.free = append(kl.free, n)
+}
+
+// detach unlink n from the list and the index
+func (kl *keyList) detach(n *node) {
+	kl.unlink(n)
+
+	occurrences := kl.index[n.key]
+	for i := range occurrences {
+		if occurrences[i] == n {
+			occurrences = append(occurrences[:i], occurrences[i+1:]...)
+			break
+		}
+	}
+
+	if len(occurrences) == 0 {
+		delete(kl.index, n.key)
+	} else {
+		kl.index[n.key] = occurrences
+	}
+
+	kl.len--
+}
+
+// moveToBack move n to the back of the list
+func (kl *keyList) moveToBack(n *node) {
+	kl.detach(n)
+	kl.linkBack(n)
+	kl.index[n.key] = append(kl.index[n.key], n)
+	kl.len++
+}
+
+// moveToFront move n to the front of the list
+func (kl *keyList) moveToFront(n *node) {
+	kl.detach(n)
+	kl.linkFront(n)
+	kl.index[n.key] = append([]*node{n}, kl.index[n.key]...)
+	kl.len++
+}
+
+// first return the front-most occurrence of key, nil if the key is not in the list
+func (kl *keyList) first(key string) *node {
+	if occurrences := kl.index[key]; len(occurrences) > 0 {
+		return occurrences[0]
+	}
+	return nil
+}
+
+// occurrence return the position of n among the occurrences of its key, from the front
+func (kl *keyList) occurrence(n *node) int {
+	for i, occurrence := range kl.index[n.key] {
+		if occurrence == n {
+			return i
+		}
+	}
+	return -1
+}
+
+// contains check if key is in the list
+func (kl *keyList) contains(key string) bool {
+	return len(kl.index[key]) > 0
+}
+
+// slice return the keys from front to back
+func (kl *keyList) slice() []string {
+	keys := make([]string, 0, kl.len)
+	for n := kl.head; n != nil; n = n.next {
+		keys = append(keys, n.key)
+	}
+	return keys
+}
+
+// reversed return the keys from back to front
+func (kl *keyList) reversed() []string {
+	keys := make([]string, 0, kl.len)
+	for n := kl.tail; n != nil; n = n.prev {
+		keys = append(keys, n.key)
+	}
+	return keys
+}
+
+// linkBack attach n after the tail
+func (kl *keyList) linkBack(n *node) {
+	n.prev, n.next = kl.tail, nil
+	if kl.tail != nil {
+		kl.tail.next = n
+	} else {
+		kl.head = n
+	}
+	kl.tail = n
+}
+
+// linkFront attach n before the head
+func (kl *keyList) linkFront(n *node) {
+	n.prev, n.next = nil, kl.head
+	if kl.head != nil {
+		kl.head.prev = n
+	} else {
+		kl.tail = n
+	}
+	kl.head = n
+}
+
+// unlink detach n from its neighbours
+func (kl *keyList) unlink(n *node) {
+	if n.prev != nil {
+		n.prev.next = n.next
+	} else {
+		kl.head = n.next
+	}
+
+	if n.next != nil {
+		n.next.prev = n.prev
+	} else {
+		kl.tail = n.prev
+	}
+
+	n.prev, n.next = nil, nil
+}
@@ -0,0 +1,105 @@
+package linear
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeyList(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	kl := newKeyList(0, nil)
+	kl.pushBack("1")
+	kl.pushBack("2")
+	kl.pushBack("1")
+	kl.pushFront("3")
+
+	// Testing
+	assert.Equal(kl.slice(), []string{"3", "1", "2", "1"})
+	assert.Equal(kl.len, 4)
+
+	kl.moveToBack(kl.first("1"))
+	assert.Equal(kl.slice(), []string{"3", "2", "1", "1"})
+
+	kl.moveToFront(kl.first("2"))
+	assert.Equal(kl.slice(), []string{"2", "3", "1", "1"})
+
+	kl.remove(kl.tail)
+	assert.True(kl.contains("1"))
+
+	kl.remove(kl.first("1"))
+	assert.False(kl.contains("1"))
+
+	kl.remove(kl.head)
+	kl.remove(kl.head)
+	assert.Equal(kl.slice(), []string{})
+	assert.Nil(kl.tail)
+	assert.Empty(kl.index)
+}
+
+func TestDuplicateKeysDrain(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	linearClient := New(1024, false)
+	linearClient.Push("1", "a")
+	linearClient.Push("2", "b")
+	linearClient.Push("1", "c")
+
+	// Testing
+	value, _ := linearClient.Get("1")
+	assert.Equal(value, "a")
+	assert.Equal(linearClient.Getkeys(), []string{"2", "1"})
+
+	value, _ = linearClient.Pop()
+	assert.Equal(value, "a")
+
+	value, _ = linearClient.Take()
+	assert.Equal(value, "b")
+
+	assert.True(linearClient.IsEmpty())
+	assert.Equal(linearClient.GetLinearCurrentSize(), int64(0))
+}
+
+func TestKeyListGrowth(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	var grows []int
+	kl := newKeyList(2, func(capacity int) int {
+		grows = append(grows, capacity)
+		return capacity
+	})
+
+	// Testing
+	for _, key := range []string{"1", "2", "3", "4", "5"} {
+		kl.pushBack(key)
+	}
+
+	assert.Equal(grows, []int{2, 4})
+	assert.Equal(kl.capacity, 8)
+
+	kl.remove(kl.head)
+	kl.pushBack("6")
+	kl.pushBack("7")
+	kl.pushBack("8")
+	kl.pushBack("9")
+
+	assert.Equal(kl.capacity, 8)
+	assert.Equal(kl.slice(), []string{"2", "3", "4", "5", "6", "7", "8", "9"})
+}
+
+func TestWithInitialCapacity(t *testing.T) {
+	assert := assert.New(t)
+
+	linearClient := New(1024, false, WithInitialCapacity(100), WithGrowthPolicy(func(capacity int) int { return 10 }))
+
+	// Testing
+	for i := 0; i < 101; i++ {
+		linearClient.Push("1", "a")
+	}
+
+	assert.Equal(linearClient.keys.capacity, 110)
+}
@@ -0,0 +1,112 @@
+package linear
+
+// GetOrLoadMany return the items by keys without remove them, the missing ones are loaded with a single loader call and pushed to the linear
+// Keys the loader doesn't return are looked up in the fallback and left out of the result if it misses too
+// Loaded keys are pushed under a single lock only when they are still missing, a key pushed in the meantime returns its
+// stored value. A key that fails to push is left out of the result and the first push error is returned with the others
+// WithLoaderBreaker stops calling loader once it fails too often
+func (l *Linear) GetOrLoadMany(keys []string, loader func(missing []string) (map[string]interface{}, error)) (map[string]interface{}, error) {
+
+	// Execution conditions
+	if l.IsClosed() {
+		return nil, ErrClosed
+	}
+
+	// Argument validator
+	if loader == nil {
+		return nil, ErrInvalidArgument
+	}
+
+	items := make(map[string]interface{}, len(keys))
+	var missing []string
+	for _, key := range keys {
+		item, ok := l.items.Load(key)
+		if ok {
+			l.debugCheck(key, item)
+			l.checksumCheck(key, item)
+		} else {
+			item, ok = l.readSpilled(key)
+		}
+		l.countLookup(ok)
+
+		if ok {
+			items[key] = l.resolve(item)
+		} else if _, seen := items[key]; !seen {
+			missing = append(missing, key)
+			items[key] = nil // Mark as seen, so duplicated keys are loaded once
+		}
+	}
+
+	if len(missing) == 0 {
+		return items, nil
+	}
+
+	// The breaker is open, the fallback serves the missing keys instead
+	if !l.breaker.allow() {
+		for _, key := range missing {
+			if item, ok := l.readFallback(key); ok {
+				items[key] = item
+			} else {
+				delete(items, key)
+			}
+		}
+		return items, ErrCircuitOpen
+	}
+
+	loaded, err := loader(missing)
+	l.breaker.done(err)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]interface{}, len(loaded))
+	valueSizes := make(map[string]int64, len(loaded))
+	for _, key := range missing {
+		if value, ok := loaded[key]; ok {
+			if l.clone != nil {
+				value = l.clone(value)
+			}
+			values[key] = value
+			valueSizes[key] = l.valueSize(key, value)
+		}
+	}
+
+	// A key pushed while loading keeps its value, which is the one returned
+	var pushErr error
+	acquired := l.lock(lockPush)
+	for _, key := range missing {
+		value, ok := values[key]
+		if !ok {
+			continue
+		}
+
+		if actual, exits := l.loadItem(key); exits {
+			items[key] = actual
+			continue
+		}
+
+		if err := l.push(key, value, valueSizes[key]); err != nil {
+			if pushErr == nil {
+				pushErr = err
+			}
+			delete(items, key)
+			continue
+		}
+		items[key] = value
+	}
+	l.unlock(lockPush, acquired)
+
+	for _, key := range missing {
+		if _, ok := values[key]; ok {
+			continue
+		}
+
+		if item, ok := l.readFallback(key); ok {
+			items[key] = item
+		} else {
+			delete(items, key)
+		}
+	}
+
+	return items, pushErr
+}
@@ -0,0 +1,78 @@
+package linear
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetOrLoadMany(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	linearClient := New(1024, false)
+	linearClient.Push("1", "a")
+
+	calls := 0
+	loader := func(missing []string) (map[string]interface{}, error) {
+		calls++
+		assert.Equal(missing, []string{"2", "3"})
+		return map[string]interface{}{"2": "b"}, nil
+	}
+
+	// Testing
+	items, err := linearClient.GetOrLoadMany([]string{"1", "2", "3", "2"}, loader)
+	if err != nil {
+		t.Errorf("GetOrLoadMany failed, expected %v, got %v", nil, err)
+	}
+
+	assert.Equal(items, map[string]interface{}{"1": "a", "2": "b"})
+	assert.Equal(calls, 1)
+	assert.Equal(linearClient.Getkeys(), []string{"1", "2"})
+
+	items, err = linearClient.GetOrLoadMany([]string{"1", "2"}, loader)
+	assert.Nil(err)
+	assert.Equal(len(items), 2)
+	assert.Equal(calls, 1)
+
+	_, err = linearClient.GetOrLoadMany([]string{"4"}, func(missing []string) (map[string]interface{}, error) {
+		return nil, errors.New("backend down")
+	})
+	assert.NotNil(err)
+}
+
+func TestGetOrLoadManyConcurrentPush(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	linearClient := New(1024, false)
+
+	// Testing
+	// The key is pushed by another caller while it is loaded
+	items, err := linearClient.GetOrLoadMany([]string{"1"}, func(missing []string) (map[string]interface{}, error) {
+		linearClient.Push("1", "stored")
+		return map[string]interface{}{"1": "loaded"}, nil
+	})
+	assert.Nil(err)
+	assert.Equal(map[string]interface{}{"1": "stored"}, items)
+	assert.Equal([]string{"1"}, linearClient.Getkeys())
+}
+
+func TestGetOrLoadManyPushError(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	linearClient, _ := NewWithOptions(WithMaxItems(2), WithFullPolicy(Reject))
+	linearClient.Push("1", "a")
+
+	// Testing
+	items, err := linearClient.GetOrLoadMany([]string{"1", "2", "3"}, func(missing []string) (map[string]interface{}, error) {
+		return map[string]interface{}{"2": "b", "3": "c"}, nil
+	})
+	if !errors.Is(err, ErrFull) {
+		t.Errorf("GetOrLoadMany failed, expected %v, got %v", ErrFull, err)
+	}
+	assert.Equal(map[string]interface{}{"1": "a", "2": "b"}, items)
+	assert.Equal([]string{"1", "2"}, linearClient.Getkeys())
+}
@@ -0,0 +1,116 @@
+package linear
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// lockOp is an operation whose lock wait is measured
+type lockOp int
+
+const (
+	lockPush lockOp = iota
+	lockPop
+	lockTake
+	lockGet
+	lockUpdate
+	lockDelete
+	lockOps
+)
+
+// lockOpNames is the name of every measured operation in LockStats
+var lockOpNames = [lockOps]string{"push", "pop", "take", "get", "update", "delete"}
+
+// LockStat is the time an operation spent waiting for the linear lock and holding it
+type LockStat struct {
+	Count   int64
+	Wait    time.Duration // Total time waiting for the lock
+	MaxWait time.Duration
+	Hold    time.Duration // Total time working under the lock
+	MaxHold time.Duration
+}
+
+// lockCounters hold the counters behind a LockStat, they are accessed atomically
+type lockCounters struct {
+	count   int64
+	wait    int64
+	maxWait int64
+	hold    int64
+	maxHold int64
+}
+
+// WithLockMetrics measure the time Push, Pop, Take, Get, Update and Delete spend waiting for the lock and holding it
+func WithLockMetrics(enabled bool) Option {
+	return func(l *Linear) {
+		l.lockMetrics = enabled
+	}
+}
+
+// LockStats return the lock wait and hold times by operation, empty unless WithLockMetrics is on
+func (l *Linear) LockStats() map[string]LockStat {
+
+	stats := map[string]LockStat{}
+	if !l.lockMetrics {
+		return stats
+	}
+
+	for op := range l.lockStats {
+		c := &l.lockStats[op]
+		stats[lockOpNames[op]] = LockStat{
+			Count:   atomic.LoadInt64(&c.count),
+			Wait:    time.Duration(atomic.LoadInt64(&c.wait)),
+			MaxWait: time.Duration(atomic.LoadInt64(&c.maxWait)),
+			Hold:    time.Duration(atomic.LoadInt64(&c.hold)),
+			MaxHold: time.Duration(atomic.LoadInt64(&c.maxHold)),
+		}
+	}
+
+	return stats
+}
+
+// lock acquire mux for op and return when it got it, the zero time when lock metrics are off
+func (l *Linear) lock(op lockOp) time.Time {
+
+	if !l.lockMetrics {
+		l.mux.Lock()
+		return time.Time{}
+	}
+
+	start := time.Now()
+	l.mux.Lock()
+	acquired := time.Now()
+
+	c := &l.lockStats[op]
+	wait := int64(acquired.Sub(start))
+	atomic.AddInt64(&c.count, 1)
+	atomic.AddInt64(&c.wait, wait)
+	storeMax(&c.maxWait, wait)
+
+	return acquired
+}
+
+// unlock release mux taken by lock for op
+func (l *Linear) unlock(op lockOp, acquired time.Time) {
+
+	if acquired.IsZero() {
+		l.mux.Unlock()
+		return
+	}
+
+	hold := int64(time.Since(acquired))
+	l.mux.Unlock()
+
+	c := &l.lockStats[op]
+	atomic.AddInt64(&c.hold, hold)
+	storeMax(&c.maxHold, hold)
+}
+
+// storeMax raise the value at addr to v
+func storeMax(addr *int64, v int64) {
+	for {
+		current := atomic.LoadInt64(addr)
+		if v <= current || atomic.CompareAndSwapInt64(addr, current, v) {
+			return
+		}
+	}
+}
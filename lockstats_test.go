@@ -0,0 +1,47 @@
+package linear
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLockStats(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	linearClient, _ := NewWithOptions(WithLockMetrics(true))
+	assert.Empty(New(1024, false).LockStats())
+
+	// Testing
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				linearClient.Push("1", "a")
+				linearClient.Take()
+			}
+		}()
+	}
+	wg.Wait()
+
+	stats := linearClient.LockStats()
+	assert.Len(stats, int(lockOps))
+	assert.Equal(int64(1000), stats["push"].Count)
+	assert.True(stats["push"].Hold > 0)
+	assert.True(stats["push"].MaxWait <= stats["push"].Wait)
+	assert.Equal(LockStat{}, stats["delete"])
+
+	// Waiting behind a held lock
+	linearClient.mux.Lock()
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		linearClient.mux.Unlock()
+	}()
+	linearClient.Delete("1")
+	assert.True(linearClient.LockStats()["delete"].MaxWait >= 10*time.Millisecond)
+}
@@ -0,0 +1,378 @@
+package linear
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+)
+
+// errMsgpackTruncated is returned for encodings that end in the middle of a value
+var errMsgpackTruncated = errors.New("msgpack: truncated encoding")
+
+// MsgpackCodec encode values with MessagePack
+// Nil, booleans, numbers, strings, byte slices, slices, arrays and maps are supported, other types fail with ErrInvalidValue.
+// Values are decoded as nil, bool, int64, uint64 above math.MaxInt64, float32, float64, string, []byte, []interface{},
+// and map[string]interface{}, or map[interface{}]interface{} when a key isn't a string
+type MsgpackCodec struct{}
+
+// Encode return the MessagePack encoding of v
+func (MsgpackCodec) Encode(v interface{}) ([]byte, error) {
+	return msgpackAppend(nil, reflect.ValueOf(v))
+}
+
+// Decode decode the MessagePack encoding data into v, a pointer to an interface{} or to the type of the decoded value
+func (MsgpackCodec) Decode(data []byte, v interface{}) error {
+
+	target := reflect.ValueOf(v)
+	if target.Kind() != reflect.Ptr || target.IsNil() {
+		return ErrInvalidArgument
+	}
+
+	value, rest, err := msgpackRead(data)
+	if err != nil {
+		return err
+	}
+	if len(rest) != 0 {
+		return errors.New("msgpack: trailing bytes")
+	}
+
+	elem := target.Elem()
+	if value == nil {
+		elem.Set(reflect.Zero(elem.Type()))
+		return nil
+	}
+
+	decoded := reflect.ValueOf(value)
+	switch {
+	case decoded.Type().AssignableTo(elem.Type()):
+		elem.Set(decoded)
+	case decoded.Type().ConvertibleTo(elem.Type()) && decoded.Kind() != reflect.Slice && decoded.Kind() != reflect.Map &&
+		(elem.Kind() == reflect.String) == (decoded.Kind() == reflect.String): // Integers would convert to runes
+		elem.Set(decoded.Convert(elem.Type()))
+	default:
+		return fmt.Errorf("msgpack: can't decode %T into %s", value, elem.Type())
+	}
+
+	return nil
+}
+
+// msgpackAppend append the encoding of v to b
+func msgpackAppend(b []byte, v reflect.Value) ([]byte, error) {
+
+	if !v.IsValid() {
+		return append(b, 0xc0), nil
+	}
+
+	switch v.Kind() {
+	case reflect.Interface, reflect.Ptr:
+		if v.IsNil() {
+			return append(b, 0xc0), nil
+		}
+		return msgpackAppend(b, v.Elem())
+	case reflect.Bool:
+		if v.Bool() {
+			return append(b, 0xc3), nil
+		}
+		return append(b, 0xc2), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return msgpackAppendInt(b, v.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return msgpackAppendUint(b, v.Uint()), nil
+	case reflect.Float32:
+		b = append(b, 0xca)
+		return msgpackAppendFixed(b, 4, uint64(math.Float32bits(float32(v.Float())))), nil
+	case reflect.Float64:
+		b = append(b, 0xcb)
+		return msgpackAppendFixed(b, 8, math.Float64bits(v.Float())), nil
+	case reflect.String:
+		return msgpackAppendString(b, v.String()), nil
+	case reflect.Slice, reflect.Array:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			if v.Kind() == reflect.Slice && v.IsNil() {
+				return append(b, 0xc0), nil
+			}
+			return msgpackAppendBytes(b, v), nil
+		}
+
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			return append(b, 0xc0), nil
+		}
+		b = msgpackAppendHeader(b, v.Len(), 0x90, 0xdc)
+		for i := 0; i < v.Len(); i++ {
+			var err error
+			if b, err = msgpackAppend(b, v.Index(i)); err != nil {
+				return nil, err
+			}
+		}
+		return b, nil
+	case reflect.Map:
+		if v.IsNil() {
+			return append(b, 0xc0), nil
+		}
+		b = msgpackAppendHeader(b, v.Len(), 0x80, 0xde)
+		iter := v.MapRange()
+		for iter.Next() {
+			var err error
+			if b, err = msgpackAppend(b, iter.Key()); err != nil {
+				return nil, err
+			}
+			if b, err = msgpackAppend(b, iter.Value()); err != nil {
+				return nil, err
+			}
+		}
+		return b, nil
+	}
+
+	return nil, fmt.Errorf("%w: msgpack can't encode %s", ErrInvalidValue, v.Type())
+}
+
+// msgpackAppendInt append the shortest encoding of a signed integer
+func msgpackAppendInt(b []byte, n int64) []byte {
+	switch {
+	case n >= 0:
+		return msgpackAppendUint(b, uint64(n))
+	case n >= -32:
+		return append(b, byte(n))
+	case n >= math.MinInt8:
+		return append(b, 0xd0, byte(n))
+	case n >= math.MinInt16:
+		return msgpackAppendFixed(append(b, 0xd1), 2, uint64(n))
+	case n >= math.MinInt32:
+		return msgpackAppendFixed(append(b, 0xd2), 4, uint64(n))
+	}
+	return msgpackAppendFixed(append(b, 0xd3), 8, uint64(n))
+}
+
+// msgpackAppendUint append the shortest encoding of an unsigned integer
+func msgpackAppendUint(b []byte, n uint64) []byte {
+	switch {
+	case n <= 0x7f:
+		return append(b, byte(n))
+	case n <= math.MaxUint8:
+		return append(b, 0xcc, byte(n))
+	case n <= math.MaxUint16:
+		return msgpackAppendFixed(append(b, 0xcd), 2, uint64(n))
+	case n <= math.MaxUint32:
+		return msgpackAppendFixed(append(b, 0xce), 4, uint64(n))
+	}
+	return msgpackAppendFixed(append(b, 0xcf), 8, n)
+}
+
+// msgpackAppendFixed append n as a big endian unsigned integer of size bytes
+func msgpackAppendFixed(b []byte, size int, n uint64) []byte {
+	for shift := 8 * (size - 1); shift >= 0; shift -= 8 {
+		b = append(b, byte(n>>shift))
+	}
+	return b
+}
+
+// msgpackAppendString append a string with the shortest header
+func msgpackAppendString(b []byte, s string) []byte {
+	switch n := len(s); {
+	case n < 32:
+		b = append(b, 0xa0|byte(n))
+	case n <= math.MaxUint8:
+		b = append(b, 0xd9, byte(n))
+	case n <= math.MaxUint16:
+		b = msgpackAppendFixed(append(b, 0xda), 2, uint64(n))
+	default:
+		b = msgpackAppendFixed(append(b, 0xdb), 4, uint64(n))
+	}
+	return append(b, s...)
+}
+
+// msgpackAppendBytes append a byte slice or array as binary
+func msgpackAppendBytes(b []byte, v reflect.Value) []byte {
+	switch n := v.Len(); {
+	case n <= math.MaxUint8:
+		b = append(b, 0xc4, byte(n))
+	case n <= math.MaxUint16:
+		b = msgpackAppendFixed(append(b, 0xc5), 2, uint64(n))
+	default:
+		b = msgpackAppendFixed(append(b, 0xc6), 4, uint64(n))
+	}
+
+	for i := 0; i < v.Len(); i++ {
+		b = append(b, byte(v.Index(i).Uint()))
+	}
+	return b
+}
+
+// msgpackAppendHeader append the header of an array or a map of n elements, fix is the fixed format and wide its 16 bits format
+func msgpackAppendHeader(b []byte, n int, fix, wide byte) []byte {
+	switch {
+	case n < 16:
+		return append(b, fix|byte(n))
+	case n <= math.MaxUint16:
+		return msgpackAppendFixed(append(b, wide), 2, uint64(n))
+	}
+	return msgpackAppendFixed(append(b, wide+1), 4, uint64(n))
+}
+
+// msgpackRead decode the value at the start of b and return it with the bytes after it
+func msgpackRead(b []byte) (interface{}, []byte, error) {
+
+	if len(b) == 0 {
+		return nil, nil, errMsgpackTruncated
+	}
+
+	c, b := b[0], b[1:]
+	switch {
+	case c <= 0x7f:
+		return int64(c), b, nil
+	case c >= 0xe0:
+		return int64(int8(c)), b, nil
+	case c&0xe0 == 0xa0:
+		return msgpackReadString(b, int(c&0x1f))
+	case c&0xf0 == 0x90:
+		return msgpackReadArray(b, int(c&0x0f))
+	case c&0xf0 == 0x80:
+		return msgpackReadMap(b, int(c&0x0f))
+	}
+
+	switch c {
+	case 0xc0:
+		return nil, b, nil
+	case 0xc2:
+		return false, b, nil
+	case 0xc3:
+		return true, b, nil
+	case 0xc4, 0xc5, 0xc6:
+		n, b, err := msgpackReadLength(b, c-0xc4)
+		if err != nil || len(b) < n {
+			return nil, nil, errMsgpackTruncated
+		}
+		return append([]byte{}, b[:n]...), b[n:], nil
+	case 0xca:
+		n, b, err := msgpackReadFixed(b, 4)
+		return math.Float32frombits(uint32(n)), b, err
+	case 0xcb:
+		n, b, err := msgpackReadFixed(b, 8)
+		return math.Float64frombits(n), b, err
+	case 0xcc, 0xcd, 0xce, 0xcf:
+		n, b, err := msgpackReadFixed(b, 1<<(c-0xcc))
+		if n > math.MaxInt64 {
+			return n, b, err
+		}
+		return int64(n), b, err
+	case 0xd0:
+		n, b, err := msgpackReadFixed(b, 1)
+		return int64(int8(n)), b, err
+	case 0xd1:
+		n, b, err := msgpackReadFixed(b, 2)
+		return int64(int16(n)), b, err
+	case 0xd2:
+		n, b, err := msgpackReadFixed(b, 4)
+		return int64(int32(n)), b, err
+	case 0xd3:
+		n, b, err := msgpackReadFixed(b, 8)
+		return int64(n), b, err
+	case 0xd9, 0xda, 0xdb:
+		n, b, err := msgpackReadLength(b, c-0xd9)
+		if err != nil {
+			return nil, nil, err
+		}
+		return msgpackReadString(b, n)
+	case 0xdc, 0xdd:
+		n, b, err := msgpackReadLength(b, c-0xdc+1)
+		if err != nil {
+			return nil, nil, err
+		}
+		return msgpackReadArray(b, n)
+	case 0xde, 0xdf:
+		n, b, err := msgpackReadLength(b, c-0xde+1)
+		if err != nil {
+			return nil, nil, err
+		}
+		return msgpackReadMap(b, n)
+	}
+
+	return nil, nil, fmt.Errorf("msgpack: unsupported format 0x%02x", c)
+}
+
+// msgpackReadFixed read a big endian unsigned integer of size bytes
+func msgpackReadFixed(b []byte, size int) (uint64, []byte, error) {
+
+	if len(b) < size {
+		return 0, nil, errMsgpackTruncated
+	}
+
+	var n uint64
+	for _, c := range b[:size] {
+		n = n<<8 | uint64(c)
+	}
+	return n, b[size:], nil
+}
+
+// msgpackReadLength read a length of 1, 2 or 4 bytes for width 0, 1 or 2
+func msgpackReadLength(b []byte, width byte) (int, []byte, error) {
+	n, b, err := msgpackReadFixed(b, 1<<width)
+	if err != nil || n > uint64(len(b)) {
+		return 0, nil, errMsgpackTruncated
+	}
+	return int(n), b, nil
+}
+
+// msgpackReadString read a string of n bytes
+func msgpackReadString(b []byte, n int) (interface{}, []byte, error) {
+	if len(b) < n {
+		return nil, nil, errMsgpackTruncated
+	}
+	return string(b[:n]), b[n:], nil
+}
+
+// msgpackReadArray read an array of n elements
+func msgpackReadArray(b []byte, n int) (interface{}, []byte, error) {
+
+	values := make([]interface{}, 0, n)
+	for i := 0; i < n; i++ {
+		value, rest, err := msgpackRead(b)
+		if err != nil {
+			return nil, nil, err
+		}
+		values = append(values, value)
+		b = rest
+	}
+
+	return values, b, nil
+}
+
+// msgpackReadMap read a map of n pairs, keyed by strings when all its keys are strings
+func msgpackReadMap(b []byte, n int) (interface{}, []byte, error) {
+
+	keys := make([]interface{}, 0, n)
+	values := make([]interface{}, 0, n)
+	stringKeys := true
+	for i := 0; i < n; i++ {
+		key, rest, err := msgpackRead(b)
+		if err != nil {
+			return nil, nil, err
+		}
+		value, rest, err := msgpackRead(rest)
+		if err != nil {
+			return nil, nil, err
+		}
+		if _, ok := key.(string); !ok {
+			stringKeys = false
+		}
+		keys, values, b = append(keys, key), append(values, value), rest
+	}
+
+	if stringKeys {
+		m := make(map[string]interface{}, n)
+		for i, key := range keys {
+			m[key.(string)] = values[i]
+		}
+		return m, b, nil
+	}
+
+	m := make(map[interface{}]interface{}, n)
+	for i, key := range keys {
+		if key != nil && !reflect.TypeOf(key).Comparable() {
+			return nil, nil, fmt.Errorf("msgpack: map key of type %T", key)
+		}
+		m[key] = values[i]
+	}
+	return m, b, nil
+}
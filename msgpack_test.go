@@ -0,0 +1,68 @@
+package linear
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMsgpackCodec(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	codec := MsgpackCodec{}
+	cases := []struct {
+		value    interface{}
+		expected interface{}
+	}{
+		{nil, nil},
+		{true, true},
+		{7, int64(7)},
+		{-20, int64(-20)},
+		{-200, int64(-200)},
+		{70000, int64(70000)},
+		{int64(math.MinInt64), int64(math.MinInt64)},
+		{uint64(math.MaxUint64), uint64(math.MaxUint64)},
+		{float32(1.5), float32(1.5)},
+		{2.25, 2.25},
+		{"linear", "linear"},
+		{strings.Repeat("s", 300), strings.Repeat("s", 300)},
+		{[]byte{1, 2}, []byte{1, 2}},
+		{[]string{"a", "b"}, []interface{}{"a", "b"}},
+		{make([]int, 20), []interface{}(nil)},
+		{map[string]int{"a": 1}, map[string]interface{}{"a": int64(1)}},
+		{map[int]bool{1: true}, map[interface{}]interface{}{int64(1): true}},
+	}
+	zeros := make([]interface{}, 20)
+	for i := range zeros {
+		zeros[i] = int64(0)
+	}
+	cases[14].expected = zeros
+
+	// Testing
+	for _, c := range cases {
+		data, err := codec.Encode(c.value)
+		assert.Nil(err)
+
+		var value interface{}
+		assert.Nil(codec.Decode(data, &value))
+		assert.Equal(c.expected, value)
+	}
+
+	// Typed targets take the decoded value when it converts
+	data, _ := codec.Encode(42)
+	var n int
+	assert.Nil(codec.Decode(data, &n))
+	assert.Equal(42, n)
+	var s string
+	assert.NotNil(codec.Decode(data, &s))
+
+	_, err := codec.Encode(struct{ X int }{1})
+	assert.True(errors.Is(err, ErrInvalidValue))
+	assert.NotNil(codec.Decode(data[:0], &n))
+	assert.NotNil(codec.Decode([]byte{0xa5, 'a'}, &s))
+	assert.NotNil(codec.Decode(append(data, 0), &n))
+}
@@ -0,0 +1,92 @@
+package linear
+
+import "time"
+
+// Option configures optional behaviours of a linear instance
+type Option func(*Linear)
+
+// Logger is the destination of the messages the linear logs, *log.Logger satisfies it
+type Logger interface {
+	Printf(format string, v ...interface{})
+}
+
+// WithMaxBytes set the linear size in bytes, it must be higher than 0
+func WithMaxBytes(maxBytes int64) Option {
+	return func(l *Linear) {
+		l.linearSizes = maxBytes
+	}
+}
+
+// WithSizeChecker evict items from the front when a push doesn't fit in the linear size
+// The Reject and Block full policies enforce the linear size without it
+func WithSizeChecker(sizeChecker bool) Option {
+	return func(l *Linear) {
+		l.sizeChecker = sizeChecker
+	}
+}
+
+// WithSizeFunc measure the values with sizeFunc instead of walking them by reflection, negative sizes count as 0
+// Key sizes are still accounted by the linear
+func WithSizeFunc(sizeFunc func(key string, value interface{}) int64) Option {
+	return func(l *Linear) {
+		l.sizeFunc = sizeFunc
+	}
+}
+
+// WithMaxItems cap the number of keys in the linear, 0 means no cap
+func WithMaxItems(maxItems int) Option {
+	return func(l *Linear) {
+		l.maxItems = maxItems
+	}
+}
+
+// WithFullPolicy decide what a push does once the linear holds its maximum number of items
+func WithFullPolicy(policy FullPolicy) Option {
+	return func(l *Linear) {
+		l.fullPolicy = policy
+	}
+}
+
+// WithBlockTimeout bound the wait of the pushes without a context under the Block full policy, they fail with
+// context.DeadlineExceeded once timeout elapsed, 0 means they wait until there is room or the linear is closed
+func WithBlockTimeout(timeout time.Duration) Option {
+	return func(l *Linear) {
+		l.blockTimeout = timeout
+	}
+}
+
+// WithLogger set where the linear logs, the standard logger is used by default
+func WithLogger(logger Logger) Option {
+	return func(l *Linear) {
+		if logger != nil {
+			l.logger = logger
+		}
+	}
+}
+
+// WithCopyOnWrite store a clone of every pushed or updated value, so later caller mutations don't reach the linear
+// A nil clone function uses the built-in reflection based deep cloner
+func WithCopyOnWrite(clone func(interface{}) interface{}) Option {
+	return func(l *Linear) {
+		if clone == nil {
+			clone = deepClone
+		}
+		l.clone = clone
+	}
+}
+
+// WithInitialCapacity pre-allocate room for nKeys keys, so large instances don't grow repeatedly while warming up
+func WithInitialCapacity(nKeys int) Option {
+	return func(l *Linear) {
+		if nKeys > 0 {
+			l.initialCapacity = nKeys
+		}
+	}
+}
+
+// WithGrowthPolicy set how many keys the linear allocates room for once its capacity is used up
+func WithGrowthPolicy(grow GrowthPolicy) Option {
+	return func(l *Linear) {
+		l.growthPolicy = grow
+	}
+}
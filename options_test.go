@@ -0,0 +1,91 @@
+package linear
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testLogger chan string
+
+func (tl testLogger) Printf(format string, v ...interface{}) {
+	select {
+	case tl <- fmt.Sprintf(format, v...):
+	default:
+	}
+}
+
+func TestNewWithOptions(t *testing.T) {
+	assert := assert.New(t)
+
+	// Testing
+	linearClient, err := NewWithOptions(WithMaxBytes(70), WithSizeChecker(true))
+	if err != nil {
+		t.Errorf("NewWithOptions failed, expected %v, got %v", nil, err)
+	}
+
+	linearClient.Push("1", "a")
+	linearClient.Push("2", "b")
+	linearClient.Push("3", "c")
+	assert.Equal(linearClient.Getkeys(), []string{"2", "3"})
+
+	linearClient, err = NewWithOptions()
+	assert.Nil(err)
+	assert.True(linearClient.GetLinearSizes() > 0)
+
+	_, err = NewWithOptions(WithMaxBytes(0), WithDriftCheck(time.Millisecond, nil))
+	assert.True(errors.Is(err, ErrInvalidSize))
+}
+
+func TestWithLogger(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	logger := make(testLogger, 1)
+	linearClient, _ := NewWithOptions(WithLogger(logger), WithDriftCheck(time.Millisecond, nil))
+	defer linearClient.Close()
+
+	linearClient.mux.Lock()
+	linearClient.linearCurrentSize++
+	linearClient.mux.Unlock()
+
+	// Testing
+	select {
+	case message := <-logger:
+		assert.Contains(message, "size drift detected")
+	case <-time.After(time.Second):
+		t.Errorf("WithLogger failed, expected %v, got %v", "a log message", "nothing")
+	}
+}
+
+func TestWithSizeFunc(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	sizeFunc := func(key string, value interface{}) int64 {
+		return int64(len(value.(string))) * 10
+	}
+	linearClient, _ := NewWithOptions(WithMaxBytes(200), WithSizeChecker(true), WithSizeFunc(sizeFunc))
+
+	// Testing
+	assert.Nil(linearClient.Push("a", "abc"))
+	expected := calculateKeySize("a") + 30
+	if size := linearClient.GetLinearCurrentSize(); size != expected {
+		t.Errorf("WithSizeFunc failed, expected %v, got %v", expected, size)
+	}
+
+	assert.Nil(linearClient.Update("a", "abcdef"))
+	assert.Equal(calculateKeySize("a")+60, linearClient.GetLinearCurrentSize())
+	assert.Nil(linearClient.CheckSize())
+
+	err := linearClient.Push("b", string(make([]byte, 20)))
+	assert.True(errors.Is(err, ErrCapacityExceeded))
+
+	shardedClient, _ := NewSharded(WithShards(2), WithSizeFunc(sizeFunc))
+	assert.Nil(shardedClient.Push("a", "abc"))
+	value, _ := shardedClient.Read("a")
+	assert.Equal("abc", value)
+}
@@ -0,0 +1,100 @@
+package linear
+
+// Peek return the item by key from the linear without remove it, unlike Read it doesn't count as an access for the
+// eviction policy, slide the TTL or refresh the item ahead
+func (l *Linear) Peek(key string) (interface{}, error) {
+
+	// Execution conditions
+	if l.IsClosed() {
+		return nil, ErrClosed
+	}
+
+	item, ok := l.items.Load(key)
+	l.countLookup(ok)
+	if !ok {
+		return nil, newError("peek", key, ErrKeyNotFound)
+	}
+
+	l.debugCheck(key, item)
+	l.checksumCheck(key, item)
+
+	return l.resolve(item), nil
+}
+
+// PeekFront return the first key and item of the linear, the one Take returns, without remove it
+func (l *Linear) PeekFront() (string, interface{}, error) {
+
+	// Execution conditions
+	if l.IsClosed() {
+		return "", nil, ErrClosed
+	}
+
+	l.mux.RLock()
+	defer l.mux.RUnlock()
+
+	return l.peekNode(l.frontNode())
+}
+
+// PeekBack return the last key and item of the linear, the one Pop returns, without remove it
+func (l *Linear) PeekBack() (string, interface{}, error) {
+
+	// Execution conditions
+	if l.IsClosed() {
+		return "", nil, ErrClosed
+	}
+
+	l.mux.RLock()
+	defer l.mux.RUnlock()
+
+	return l.peekNode(l.backNode())
+}
+
+// PeekAt return the key and item at index, counted from the front, without remove it
+func (l *Linear) PeekAt(index int) (string, interface{}, error) {
+
+	// Execution conditions
+	if l.IsClosed() {
+		return "", nil, ErrClosed
+	}
+
+	l.mux.RLock()
+	defer l.mux.RUnlock()
+
+	// Argument validator
+	if index < 0 || index >= l.keys.len {
+		if l.keys.len == 0 {
+			return "", nil, ErrEmpty
+		}
+		return "", nil, ErrInvalidArgument
+	}
+
+	// Walk from the nearest end
+	var n *node
+	if index < l.keys.len/2 {
+		n = l.keys.head
+		for i := 0; i < index; i++ {
+			n = n.next
+		}
+	} else {
+		n = l.keys.tail
+		for i := l.keys.len - 1; i > index; i-- {
+			n = n.prev
+		}
+	}
+
+	return l.peekNode(n)
+}
+
+// peekNode return the key and item of n, caller must hold mux
+func (l *Linear) peekNode(n *node) (string, interface{}, error) {
+
+	// Execution conditions
+	if n == nil {
+		return "", nil, ErrEmpty
+	}
+
+	item, _ := l.items.Load(n.key)
+	l.debugCheck(n.key, item)
+
+	return n.key, l.resolve(item), nil
+}
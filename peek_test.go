@@ -0,0 +1,78 @@
+package linear
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPeek(t *testing.T) {
+	assert := assert.New(t)
+
+	linearClient := New(1024, false)
+
+	_, _, err := linearClient.PeekFront()
+	assert.True(errors.Is(err, ErrEmpty))
+
+	// Setting up
+	datas := []struct {
+		key   string
+		value string
+	}{
+		{"1", "a"},
+		{"2", "b"},
+		{"3", "c"},
+		{"4", "d"},
+	}
+
+	for _, data := range datas {
+		linearClient.Push(data.key, data.value)
+	}
+
+	// Testing
+	key, value, err := linearClient.PeekFront()
+	assert.Nil(err)
+	assert.Equal(key, "1")
+	assert.Equal(value, "a")
+
+	key, value, err = linearClient.PeekBack()
+	assert.Nil(err)
+	assert.Equal(key, "4")
+	assert.Equal(value, "d")
+
+	for index, data := range datas {
+		key, value, err = linearClient.PeekAt(index)
+		assert.Nil(err)
+		assert.Equal(key, data.key)
+		assert.Equal(value, data.value)
+	}
+
+	_, _, err = linearClient.PeekAt(4)
+	assert.True(errors.Is(err, ErrInvalidArgument))
+
+	assert.Equal(linearClient.GetNumberOfKeys(), 4)
+}
+
+func TestPeekKey(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	linearClient, _ := NewWithOptions(WithMaxItems(2), WithEvictionPolicy(LRU()))
+	linearClient.Push("1", "a")
+	linearClient.Push("2", "b")
+
+	// Testing
+	value, err := linearClient.Peek("1")
+	if err != nil {
+		t.Errorf("Peek failed, expected %v, got %v", "a", err)
+	}
+	assert.Equal("a", value)
+
+	_, err = linearClient.Peek("3")
+	assert.True(errors.Is(err, ErrKeyNotFound))
+
+	// The peeked key is still the least recently used
+	linearClient.Push("3", "c")
+	assert.Equal([]string{"2", "3"}, linearClient.Getkeys())
+}
@@ -0,0 +1,101 @@
+package linear
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// WithPersistence write a snapshot of the linear to path every interval and on Close
+// The file is replaced atomically, an interval of 0 only writes it on Close
+func WithPersistence(path string, interval time.Duration) Option {
+	return func(l *Linear) {
+		l.persistPath = path
+		l.persistInterval = interval
+	}
+}
+
+// Open return new linear instance restored from the snapshot file at path and persisted back to it
+// A missing file starts an empty linear, opts may set WithPersistence to change the interval
+func Open(path string, opts ...Option) (*Linear, error) {
+	return NewWithOptions(append([]Option{WithPersistence(path, 0)}, append(opts, func(l *Linear) {
+		l.openPath = path
+	})...)...)
+}
+
+// openFile restore the snapshot file given to Open, caller must not hold mux
+func (l *Linear) openFile() error {
+
+	f, err := os.Open(l.openPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return l.Restore(f)
+}
+
+// startPersistence write the snapshot file every persistInterval in the background
+func (l *Linear) startPersistence() {
+	l.startWorker(func(done <-chan struct{}) {
+		ticker := time.NewTicker(l.persistInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				if err := l.persist(); err != nil {
+					l.logger.Printf("linear: persisting to %s failed: %v", l.persistPath, err)
+				}
+			}
+		}
+	})
+}
+
+// persist write the snapshot to a temporary file next to persistPath and rename it over persistPath
+// so a crash leaves either the previous or the new snapshot
+func (l *Linear) persist() error {
+
+	l.persistMux.Lock()
+	defer l.persistMux.Unlock()
+
+	return writeFileAtomic(l.persistPath, l.writeSnapshot)
+}
+
+// writeFileAtomic write a temporary file next to path with write and rename it over path
+func writeFileAtomic(path string, write func(w io.Writer) error) error {
+
+	dir, base := filepath.Split(path)
+	if dir == "" {
+		dir = "."
+	}
+
+	tmp, err := os.CreateTemp(dir, base+".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := write(tmp); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,62 @@
+package linear
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOpen(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	path := filepath.Join(t.TempDir(), "linear.snapshot")
+	linearClient, err := Open(path)
+	assert.Nil(err)
+	assert.True(linearClient.IsEmpty())
+
+	linearClient.Push("1", "a")
+	linearClient.Push("2", "b")
+
+	// Testing
+	assert.Nil(linearClient.Close())
+
+	reopened, err := Open(path)
+	assert.Nil(err)
+	assert.Equal([]string{"1", "2"}, reopened.Getkeys())
+	assert.Nil(reopened.Close())
+
+	entries, _ := os.ReadDir(filepath.Dir(path))
+	assert.Len(entries, 1)
+
+	assert.Nil(os.WriteFile(path, []byte("LINEAR garbage"), 0o600))
+	_, err = Open(path)
+	assert.True(errors.Is(err, ErrCorrupted))
+}
+
+func TestWithPersistence(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	path := filepath.Join(t.TempDir(), "linear.snapshot")
+	linearClient, err := NewWithOptions(WithPersistence(path, 5*time.Millisecond))
+	assert.Nil(err)
+	linearClient.Push("1", "a")
+
+	// Testing
+	assert.Equal(1, linearClient.Goroutines())
+	assert.Eventually(func() bool {
+		_, err := os.Stat(path)
+		return err == nil
+	}, time.Second, time.Millisecond)
+
+	assert.Nil(linearClient.Close())
+	assert.Equal(0, linearClient.Goroutines())
+
+	_, err = NewWithOptions(WithPersistence("", time.Second))
+	assert.True(errors.Is(err, ErrInvalidArgument))
+}
@@ -0,0 +1,184 @@
+package linear
+
+import (
+	"container/heap"
+)
+
+// priorityEntry is the priority of one key occurrence, index is its position in the highest and lowest heaps
+type priorityEntry struct {
+	n        *node
+	priority int
+	seq      uint64
+	index    [2]int
+}
+
+// priorityHeap order the entries by highest priority first when lowest is false, by lowest priority first otherwise
+// Entries of equal priority are ordered oldest first
+type priorityHeap struct {
+	entries []*priorityEntry
+	lowest  bool
+}
+
+func (h *priorityHeap) side() int {
+	if h.lowest {
+		return 1
+	}
+	return 0
+}
+
+func (h *priorityHeap) Len() int { return len(h.entries) }
+
+func (h *priorityHeap) Less(i, j int) bool {
+	a, b := h.entries[i], h.entries[j]
+	if a.priority != b.priority {
+		return (a.priority > b.priority) != h.lowest
+	}
+	return a.seq < b.seq
+}
+
+func (h *priorityHeap) Swap(i, j int) {
+	h.entries[i], h.entries[j] = h.entries[j], h.entries[i]
+	h.entries[i].index[h.side()] = i
+	h.entries[j].index[h.side()] = j
+}
+
+func (h *priorityHeap) Push(x interface{}) {
+	entry := x.(*priorityEntry)
+	entry.index[h.side()] = len(h.entries)
+	h.entries = append(h.entries, entry)
+}
+
+func (h *priorityHeap) Pop() interface{} {
+	last := len(h.entries) - 1
+	entry := h.entries[last]
+	h.entries[last] = nil
+	h.entries = h.entries[:last]
+	return entry
+}
+
+// priorityQueue keep the key occurrences in a highest and a lowest priority heap, so both ends are O(log n)
+type priorityQueue struct {
+	highest priorityHeap
+	lowest  priorityHeap
+	entries map[*node]*priorityEntry
+	seq     uint64
+}
+
+// newPriorityQueue return an empty priority queue
+func newPriorityQueue() *priorityQueue {
+	return &priorityQueue{lowest: priorityHeap{lowest: true}, entries: map[*node]*priorityEntry{}}
+}
+
+// push add n with priority
+func (pq *priorityQueue) push(n *node, priority int) {
+	pq.seq++
+	entry := &priorityEntry{n: n, priority: priority, seq: pq.seq}
+	pq.entries[n] = entry
+	heap.Push(&pq.highest, entry)
+	heap.Push(&pq.lowest, entry)
+}
+
+// remove drop n from the queue
+func (pq *priorityQueue) remove(n *node) {
+	entry, ok := pq.entries[n]
+	if !ok {
+		return
+	}
+
+	delete(pq.entries, n)
+	heap.Remove(&pq.highest, entry.index[0])
+	heap.Remove(&pq.lowest, entry.index[1])
+}
+
+// first return the occurrence with the highest priority, nil when the queue is empty
+func (pq *priorityQueue) first() *node {
+	if len(pq.highest.entries) == 0 {
+		return nil
+	}
+	return pq.highest.entries[0].n
+}
+
+// last return the occurrence with the lowest priority, nil when the queue is empty
+func (pq *priorityQueue) last() *node {
+	if len(pq.lowest.entries) == 0 {
+		return nil
+	}
+	return pq.lowest.entries[0].n
+}
+
+// reset empty the queue
+func (pq *priorityQueue) reset() {
+	*pq = *newPriorityQueue()
+}
+
+// WithPriority order Take, Pop and the eviction by priority instead of push order
+// Take returns the highest priority item, Pop and the eviction the lowest one, equal priorities keep the push order
+// Priorities are not persisted, items replayed or restored from snapshots have priority 0
+func WithPriority() Option {
+	return func(l *Linear) {
+		l.priorities = newPriorityQueue()
+	}
+}
+
+// PushWithPriority push item to the linear with key and priority, Push uses priority 0
+// It returns ErrInvalidArgument unless the linear was created WithPriority
+func (l *Linear) PushWithPriority(key string, value interface{}, priority int) error {
+
+	// Execution conditions
+	if l.IsClosed() {
+		return ErrClosed
+	}
+
+	// Argument validator
+	if l.priorities == nil {
+		return ErrInvalidArgument
+	}
+
+	if key == "" && value == nil {
+		return ErrInvalidKey
+	}
+
+	if l.clone != nil {
+		value = l.clone(value)
+	}
+
+	valueSize := l.valueSize(key, value)
+
+	acquired := l.lock(lockPush)
+	l.pushPriority = priority
+	err := l.push(key, value, valueSize)
+	l.pushPriority = 0
+	l.unlock(lockPush, acquired)
+
+	return err
+}
+
+// frontNode return the occurrence Take removes, caller must hold mux
+func (l *Linear) frontNode() *node {
+	if l.priorities != nil {
+		return l.priorities.first()
+	}
+	return l.keys.head
+}
+
+// backNode return the occurrence Pop removes, caller must hold mux
+func (l *Linear) backNode() *node {
+	if l.priorities != nil {
+		return l.priorities.last()
+	}
+	return l.keys.tail
+}
+
+// priorityPushed add the new occurrence n with the priority of the current push, caller must hold mux
+func (l *Linear) priorityPushed(n *node) {
+	if l.priorities != nil {
+		l.priorities.push(n, l.pushPriority)
+	}
+}
+
+// priorityRemoved drop the occurrence n, caller must hold mux
+func (l *Linear) priorityRemoved(n *node) {
+	if l.priorities != nil {
+		l.priorities.remove(n)
+	}
+}
@@ -0,0 +1,85 @@
+package linear
+
+import (
+	"errors"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPushWithPriority(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	linearClient, _ := NewWithOptions(WithPriority())
+	linearClient.PushWithPriority("low", "l", -1)
+	linearClient.Push("normal", "n")
+	linearClient.PushWithPriority("high", "h", 5)
+	linearClient.PushWithPriority("high2", "h2", 5)
+
+	// Testing
+	key, _, _ := linearClient.PeekFront()
+	assert.Equal("high", key)
+	key, _, _ = linearClient.PeekBack()
+	assert.Equal("low", key)
+
+	item, _ := linearClient.Take()
+	assert.Equal("h", item)
+	item, _ = linearClient.Pop()
+	assert.Equal("l", item)
+
+	// Keyed removals leave the heaps consistent
+	linearClient.Get("high2")
+	item, _ = linearClient.Take()
+	assert.Equal("n", item)
+	_, err := linearClient.Take()
+	assert.True(errors.Is(err, ErrEmpty))
+
+	plainClient := New(1024, false)
+	assert.True(errors.Is(plainClient.PushWithPriority("1", 1, 1), ErrInvalidArgument))
+}
+
+func TestPriorityEviction(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	linearClient, _ := NewWithOptions(WithPriority(), WithMaxItems(3))
+	linearClient.PushWithPriority("a", 1, 3)
+	linearClient.PushWithPriority("b", 2, 1)
+	linearClient.PushWithPriority("c", 3, 2)
+
+	// Testing
+	assert.Nil(linearClient.PushWithPriority("d", 4, 4))
+	if _, exits := linearClient.IsExits("b"); exits {
+		t.Errorf("PushWithPriority failed, expected %v, got %v", false, exits)
+	}
+
+	items, _ := linearClient.TakeN(3)
+	assert.Equal([]interface{}{4, 1, 3}, items)
+}
+
+func TestPriorityOrder(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	linearClient, _ := NewWithOptions(WithPriority())
+	r := rand.New(rand.NewSource(1))
+	priorities := make([]int, 500)
+	for i := range priorities {
+		priorities[i] = r.Intn(50)
+		linearClient.PushWithPriority(string(rune('a'+i%26))+string(rune(i)), priorities[i], priorities[i])
+	}
+
+	// Testing
+	sort.Sort(sort.Reverse(sort.IntSlice(priorities)))
+	for _, expected := range priorities {
+		item, err := linearClient.Take()
+		assert.Nil(err)
+		if item != expected {
+			t.Errorf("Take failed, expected %v, got %v", expected, item)
+			break
+		}
+	}
+}
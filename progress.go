@@ -0,0 +1,26 @@
+package linear
+
+import (
+	"io"
+	"sync/atomic"
+)
+
+// ProgressFunc is called by the bulk operations with the items done out of total and the bytes processed
+type ProgressFunc func(done, total int, bytes int64)
+
+// countingReader count the bytes read from r
+type countingReader struct {
+	r io.Reader
+	n int64 // Accessed atomically
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	atomic.AddInt64(&c.n, int64(n))
+	return n, err
+}
+
+// count return the bytes read so far
+func (c *countingReader) count() int64 {
+	return atomic.LoadInt64(&c.n)
+}
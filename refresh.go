@@ -0,0 +1,126 @@
+package linear
+
+import (
+	"sync"
+	"time"
+)
+
+// refresher reload the keys read late in their TTL
+type refresher struct {
+	fraction   float64
+	load       func(key string) (interface{}, error)
+	mux        sync.Mutex
+	refreshing map[string]bool
+}
+
+// WithRefreshAhead reload a key with load in the background when Read returns it after fraction of its TTL elapsed
+// The reloaded value replaces the old one and restarts the TTL, so hot keys don't expire in the face of their readers
+// A failed reload is logged and the old value is kept until it expires, WithLoaderBreaker stops reloading once they fail too often
+func WithRefreshAhead(fraction float64, load func(key string) (interface{}, error)) Option {
+	return func(l *Linear) {
+		l.refresh = &refresher{fraction: fraction, load: load, refreshing: map[string]bool{}}
+	}
+}
+
+// refreshAhead start the reload of a key that was read after the refresh fraction of its TTL
+func (l *Linear) refreshAhead(key string) {
+
+	// Execution conditions
+	if l.refresh == nil {
+		return
+	}
+
+	l.mux.RLock()
+	t, ok := l.expiries[key]
+	due := ok && !l.now().Before(t.at.Add(-time.Duration(float64(t.ttl)*(1-l.refresh.fraction))))
+	l.mux.RUnlock()
+
+	if !due {
+		return
+	}
+
+	// One reload per key at a time
+	l.refresh.mux.Lock()
+	if l.refresh.refreshing[key] {
+		l.refresh.mux.Unlock()
+		return
+	}
+
+	// The breaker is open, the current value is served until it expires
+	if !l.breaker.allow() {
+		l.refresh.mux.Unlock()
+		return
+	}
+	l.refresh.refreshing[key] = true
+	l.refresh.mux.Unlock()
+
+	started := l.startWorker(func(done <-chan struct{}) {
+		defer func() {
+			l.refresh.mux.Lock()
+			delete(l.refresh.refreshing, key)
+			l.refresh.mux.Unlock()
+		}()
+
+		value, err := l.refresh.load(key)
+		l.breaker.done(err)
+		if err != nil {
+			l.logger.Printf("linear: refreshing %q failed: %v", key, err)
+			return
+		}
+
+		if err := l.swapRefreshed(key, value); err != nil {
+			l.logger.Printf("linear: refreshing %q failed: %v", key, err)
+		}
+	})
+
+	if !started {
+		l.breaker.done(nil) // Closed, the loader was not called
+		l.refresh.mux.Lock()
+		delete(l.refresh.refreshing, key)
+		l.refresh.mux.Unlock()
+	}
+}
+
+// swapRefreshed replace the value of the key by its reloaded value and restart its TTL
+func (l *Linear) swapRefreshed(key string, value interface{}) error {
+
+	// Execution conditions
+	if l.IsClosed() {
+		return nil
+	}
+
+	valueSize := l.valueSize(key, value)
+	if calculateKeySize(key)+valueSize > l.GetLinearSizes() {
+		return newError("refresh", key, ErrCapacityExceeded)
+	}
+
+	if l.clone != nil {
+		value = l.clone(value)
+	}
+
+	acquired := l.lock(lockUpdate)
+	defer l.unlock(lockUpdate, acquired)
+
+	// Taken, evicted or expired during the reload
+	if !l.keys.contains(key) {
+		return nil
+	}
+
+	// The value of a shard keeps its position in the sharded order
+	if current, ok := l.loadItem(key); ok {
+		if item, ok := current.(sequenced); ok {
+			value = sequenced{Seq: item.Seq, Value: value}
+			valueSize = l.valueSize(key, value)
+		}
+	}
+
+	if err := l.update(key, value, valueSize); err != nil {
+		return err
+	}
+
+	if t, ok := l.expiries[key]; ok {
+		l.setExpiry(key, t.ttl)
+	}
+
+	return nil
+}
@@ -0,0 +1,72 @@
+package linear
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithRefreshAhead(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	var loads int32
+	load := func(key string) (interface{}, error) {
+		if key == "2" {
+			return nil, errors.New("source down")
+		}
+		return int(atomic.AddInt32(&loads, 1)), nil
+	}
+
+	_, err := NewWithOptions(WithRefreshAhead(1, load))
+	assert.True(errors.Is(err, ErrInvalidArgument))
+
+	_, err = NewWithOptions(WithRefreshAhead(0.5, nil))
+	assert.True(errors.Is(err, ErrInvalidArgument))
+
+	logger := make(testLogger, 1)
+	linearClient, _ := NewWithOptions(WithWheelTick(time.Millisecond), WithRefreshAhead(0.5, load), WithLogger(logger))
+	defer linearClient.Close()
+
+	assert.Nil(linearClient.PushWithTTL("1", 0, 200*time.Millisecond))
+	assert.Nil(linearClient.PushWithTTL("2", 0, 200*time.Millisecond))
+	assert.Nil(linearClient.Push("3", 0))
+
+	// Testing
+	// Early in the TTL nothing is reloaded
+	_, _ = linearClient.Read("1")
+	_, _ = linearClient.Read("3")
+	time.Sleep(20 * time.Millisecond)
+	if got := atomic.LoadInt32(&loads); got != 0 {
+		t.Errorf("WithRefreshAhead failed, expected %v, got %v", 0, got)
+	}
+
+	// Past half of the TTL a read reloads the value and restarts the TTL
+	time.Sleep(100 * time.Millisecond)
+	value, _ := linearClient.Read("1")
+	assert.Equal(0, value)
+	assert.Eventually(func() bool {
+		value, _ := linearClient.Read("1")
+		return value == 1
+	}, time.Second, time.Millisecond)
+
+	ttl, _ := linearClient.GetTTL("1")
+	assert.True(ttl > 100*time.Millisecond)
+
+	// A failed reload keeps the old value until it expires
+	_, _ = linearClient.Read("2")
+	select {
+	case <-logger:
+	case <-time.After(time.Second):
+		t.Errorf("WithRefreshAhead failed, expected %v, got %v", "a log message", "nothing")
+	}
+	assert.Eventually(func() bool {
+		_, exits := linearClient.IsExits("2")
+		return !exits
+	}, time.Second, time.Millisecond)
+	_, exits := linearClient.IsExits("1")
+	assert.True(exits)
+}
@@ -0,0 +1,118 @@
+package linear
+
+import (
+	"encoding/binary"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// TypeRegistry name the types of the values stored in the linear, so the codec it builds restores them with their Go
+// type instead of the generic types of its inner codec, without registering them with gob
+type TypeRegistry struct {
+	mux   sync.RWMutex
+	types map[string]reflect.Type
+	names map[reflect.Type]string
+}
+
+// NewTypeRegistry return an empty type registry
+func NewTypeRegistry() *TypeRegistry {
+	return &TypeRegistry{types: map[string]reflect.Type{}, names: map[reflect.Type]string{}}
+}
+
+// Register name the type of sample, a pointer sample registers the pointer type
+// A name or a type registered once can't be registered to another type or name, readers must register the same names
+func (r *TypeRegistry) Register(name string, sample interface{}) error {
+
+	// Argument validator
+	if name == "" || sample == nil {
+		return ErrInvalidArgument
+	}
+
+	t := reflect.TypeOf(sample)
+
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	if registered, ok := r.types[name]; ok && registered != t {
+		return fmt.Errorf("%w: %q is registered to %s", ErrInvalidArgument, name, registered)
+	}
+	if registered, ok := r.names[t]; ok && registered != name {
+		return fmt.Errorf("%w: %s is registered as %q", ErrInvalidArgument, t, registered)
+	}
+
+	r.types[name] = t
+	r.names[t] = name
+
+	return nil
+}
+
+// Codec return a codec writing the registered name of the type of a value before its inner encoding, values of types
+// not registered are written without a name and decoded as inner decodes them. A nil inner codec is JSONCodec.
+// inner must decode into a pointer to a registered type, as JSONCodec does
+func (r *TypeRegistry) Codec(inner Codec) Codec {
+	if inner == nil {
+		inner = JSONCodec{}
+	}
+	return registryCodec{registry: r, inner: inner}
+}
+
+// registryCodec is the codec of a TypeRegistry
+type registryCodec struct {
+	registry *TypeRegistry
+	inner    Codec
+}
+
+// Encode return the type name of v and the inner encoding of v
+func (c registryCodec) Encode(v interface{}) ([]byte, error) {
+
+	var name string
+	if v != nil {
+		c.registry.mux.RLock()
+		name = c.registry.names[reflect.TypeOf(v)]
+		c.registry.mux.RUnlock()
+	}
+
+	payload, err := c.inner.Encode(v)
+	if err != nil {
+		return nil, err
+	}
+
+	data := make([]byte, binary.MaxVarintLen64, binary.MaxVarintLen64+len(name)+len(payload))
+	data = data[:binary.PutUvarint(data, uint64(len(name)))]
+	return append(append(data, name...), payload...), nil
+}
+
+// Decode decode data into v with the type its name is registered to, v is a *interface{} or a pointer to that type
+func (c registryCodec) Decode(data []byte, v interface{}) error {
+
+	length, n := binary.Uvarint(data)
+	if n <= 0 || uint64(len(data)-n) < length {
+		return fmt.Errorf("%w: truncated type name", ErrCorrupted)
+	}
+	name, payload := string(data[n:n+int(length)]), data[n+int(length):]
+
+	if name == "" {
+		return c.inner.Decode(payload, v)
+	}
+
+	c.registry.mux.RLock()
+	t, ok := c.registry.types[name]
+	c.registry.mux.RUnlock()
+	if !ok {
+		return fmt.Errorf("%w: type %q is not registered", ErrInvalidValue, name)
+	}
+
+	decoded := reflect.New(t)
+	if err := c.inner.Decode(payload, decoded.Interface()); err != nil {
+		return err
+	}
+
+	target := reflect.ValueOf(v)
+	if target.Kind() != reflect.Ptr || target.IsNil() || !t.AssignableTo(target.Elem().Type()) {
+		return fmt.Errorf("%w: can't decode %s into %T", ErrInvalidArgument, t, v)
+	}
+	target.Elem().Set(decoded.Elem())
+
+	return nil
+}
@@ -0,0 +1,65 @@
+package linear
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// label is a second type of the registry, stored behind a pointer
+type label struct {
+	Name string
+}
+
+func TestTypeRegistry(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	registry := NewTypeRegistry()
+	assert.Nil(registry.Register("point", point{}))
+	assert.Nil(registry.Register("label", &label{}))
+	assert.Nil(registry.Register("point", point{}))
+	assert.True(errors.Is(registry.Register("point", label{}), ErrInvalidArgument))
+	assert.True(errors.Is(registry.Register("other", point{}), ErrInvalidArgument))
+	assert.True(errors.Is(registry.Register("", point{}), ErrInvalidArgument))
+
+	linearClient, _ := NewWithOptions(WithCodec(registry.Codec(nil)))
+	linearClient.Push("1", point{1, 2})
+	linearClient.Push("2", &label{"a"})
+	linearClient.Push("3", []int{1})
+	linearClient.Push("4", nil)
+
+	// Testing
+	var buf bytes.Buffer
+	assert.Nil(linearClient.Snapshot(&buf))
+
+	restored, _ := NewWithOptions(WithCodec(registry.Codec(JSONCodec{})))
+	assert.Nil(restored.Restore(bytes.NewReader(buf.Bytes())))
+	value, _ := restored.Read("1")
+	assert.Equal(point{1, 2}, value)
+	value, _ = restored.Read("2")
+	assert.Equal(&label{"a"}, value)
+
+	// Types not registered decode as the inner codec decodes them
+	value, _ = restored.Read("3")
+	assert.Equal([]interface{}{float64(1)}, value)
+	value, _ = restored.Read("4")
+	assert.Nil(value)
+
+	// A reader must know the names
+	other, _ := NewWithOptions(WithCodec(NewTypeRegistry().Codec(nil)))
+	assert.True(errors.Is(other.Restore(bytes.NewReader(buf.Bytes())), ErrCorrupted))
+
+	// The codec decodes into the registered type too
+	codec := registry.Codec(nil)
+	data, err := codec.Encode(point{3, 4})
+	assert.Nil(err)
+	var p point
+	assert.Nil(codec.Decode(data, &p))
+	assert.Equal(point{3, 4}, p)
+	var l label
+	assert.True(errors.Is(codec.Decode(data, &l), ErrInvalidArgument))
+	assert.True(errors.Is(codec.Decode(data[:3], &p), ErrCorrupted))
+}
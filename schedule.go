@@ -0,0 +1,240 @@
+package linear
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule return the next time after t a scheduled push is due
+type Schedule interface {
+	Next(t time.Time) time.Time
+}
+
+// CatchUpPolicy decide what a scheduled push does with the ticks it missed
+type CatchUpPolicy int
+
+const (
+	// SkipMissed push once for the latest missed tick
+	SkipMissed CatchUpPolicy = iota
+	// CatchUp push once for every missed tick
+	CatchUp
+)
+
+// maxCatchUp bound the number of pushes made for missed ticks at once
+const maxCatchUp = 1024
+
+type every time.Duration
+
+// Every return a schedule due every d, an interval not higher than 0 is never due, so SchedulePush rejects it
+func Every(d time.Duration) Schedule {
+	return every(d)
+}
+
+// Next return t plus the interval, or the zero time when the interval is not higher than 0
+func (e every) Next(t time.Time) time.Time {
+	if e <= 0 {
+		return time.Time{}
+	}
+	return t.Add(time.Duration(e))
+}
+
+type cron struct {
+	minute, hour, dom, month, dow uint64
+	anyDom, anyDow                bool
+}
+
+// cronFields hold the bounds of the minute, hour, day of month, month and day of week fields
+var cronFields = [5][2]int{{0, 59}, {0, 23}, {1, 31}, {1, 12}, {0, 6}}
+
+// ParseCron parse a standard five fields cron expression "minute hour day-of-month month day-of-week"
+// Fields accept "*", numbers, ranges "a-b", steps "*/n" or "a-b/n" and comma separated lists
+func ParseCron(expr string) (Schedule, error) {
+
+	fields := strings.Fields(expr)
+
+	// Argument validator
+	if len(fields) != len(cronFields) {
+		return nil, fmt.Errorf("%w: cron expression %q needs %d fields", ErrInvalidArgument, expr, len(cronFields))
+	}
+
+	var sets [5]uint64
+	for i, field := range fields {
+		set, err := parseCronField(field, cronFields[i][0], cronFields[i][1])
+		if err != nil {
+			return nil, fmt.Errorf("%w: cron expression %q: %v", ErrInvalidArgument, expr, err)
+		}
+		sets[i] = set
+	}
+
+	return &cron{
+		minute: sets[0],
+		hour:   sets[1],
+		dom:    sets[2],
+		month:  sets[3],
+		dow:    sets[4],
+		anyDom: fields[2] == "*",
+		anyDow: fields[4] == "*",
+	}, nil
+}
+
+// parseCronField return the bit set of the values matched by a cron field
+func parseCronField(field string, min, max int) (uint64, error) {
+
+	var set uint64
+	for _, part := range strings.Split(field, ",") {
+		rng, step := part, 1
+		if i := strings.IndexByte(part, '/'); i >= 0 {
+			n, err := strconv.Atoi(part[i+1:])
+			if err != nil || n <= 0 {
+				return 0, fmt.Errorf("invalid step %q", part)
+			}
+			rng, step = part[:i], n
+		}
+
+		low, high := min, max
+		if rng != "*" {
+			bounds := strings.SplitN(rng, "-", 2)
+			var err error
+			if low, err = strconv.Atoi(bounds[0]); err != nil {
+				return 0, fmt.Errorf("invalid value %q", part)
+			}
+			high = low
+			if len(bounds) == 2 {
+				if high, err = strconv.Atoi(bounds[1]); err != nil {
+					return 0, fmt.Errorf("invalid value %q", part)
+				}
+			} else if step > 1 {
+				high = max
+			}
+		}
+
+		if low < min || high > max || low > high {
+			return 0, fmt.Errorf("value %q out of range %d-%d", part, min, max)
+		}
+
+		for v := low; v <= high; v += step {
+			set |= 1 << uint(v)
+		}
+	}
+
+	return set, nil
+}
+
+// Next return the first minute after t matching the expression
+func (c *cron) Next(t time.Time) time.Time {
+
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+
+	for t.Before(limit) {
+		switch {
+		case c.month&(1<<uint(t.Month())) == 0:
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+		case !c.matchDay(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+		case c.hour&(1<<uint(t.Hour())) == 0:
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+		case c.minute&(1<<uint(t.Minute())) == 0:
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+
+	return time.Time{}
+}
+
+// matchDay check the day of month and day of week fields, when both are restricted either one matches
+func (c *cron) matchDay(t time.Time) bool {
+
+	dom := c.dom&(1<<uint(t.Day())) != 0
+	dow := c.dow&(1<<uint(t.Weekday())) != 0
+
+	if !c.anyDom && !c.anyDow {
+		return dom || dow
+	}
+
+	return dom && dow
+}
+
+// SchedulePush push the item returned by generate every time the schedule is due until the linear is closed
+// Ticks missed while the linear was busy are handled by policy, push errors are logged.
+// A schedule never due, or whose next tick is not after the previous one, is rejected with ErrInvalidArgument
+// or stops when it happens later
+func (l *Linear) SchedulePush(schedule Schedule, policy CatchUpPolicy, generate func(at time.Time) (string, interface{})) error {
+
+	// Argument validator
+	if schedule == nil || generate == nil {
+		return ErrInvalidArgument
+	}
+
+	// Execution conditions
+	if l.IsClosed() {
+		return ErrClosed
+	}
+
+	now := time.Now()
+	next := schedule.Next(now)
+	if next.IsZero() {
+		return fmt.Errorf("%w: schedule is never due", ErrInvalidArgument)
+	}
+
+	if !next.After(now) {
+		return fmt.Errorf("%w: schedule does not move forward", ErrInvalidArgument)
+	}
+
+	started := l.startWorker(func(done <-chan struct{}) {
+		timer := time.NewTimer(time.Until(next))
+		defer timer.Stop()
+
+		for {
+			select {
+			case <-done:
+				return
+			case now := <-timer.C:
+				var due []time.Time
+				due, next = dueTicks(schedule, policy, next, now)
+				for _, at := range due {
+					key, value := generate(at)
+					if err := l.Push(key, value); err != nil {
+						l.logger.Printf("linear: scheduled push of %q failed: %v", key, err)
+					}
+				}
+				if next.IsZero() {
+					return
+				}
+				timer.Reset(time.Until(next))
+			}
+		}
+	})
+
+	if !started {
+		return ErrClosed
+	}
+
+	return nil
+}
+
+// dueTicks return the ticks to push at now starting from next, and the next tick still to come
+// The next tick is the zero time once the schedule is never due again or stops moving forward
+func dueTicks(schedule Schedule, policy CatchUpPolicy, next, now time.Time) ([]time.Time, time.Time) {
+
+	var due []time.Time
+	for !next.IsZero() && !next.After(now) {
+		if policy == SkipMissed || len(due) == maxCatchUp {
+			due = append(due[:0], next)
+		} else {
+			due = append(due, next)
+		}
+
+		following := schedule.Next(next)
+		if !following.After(next) {
+			return due, time.Time{}
+		}
+		next = following
+	}
+
+	return due, next
+}
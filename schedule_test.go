@@ -0,0 +1,124 @@
+package linear
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCron(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	from := time.Date(2024, time.January, 1, 10, 7, 30, 0, time.UTC) // Monday
+	cases := map[string]time.Time{
+		"* * * * *":       time.Date(2024, time.January, 1, 10, 8, 0, 0, time.UTC),
+		"*/15 * * * *":    time.Date(2024, time.January, 1, 10, 15, 0, 0, time.UTC),
+		"0 9-17 * * *":    time.Date(2024, time.January, 1, 11, 0, 0, 0, time.UTC),
+		"30 2 * * *":      time.Date(2024, time.January, 2, 2, 30, 0, 0, time.UTC),
+		"0 0 1 */3 *":     time.Date(2024, time.April, 1, 0, 0, 0, 0, time.UTC),
+		"0 0 * * 6":       time.Date(2024, time.January, 6, 0, 0, 0, 0, time.UTC),
+		"0 0 15 * 3":      time.Date(2024, time.January, 3, 0, 0, 0, 0, time.UTC),
+		"5,10 10 1,2 * *": time.Date(2024, time.January, 1, 10, 10, 0, 0, time.UTC),
+	}
+
+	// Testing
+	for expr, expected := range cases {
+		schedule, err := ParseCron(expr)
+		assert.Nil(err, expr)
+		if next := schedule.Next(from); !next.Equal(expected) {
+			t.Errorf("ParseCron %q failed, expected %v, got %v", expr, expected, next)
+		}
+	}
+
+	for _, expr := range []string{"", "* * * *", "60 * * * *", "* * 0 * *", "*/0 * * * *", "5-1 * * * *", "a * * * *"} {
+		_, err := ParseCron(expr)
+		assert.True(errors.Is(err, ErrInvalidArgument), expr)
+	}
+
+	schedule, _ := ParseCron("0 0 31 2 *")
+	assert.True(schedule.Next(from).IsZero())
+}
+
+func TestDueTicks(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	start := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	now := start.Add(3500 * time.Millisecond)
+
+	// Testing
+	due, next := dueTicks(Every(time.Second), CatchUp, start, now)
+	assert.Len(due, 4)
+	assert.Equal(start.Add(4*time.Second), next)
+
+	due, next = dueTicks(Every(time.Second), SkipMissed, start, now)
+	assert.Equal([]time.Time{start.Add(3 * time.Second)}, due)
+	assert.Equal(start.Add(4*time.Second), next)
+
+	due, _ = dueTicks(Every(time.Second), CatchUp, start, start.Add(-time.Second))
+	assert.Empty(due)
+
+	// A schedule not moving forward stops instead of looping
+	due, next = dueTicks(stuckSchedule{}, CatchUp, start, now)
+	assert.Equal([]time.Time{start}, due)
+	assert.True(next.IsZero())
+}
+
+// stuckSchedule is due again at the time it was due
+type stuckSchedule struct{}
+
+func (stuckSchedule) Next(t time.Time) time.Time {
+	return t
+}
+
+func TestScheduleEveryZero(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	linearClient := New(1024, false)
+	generate := func(at time.Time) (string, interface{}) {
+		return at.Format(time.RFC3339Nano), 1
+	}
+
+	// Testing
+	assert.True(Every(0).Next(time.Now()).IsZero())
+	assert.True(errors.Is(linearClient.SchedulePush(Every(0), CatchUp, generate), ErrInvalidArgument))
+	assert.True(errors.Is(linearClient.SchedulePush(Every(-time.Second), SkipMissed, generate), ErrInvalidArgument))
+	assert.True(errors.Is(linearClient.SchedulePush(stuckSchedule{}, CatchUp, generate), ErrInvalidArgument))
+	assert.Equal(0, linearClient.Goroutines())
+
+	closed := make(chan error)
+	go func() { closed <- linearClient.Close() }()
+	select {
+	case err := <-closed:
+		assert.Nil(err)
+	case <-time.After(time.Second):
+		t.Errorf("Close failed, expected %v, got %v", "no wait", "blocked")
+	}
+}
+
+func TestSchedulePush(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	linearClient := New(1024, false)
+	count := 0
+	generate := func(at time.Time) (string, interface{}) {
+		count++
+		return at.Format(time.RFC3339Nano), count
+	}
+
+	// Testing
+	assert.True(errors.Is(linearClient.SchedulePush(nil, CatchUp, generate), ErrInvalidArgument))
+	assert.Nil(linearClient.SchedulePush(Every(5*time.Millisecond), CatchUp, generate))
+	assert.Equal(1, linearClient.Goroutines())
+
+	assert.Eventually(func() bool { return linearClient.ApproxLen() >= 2 }, time.Second, time.Millisecond)
+
+	assert.Nil(linearClient.Close())
+	assert.Equal(0, linearClient.Goroutines())
+	assert.True(errors.Is(linearClient.SchedulePush(Every(time.Second), CatchUp, generate), ErrClosed))
+}
@@ -0,0 +1,409 @@
+package linear
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"runtime"
+	"sort"
+	"strconv"
+	"sync/atomic"
+)
+
+// sequenced is the value stored by the shards, Seq orders the items across shards
+type sequenced struct {
+	Seq   uint64
+	Value interface{}
+}
+
+// sequencedCodec encode the sequence of sharded items before their value, which is encoded by the inner codec
+type sequencedCodec struct {
+	inner Codec
+}
+
+// Encode return the sequence as a uvarint followed by the inner encoding of the value, nil values are encoded as no bytes
+func (c sequencedCodec) Encode(v interface{}) ([]byte, error) {
+
+	item, ok := v.(sequenced)
+	if !ok {
+		return nil, fmt.Errorf("%T is not a sharded item", v)
+	}
+
+	data := make([]byte, binary.MaxVarintLen64)
+	data = data[:binary.PutUvarint(data, item.Seq)]
+	if item.Value == nil {
+		return data, nil
+	}
+
+	value, err := c.inner.Encode(item.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(data, value...), nil
+}
+
+// Decode decode a sharded item encoded by Encode into v, a *interface{}
+func (c sequencedCodec) Decode(data []byte, v interface{}) error {
+
+	seq, n := binary.Uvarint(data)
+	if n <= 0 {
+		return errors.New("truncated sequence")
+	}
+
+	item := sequenced{Seq: seq}
+	if len(data) > n {
+		if err := c.inner.Decode(data[n:], &item.Value); err != nil {
+			return err
+		}
+	}
+
+	target, ok := v.(*interface{})
+	if !ok {
+		return fmt.Errorf("cannot decode a sharded item into %T", v)
+	}
+	*target = item
+
+	return nil
+}
+
+// Sharded spread the keys over shards, each with its own lock, so writes to different keys don't serialize
+// Pop and Take follow the global push order when the linear is used serially, concurrent pushes and removals
+// on different shards may interleave. A key duplicated by Push keeps the position of its first push
+type Sharded struct {
+	seq    uint64 // Accessed atomically, kept first for 64-bit alignment
+	shards []*Linear
+}
+
+// WithShards set the number of shards of NewSharded, runtime.GOMAXPROCS(0) by default, NewWithOptions rejects it
+func WithShards(n int) Option {
+	return func(l *Linear) {
+		l.shardCount = n
+	}
+}
+
+// NewSharded return new sharded linear instance, opts configure every shard, so sizes and caps apply per shard
+// Every shard writes its own files, path + "." + its index, for WithPersistence and WithAppendLog. WithSpillover is
+// rejected with ErrInvalidArgument, Pop and Take order the shards by the items in memory only. So is
+// WithEvictionPolicy with a policy other than FIFO and LIFO, a policy instance keeps the keys of a single linear
+func NewSharded(opts ...Option) (*Sharded, error) {
+
+	probe := Linear{}
+	for _, opt := range opts {
+		opt(&probe)
+	}
+
+	n := probe.shardCount
+	if n == 0 {
+		n = runtime.GOMAXPROCS(0)
+	}
+
+	// Argument validator
+	if n < 0 || probe.spill != nil {
+		return nil, ErrInvalidArgument
+	}
+
+	switch probe.eviction.(type) {
+	case nil, fifoPolicy, lifoPolicy:
+	default:
+		return nil, ErrInvalidArgument
+	}
+
+	s := &Sharded{shards: make([]*Linear, n)}
+	for i := range s.shards {
+		shard, err := NewWithOptions(append(opts, shardOption(i))...)
+		if err != nil {
+			s.Close()
+			return nil, err
+		}
+		s.shards[i] = shard
+	}
+
+	// Restored items keep their sequence, new pushes follow them
+	for _, shard := range s.shards {
+		shard.RangeOrdered(func(key string, value interface{}) bool {
+			if item, ok := value.(sequenced); ok && item.Seq > s.seq {
+				s.seq = item.Seq
+			}
+			return true
+		})
+	}
+
+	return s, nil
+}
+
+// OpenSharded return new sharded linear instance restored from the snapshot files of its shards at path + "." + their
+// index and persisted back to them, like Open. The number of shards must be the one the files were written with
+func OpenSharded(path string, opts ...Option) (*Sharded, error) {
+	return NewSharded(append([]Option{WithPersistence(path, 0)}, append(opts, func(l *Linear) {
+		l.openPath = path
+	})...)...)
+}
+
+// shardOption set up the shard i of a sharded linear, it must come after the user options
+func shardOption(i int) Option {
+	return func(l *Linear) {
+		suffix := "." + strconv.Itoa(i)
+		if l.persistPath != "" {
+			l.persistPath += suffix
+		}
+		if l.openPath != "" {
+			l.openPath += suffix
+		}
+		if l.walPath != "" {
+			l.walPath += suffix
+		}
+		l.codec = sequencedCodec{inner: l.codecOrDefault()}
+		for i := range l.prefixCodecs {
+			if l.prefixCodecs[i].codec != nil {
+				l.prefixCodecs[i].codec = sequencedCodec{inner: l.prefixCodecs[i].codec}
+			}
+		}
+		l.shardCount = 0
+	}
+}
+
+// Shards return the number of shards
+func (s *Sharded) Shards() int {
+	return len(s.shards)
+}
+
+// shard return the shard holding key
+func (s *Sharded) shard(key string) *Linear {
+	h := fnv.New32a()
+	h.Write([]byte(key))
+	return s.shards[h.Sum32()%uint32(len(s.shards))]
+}
+
+// Push item to the linear with key
+func (s *Sharded) Push(key string, value interface{}) error {
+
+	// Argument validator
+	if key == "" && value == nil {
+		return ErrInvalidKey
+	}
+
+	return s.shard(key).Push(key, sequenced{Seq: atomic.AddUint64(&s.seq, 1), Value: value})
+}
+
+// Pop return and remove the last pushed item out of the linear
+func (s *Sharded) Pop() (interface{}, error) {
+	return s.remove(true)
+}
+
+// Take return and remove the first pushed item out of the linear
+func (s *Sharded) Take() (interface{}, error) {
+	return s.remove(false)
+}
+
+// remove take the item with the lowest sequence from the front of the shards, or the highest one from their back
+func (s *Sharded) remove(back bool) (interface{}, error) {
+
+	for attempt := 0; attempt <= len(s.shards); attempt++ {
+		var (
+			best  *Linear
+			bestN uint64
+		)
+		for _, shard := range s.shards {
+			seq, ok := shard.endSeq(back)
+			if ok && (best == nil || (back && seq > bestN) || (!back && seq < bestN)) {
+				best, bestN = shard, seq
+			}
+		}
+
+		if best == nil {
+			if s.IsClosed() {
+				return nil, ErrClosed
+			}
+			return nil, ErrEmpty
+		}
+
+		remove := best.Take
+		if back {
+			remove = best.Pop
+		}
+
+		item, err := remove()
+		if errors.Is(err, ErrEmpty) {
+			continue // Drained by a concurrent removal
+		}
+		if err != nil {
+			return nil, err
+		}
+		return unwrap(item), nil
+	}
+
+	return nil, ErrEmpty
+}
+
+// endSeq return the sequence of the item at the front or the back of the shard, items without one come first
+func (l *Linear) endSeq(back bool) (uint64, bool) {
+
+	l.mux.RLock()
+	defer l.mux.RUnlock()
+
+	n := l.keys.head
+	if back {
+		n = l.keys.tail
+	}
+	if n == nil {
+		return 0, false
+	}
+
+	item, _ := l.loadItem(n.key)
+	return seqOf(item), true
+}
+
+// seqOf return the sequence of a stored item, 0 for an item a shard stored without one
+func seqOf(item interface{}) uint64 {
+	if value, ok := item.(sequenced); ok {
+		return value.Seq
+	}
+	return 0
+}
+
+// Get return and remove the item by key out of the linear
+func (s *Sharded) Get(key string) (interface{}, error) {
+
+	item, err := s.shard(key).Get(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return unwrap(item), nil
+}
+
+// Read return the item by key without remove it
+func (s *Sharded) Read(key string) (interface{}, error) {
+
+	item, err := s.shard(key).Read(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return unwrap(item), nil
+}
+
+// unwrap return the value of a sequenced item, items loaded by a fallback are returned as is
+func unwrap(item interface{}) interface{} {
+	if value, ok := item.(sequenced); ok {
+		return value.Value
+	}
+	return item
+}
+
+// Update reassign value to the key, the key keeps its position
+func (s *Sharded) Update(key string, value interface{}) error {
+
+	shard := s.shard(key)
+
+	// Execution conditions
+	if shard.IsClosed() {
+		return ErrClosed
+	}
+
+	// Argument validator
+	if key == "" && value == nil {
+		return ErrInvalidKey
+	}
+
+	if shard.clone != nil {
+		value = shard.clone(value)
+	}
+
+	shard.mux.Lock()
+	defer shard.mux.Unlock()
+
+	current, exits := shard.loadItem(key)
+	if !exits {
+		return newError("update", key, ErrKeyNotFound)
+	}
+
+	item := sequenced{Seq: seqOf(current), Value: value}
+	itemSize := shard.valueSize(key, item)
+	if calculateKeySize(key)+itemSize > shard.linearSizes {
+		return newError("update", key, ErrCapacityExceeded)
+	}
+
+	if err := shard.update(key, item, itemSize); err != nil {
+		return err
+	}
+	atomic.AddInt64(&shard.stats.updates, 1)
+
+	return nil
+}
+
+// Delete remove every occurrence of the key and its item out of the linear
+func (s *Sharded) Delete(key string) error {
+	return s.shard(key).Delete(key)
+}
+
+// IsExits check key exits or not
+func (s *Sharded) IsExits(key string) bool {
+	_, exits := s.shard(key).IsExits(key)
+	return exits
+}
+
+// Getkeys return a copy of the list of key in push order
+func (s *Sharded) Getkeys() []string {
+
+	type keySeq struct {
+		key string
+		seq uint64
+	}
+
+	var keys []keySeq
+	for _, shard := range s.shards {
+		shard.RangeOrdered(func(key string, value interface{}) bool {
+			keys = append(keys, keySeq{key, seqOf(value)})
+			return true
+		})
+	}
+
+	sort.SliceStable(keys, func(i, j int) bool { return keys[i].seq < keys[j].seq })
+
+	ordered := make([]string, len(keys))
+	for i := range keys {
+		ordered[i] = keys[i].key
+	}
+
+	return ordered
+}
+
+// GetNumberOfKeys return the number of keys
+func (s *Sharded) GetNumberOfKeys() int {
+
+	n := 0
+	for _, shard := range s.shards {
+		n += shard.GetNumberOfKeys()
+	}
+
+	return n
+}
+
+// IsEmpty check linear size
+func (s *Sharded) IsEmpty() bool {
+	return s.GetNumberOfKeys() == 0
+}
+
+// IsClosed check if the linear was closed
+func (s *Sharded) IsClosed() bool {
+	return len(s.shards) > 0 && s.shards[0].IsClosed()
+}
+
+// Close close every shard
+func (s *Sharded) Close() error {
+
+	var err error
+	for _, shard := range s.shards {
+		if shard == nil {
+			continue
+		}
+		if closeErr := shard.Close(); err == nil {
+			err = closeErr
+		}
+	}
+
+	return err
+}
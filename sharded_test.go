@@ -0,0 +1,278 @@
+package linear
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShardedOrder(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	shardedClient, err := NewSharded(WithShards(4))
+	assert.Nil(err)
+	assert.Equal(4, shardedClient.Shards())
+
+	for i := 0; i < 20; i++ {
+		assert.Nil(shardedClient.Push(strconv.Itoa(i), i))
+	}
+
+	// Testing
+	keys := shardedClient.Getkeys()
+	for i := range keys {
+		if keys[i] != strconv.Itoa(i) {
+			t.Errorf("Getkeys failed, expected %v, got %v", strconv.Itoa(i), keys[i])
+		}
+	}
+
+	item, _ := shardedClient.Take()
+	assert.Equal(0, item)
+	item, _ = shardedClient.Pop()
+	assert.Equal(19, item)
+
+	for i := 1; i < 19; i++ {
+		item, err := shardedClient.Take()
+		assert.Nil(err)
+		if item != i {
+			t.Errorf("Take failed, expected %v, got %v", i, item)
+		}
+	}
+
+	_, err = shardedClient.Take()
+	assert.True(errors.Is(err, ErrEmpty))
+	assert.True(shardedClient.IsEmpty())
+}
+
+func TestShardedKeyed(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	shardedClient, _ := NewSharded(WithShards(3), WithMaxBytes(1024))
+	shardedClient.Push("a", 1)
+	shardedClient.Push("b", 2)
+	shardedClient.Push("c", 3)
+
+	// Testing
+	assert.Nil(shardedClient.Update("a", 10))
+	item, _ := shardedClient.Read("a")
+	assert.Equal(10, item)
+	assert.True(errors.Is(shardedClient.Update("z", 1), ErrKeyNotFound))
+
+	// Update keeps the position of the key
+	item, _ = shardedClient.Take()
+	assert.Equal(10, item)
+
+	item, err := shardedClient.Get("c")
+	assert.Nil(err)
+	assert.Equal(3, item)
+	assert.False(shardedClient.IsExits("c"))
+
+	assert.Nil(shardedClient.Delete("b"))
+	assert.Equal(0, shardedClient.GetNumberOfKeys())
+
+	_, err = NewSharded(WithShards(-1))
+	assert.True(errors.Is(err, ErrInvalidArgument))
+
+	assert.Nil(shardedClient.Close())
+	assert.True(shardedClient.IsClosed())
+	_, err = shardedClient.Take()
+	assert.True(errors.Is(err, ErrClosed))
+}
+
+func TestShardedConcurrent(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	shardedClient, _ := NewSharded(WithShards(8))
+	var wg sync.WaitGroup
+
+	// Testing
+	for w := 0; w < 8; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < 500; i++ {
+				shardedClient.Push(strconv.Itoa(w*500+i), i)
+			}
+		}(w)
+	}
+	wg.Wait()
+	assert.Equal(4000, shardedClient.GetNumberOfKeys())
+
+	var mux sync.Mutex
+	taken := 0
+	for w := 0; w < 8; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				if _, err := shardedClient.Take(); err != nil {
+					return
+				}
+				mux.Lock()
+				taken++
+				mux.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(4000, taken)
+	assert.True(shardedClient.IsEmpty())
+}
+
+func BenchmarkParallelPushTake(b *testing.B) {
+
+	b.Run("linear", func(b *testing.B) {
+		linearClient := New(1<<30, false)
+		benchmarkPushTake(b, linearClient.Push, linearClient.Get)
+	})
+
+	b.Run("sharded", func(b *testing.B) {
+		shardedClient, _ := NewSharded(WithMaxBytes(1 << 30))
+		benchmarkPushTake(b, shardedClient.Push, shardedClient.Get)
+	})
+}
+
+// benchmarkPushTake push and remove distinct keys from parallel goroutines
+func benchmarkPushTake(b *testing.B, push func(string, interface{}) error, get func(string) (interface{}, error)) {
+
+	var worker int64
+	var mux sync.Mutex
+
+	b.RunParallel(func(pb *testing.PB) {
+		mux.Lock()
+		worker++
+		prefix := strconv.FormatInt(worker, 10) + "-"
+		mux.Unlock()
+
+		for i := 0; pb.Next(); i++ {
+			key := prefix + strconv.Itoa(i)
+			push(key, i)
+			get(key)
+		}
+	})
+}
+
+func TestShardedPersistence(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	path := filepath.Join(t.TempDir(), "sharded.snapshot")
+	shardedClient, err := OpenSharded(path, WithShards(4))
+	assert.Nil(err)
+	for i := 0; i < 40; i++ {
+		assert.Nil(shardedClient.Push(strconv.Itoa(i), i))
+	}
+	assert.Nil(shardedClient.Close())
+
+	// Testing
+	for i := 0; i < 4; i++ {
+		_, err := os.Stat(path + "." + strconv.Itoa(i))
+		assert.Nil(err)
+	}
+
+	reopened, err := OpenSharded(path, WithShards(4))
+	assert.Nil(err)
+	defer reopened.Close()
+	assert.Equal(40, reopened.GetNumberOfKeys())
+
+	// New pushes follow the restored items
+	assert.Nil(reopened.Push("40", 40))
+	item, _ := reopened.Pop()
+	assert.Equal(40, item)
+
+	for i := 0; i < 40; i++ {
+		item, err := reopened.Take()
+		assert.Nil(err)
+		if item != i {
+			t.Errorf("Take failed, expected %v, got %v", i, item)
+		}
+	}
+}
+
+func TestShardedAppendLog(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	path := filepath.Join(t.TempDir(), "sharded.log")
+	shardedClient, err := NewSharded(WithShards(3), WithAppendLog(path, 0), WithCodec(JSONCodec{}))
+	assert.Nil(err)
+	for i := 0; i < 20; i++ {
+		assert.Nil(shardedClient.Push(strconv.Itoa(i), "v"+strconv.Itoa(i)))
+	}
+	assert.Nil(shardedClient.Close())
+
+	// Testing
+	reopened, err := NewSharded(WithShards(3), WithAppendLog(path, 0), WithCodec(JSONCodec{}))
+	assert.Nil(err)
+	defer reopened.Close()
+
+	keys := reopened.Getkeys()
+	assert.Equal(20, len(keys))
+	for i := range keys {
+		if keys[i] != strconv.Itoa(i) {
+			t.Errorf("Getkeys failed, expected %v, got %v", strconv.Itoa(i), keys[i])
+		}
+	}
+	item, _ := reopened.Read("7")
+	assert.Equal("v7", item)
+}
+
+func TestShardedOptions(t *testing.T) {
+	assert := assert.New(t)
+
+	// Testing
+	_, err := NewSharded(WithShards(2), WithMaxItems(1), WithSpillover(t.TempDir(), 1024))
+	assert.True(errors.Is(err, ErrInvalidArgument))
+
+	_, err = NewWithOptions(WithShards(2))
+	assert.True(errors.Is(err, ErrInvalidArgument))
+}
+
+func TestShardedEvictionPolicy(t *testing.T) {
+	assert := assert.New(t)
+
+	// Testing
+	_, err := NewSharded(WithShards(2), WithEvictionPolicy(LRU()))
+	assert.True(errors.Is(err, ErrInvalidArgument))
+
+	shardedClient, err := NewSharded(WithShards(2), WithEvictionPolicy(LIFO()))
+	assert.Nil(err)
+	shardedClient.Close()
+}
+
+func TestShardedRefreshAhead(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	load := func(key string) (interface{}, error) { return key + "-reloaded", nil }
+	shardedClient, err := NewSharded(WithShards(2), WithDefaultTTL(100*time.Millisecond), WithRefreshAhead(0.1, load))
+	assert.Nil(err)
+	defer shardedClient.Close()
+
+	shardedClient.Push("1", "a")
+	shardedClient.Push("2", "b")
+
+	// Testing
+	time.Sleep(20 * time.Millisecond)
+	shardedClient.Read("1")
+	assert.Eventually(func() bool {
+		value, _ := shardedClient.Read("1")
+		return value == "1-reloaded"
+	}, time.Second, time.Millisecond)
+
+	assert.Equal([]string{"1", "2"}, shardedClient.Getkeys())
+	item, err := shardedClient.Take()
+	assert.Nil(err)
+	if item != "1-reloaded" {
+		t.Errorf("Take failed, expected %v, got %v", "1-reloaded", item)
+	}
+}
@@ -0,0 +1,160 @@
+package linear
+
+import (
+	"reflect"
+	"unsafe"
+)
+
+// maxSizeDepth cap how deep calculateValueSize follows nested references
+const maxSizeDepth = 64
+
+// mapEntryOverhead approximate the bucket bytes a map spends per entry besides its keys and values
+const mapEntryOverhead = 8
+
+// sizeVisit identify an already measured reference to skip shared data and cycles
+type sizeVisit struct {
+	ptr uintptr
+	typ reflect.Type
+}
+
+// calculateItemSize return the bytes taken by key and value
+func calculateItemSize(key string, value interface{}) int64 {
+	return calculateKeySize(key) + calculateValueSize(value)
+}
+
+// valueSize return the bytes accounted for the value of key, measured by the WithSizeFunc function when set
+func (l *Linear) valueSize(key string, value interface{}) int64 {
+	if lazy, ok := value.(*lazyValue); ok {
+		return calculateValueSize(lazy.data)
+	}
+
+	if l.sizeFunc == nil {
+		return calculateValueSize(value)
+	}
+
+	// Values of a sharded linear carry their sequence
+	if item, ok := value.(sequenced); ok {
+		return int64(unsafe.Sizeof(item.Seq)) + l.valueSize(key, item.Value)
+	}
+
+	if size := l.sizeFunc(key, value); size > 0 {
+		return size
+	}
+	return 0
+}
+
+// calculateKeySize return the bytes taken by key, string header included
+func calculateKeySize(key string) int64 {
+	return int64(unsafe.Sizeof(key)) + int64(len(key))
+}
+
+// calculateValueSize return the bytes taken by value and everything it references through pointers, slices, maps and interfaces
+func calculateValueSize(value interface{}) int64 {
+	if value == nil {
+		return 0
+	}
+
+	v := reflect.ValueOf(value)
+	return int64(v.Type().Size()) + referencedSize(v, map[sizeVisit]bool{}, 0)
+}
+
+// referencedSize return the bytes v references outside of its own memory, visited stops on shared data and cycles
+func referencedSize(v reflect.Value, visited map[sizeVisit]bool, depth int) int64 {
+
+	if depth > maxSizeDepth || !hasReferences(v.Type()) {
+		return 0
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		return int64(v.Len())
+
+	case reflect.Ptr:
+		if v.IsNil() || !visit(v, visited) {
+			return 0
+		}
+		return int64(v.Type().Elem().Size()) + referencedSize(v.Elem(), visited, depth+1)
+
+	case reflect.Interface:
+		if v.IsNil() {
+			return 0
+		}
+		elem := v.Elem()
+		return int64(elem.Type().Size()) + referencedSize(elem, visited, depth+1)
+
+	case reflect.Slice:
+		if v.IsNil() || !visit(v, visited) {
+			return 0
+		}
+
+		size := int64(v.Cap()) * int64(v.Type().Elem().Size())
+		if hasReferences(v.Type().Elem()) {
+			for i := 0; i < v.Len(); i++ {
+				size += referencedSize(v.Index(i), visited, depth+1)
+			}
+		}
+		return size
+
+	case reflect.Array:
+		var size int64
+		for i := 0; i < v.Len(); i++ {
+			size += referencedSize(v.Index(i), visited, depth+1)
+		}
+		return size
+
+	case reflect.Map:
+		if v.IsNil() || !visit(v, visited) {
+			return 0
+		}
+
+		entrySize := int64(v.Type().Key().Size()) + int64(v.Type().Elem().Size()) + mapEntryOverhead
+		size := int64(v.Len()) * entrySize
+		iter := v.MapRange()
+		for iter.Next() {
+			size += referencedSize(iter.Key(), visited, depth+1) + referencedSize(iter.Value(), visited, depth+1)
+		}
+		return size
+
+	case reflect.Struct:
+		var size int64
+		for i := 0; i < v.NumField(); i++ {
+			size += referencedSize(v.Field(i), visited, depth+1)
+		}
+		return size
+
+	case reflect.Chan:
+		if v.IsNil() || !visit(v, visited) {
+			return 0
+		}
+		return int64(v.Cap()) * int64(v.Type().Elem().Size())
+	}
+
+	return 0
+}
+
+// visit mark the reference held by v as measured and report if it was not measured before
+func visit(v reflect.Value, visited map[sizeVisit]bool) bool {
+	key := sizeVisit{v.Pointer(), v.Type()}
+	if visited[key] {
+		return false
+	}
+	visited[key] = true
+	return true
+}
+
+// hasReferences report if a value of type t can reference memory outside of itself
+func hasReferences(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.String, reflect.Ptr, reflect.Interface, reflect.Slice, reflect.Map, reflect.Chan:
+		return true
+	case reflect.Array:
+		return t.Len() > 0 && hasReferences(t.Elem())
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			if hasReferences(t.Field(i).Type) {
+				return true
+			}
+		}
+	}
+	return false
+}
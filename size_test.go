@@ -0,0 +1,74 @@
+package linear
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCalculateValueSize(t *testing.T) {
+	assert := assert.New(t)
+
+	type node struct {
+		Name string
+		Next *node
+	}
+
+	cycle := &node{Name: "ab"}
+	cycle.Next = cycle
+
+	tests := []struct {
+		value    interface{}
+		expected int64
+	}{
+		{nil, 0},
+		{1, 8},
+		{"abc", 16 + 3},
+		{[]byte("abcd"), 24 + 4},
+		{[]string{"a", "bc"}, 24 + 2*16 + 3},
+		{[2]string{"a", "b"}, 32 + 2},
+		{map[string]int{"a": 1}, 8 + 16 + 8 + mapEntryOverhead + 1},
+		{node{Name: "a"}, 24 + 1},
+		{cycle, 8 + 24 + 2},
+		{struct{ Value interface{} }{"ab"}, 16 + 16 + 2},
+	}
+
+	// Testing
+	for _, test := range tests {
+		assert.Equal(test.expected, calculateValueSize(test.value), "%#v", test.value)
+	}
+
+	assert.Equal(calculateItemSize("ab", "cd"), int64(16+2+16+2))
+}
+
+func TestSizeAccounting(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	linearClient := New(1<<20, false)
+	linearClient.Push("1", "a")
+	linearClient.Push("1", "b")
+	linearClient.Push("2", map[string][]byte{"a": []byte("abc")})
+	linearClient.Alias("3", "2")
+	linearClient.Alias("4", "2")
+
+	// Testing
+	assert.Nil(linearClient.Update("1", "long value"))
+	assert.Nil(linearClient.CheckSize())
+
+	assert.Nil(linearClient.Update("3", []int{1, 2, 3}))
+	assert.Nil(linearClient.CheckSize())
+
+	linearClient.Get("2")
+	assert.Nil(linearClient.CheckSize())
+
+	linearClient.Get("4")
+	assert.Nil(linearClient.Update("1", 1))
+	assert.Nil(linearClient.CheckSize())
+
+	for !linearClient.IsEmpty() {
+		linearClient.Take()
+	}
+
+	assert.Equal(linearClient.GetLinearCurrentSize(), int64(0))
+}
@@ -0,0 +1,652 @@
+package linear
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/gob"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"runtime"
+	"sync"
+	"time"
+)
+
+// snapshotMagic start every snapshot stream, followed by the format version
+var snapshotMagic = []byte("LINEAR")
+
+const (
+	// snapshotVersion hold the whole state in a single frame, it is still restored
+	snapshotVersion = 1
+	// snapshotChunkedVersion split the keys in chunks restored in parallel, it is the format written
+	snapshotChunkedVersion = 2
+)
+
+// snapshotChunkKeys is the number of key occurrences per snapshot chunk
+const snapshotChunkKeys = 4096
+
+// snapshotState is the gob encoded content of a snapshot
+type snapshotState struct {
+	Keys   []string // Front to back, duplicated keys included
+	Values map[string]interface{}
+	Refs   map[string]int
+	Shared [][]string
+	Seq    uint64 // Last append log record included
+
+	Expiries map[string]snapshotExpiry // Deadlines of the WallClock clock
+}
+
+// snapshotExpiry is the wall-clock deadline of a key in Unix nanoseconds with its TTL
+type snapshotExpiry struct {
+	At  int64
+	TTL time.Duration
+}
+
+// snapshotMeta is the first frame of a chunked snapshot
+type snapshotMeta struct {
+	Refs   map[string]int
+	Shared [][]string
+	Seq    uint64
+	Keys   int // Key occurrences over all chunks
+	Chunks int
+
+	Expiries map[string]snapshotExpiry
+}
+
+// snapshotChunk is a run of key occurrences, Values hold the keys whose first occurrence is in the chunk
+type snapshotChunk struct {
+	Index   int // Position of the chunk, chunks are restored in this order
+	Keys    []string
+	Values  map[string]interface{}
+	Encoded map[string][]byte // Values encoded by the WithCodec codec instead of gob
+
+	sizes map[string]int64 // Value sizes measured on restore
+}
+
+// RestoreProgress report how far a restore is, in key occurrences
+type RestoreProgress struct {
+	Decoded  int
+	Restored int
+	Total    int
+}
+
+// WithRestoreWorkers set how many goroutines decode and insert the chunks of a snapshot, runtime.GOMAXPROCS(0) by default
+func WithRestoreWorkers(workers int) Option {
+	return func(l *Linear) {
+		l.restoreWorkers = workers
+	}
+}
+
+// WithRestoreProgress call progress after every chunk of a snapshot decoded or inserted
+// It runs under the linear lock while chunks are inserted, so it must not use the linear
+func WithRestoreProgress(progress func(RestoreProgress)) Option {
+	return func(l *Linear) {
+		l.restoreProgress = progress
+	}
+}
+
+// Snapshot write the full state of the linear to w
+// Values are gob encoded, so concrete types stored behind interface{} must be registered with gob.Register, unless WithCodec is used
+func (l *Linear) Snapshot(w io.Writer) error {
+
+	// Execution conditions
+	if l.IsClosed() {
+		return ErrClosed
+	}
+
+	return l.writeSnapshot(w)
+}
+
+// writeSnapshot write the snapshot of Snapshot, it works on a closed linear so Close can persist it
+func (l *Linear) writeSnapshot(w io.Writer) error {
+
+	l.mux.RLock()
+	state := l.captureState()
+	l.mux.RUnlock()
+
+	return encodeSnapshot(w, state, l.snapshotEncoder())
+}
+
+// captureState return the state written by snapshots, caller must hold mux
+func (l *Linear) captureState() *snapshotState {
+
+	state := snapshotState{
+		Keys:   l.keys.slice(),
+		Values: make(map[string]interface{}, len(l.keys.index)),
+		Refs:   make(map[string]int, len(l.refs)),
+	}
+
+	for key := range l.keys.index {
+		state.Values[key], _ = l.items.Load(key)
+		if !l.hasCodec() {
+			state.Values[key] = l.resolve(state.Values[key]) // Gob can't write lazy values, a codec writes their encoding
+		}
+	}
+
+	for key, refs := range l.refs {
+		state.Refs[key] = refs
+	}
+
+	groups := map[*int][]string{}
+	for key, group := range l.shared {
+		groups[group] = append(groups[group], key)
+	}
+
+	for _, keys := range groups {
+		state.Shared = append(state.Shared, keys)
+	}
+	state.Seq = l.walSeq
+
+	// Monotonic deadlines mean nothing to another process
+	if l.expiryClock == WallClock && len(l.expiries) > 0 {
+		state.Expiries = make(map[string]snapshotExpiry, len(l.expiries))
+		for key, t := range l.expiries {
+			state.Expiries[key] = snapshotExpiry{At: t.at.UnixNano(), TTL: t.ttl}
+		}
+	}
+
+	return &state
+}
+
+// encodeSnapshot write state as a chunked snapshot to w, encode serialize the values when it isn't nil
+func encodeSnapshot(w io.Writer, state *snapshotState, encode func(key string, value interface{}) ([]byte, error)) error {
+
+	header := make([]byte, len(snapshotMagic)+1)
+	copy(header, snapshotMagic)
+	header[len(snapshotMagic)] = snapshotChunkedVersion
+	if _, err := w.Write(header); err != nil {
+		return err
+	}
+
+	chunks := (len(state.Keys) + snapshotChunkKeys - 1) / snapshotChunkKeys
+	meta := snapshotMeta{Refs: state.Refs, Shared: state.Shared, Seq: state.Seq, Keys: len(state.Keys), Chunks: chunks, Expiries: state.Expiries}
+	if err := writeFrame(w, &meta); err != nil {
+		return err
+	}
+
+	written := make(map[string]bool, len(state.Values))
+	for i := 0; i < chunks; i++ {
+		end := (i + 1) * snapshotChunkKeys
+		if end > len(state.Keys) {
+			end = len(state.Keys)
+		}
+
+		chunk := snapshotChunk{Index: i, Keys: state.Keys[i*snapshotChunkKeys : end], Values: map[string]interface{}{}}
+		if encode != nil {
+			chunk.Encoded = map[string][]byte{}
+		}
+		for _, key := range chunk.Keys {
+			if written[key] {
+				continue
+			}
+			written[key] = true
+
+			if encode == nil {
+				chunk.Values[key] = state.Values[key]
+				continue
+			}
+
+			encoded, err := encode(key, state.Values[key])
+			if err != nil {
+				return err
+			}
+			chunk.Encoded[key] = encoded
+		}
+
+		if err := writeFrame(w, &chunk); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// writeFrame write v gob encoded, after its checksum and length
+func writeFrame(w io.Writer, v interface{}) error {
+
+	var payload bytes.Buffer
+	if err := gob.NewEncoder(&payload).Encode(v); err != nil {
+		return err
+	}
+
+	header := make([]byte, 4+8)
+	binary.BigEndian.PutUint32(header, crc32.ChecksumIEEE(payload.Bytes()))
+	binary.BigEndian.PutUint64(header[4:], uint64(payload.Len()))
+
+	if _, err := w.Write(header); err != nil {
+		return err
+	}
+
+	_, err := payload.WriteTo(w)
+	return err
+}
+
+// readFrame return the checked payload of the next frame of r
+func readFrame(r io.Reader) ([]byte, error) {
+
+	header := make([]byte, 4+8)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrCorrupted, err)
+	}
+
+	checksum := binary.BigEndian.Uint32(header)
+	length := binary.BigEndian.Uint64(header[4:])
+
+	var payload bytes.Buffer
+	if n, err := io.CopyN(&payload, r, int64(length)); err != nil || uint64(n) != length {
+		return nil, fmt.Errorf("%w: truncated payload", ErrCorrupted)
+	}
+
+	if crc32.ChecksumIEEE(payload.Bytes()) != checksum {
+		return nil, fmt.Errorf("%w: checksum mismatch", ErrCorrupted)
+	}
+
+	return payload.Bytes(), nil
+}
+
+// Restore replace the content of the linear with the snapshot read from r
+// The stream is fully validated before the linear is touched, so a corrupted snapshot leaves it unchanged
+// Chunks are decoded and inserted by WithRestoreWorkers goroutines
+func (l *Linear) Restore(r io.Reader) error {
+	return l.RestoreContext(context.Background(), r, nil)
+}
+
+// RestoreContext is Restore stopping with the error of ctx when it is done before the snapshot is decoded
+// progress is called with the key occurrences decoded and the bytes read after every chunk, and once restored
+func (l *Linear) RestoreContext(ctx context.Context, r io.Reader, progress ProgressFunc) error {
+
+	// Execution conditions
+	if l.IsClosed() {
+		return ErrClosed
+	}
+
+	counted := &countingReader{r: r}
+	r = counted
+
+	// Both versions start with a frame after the version
+	header := make([]byte, len(snapshotMagic)+1+4+8)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return fmt.Errorf("%w: %v", ErrCorrupted, err)
+	}
+
+	if !bytes.Equal(header[:len(snapshotMagic)], snapshotMagic) {
+		return fmt.Errorf("%w: bad magic", ErrCorrupted)
+	}
+	r = io.MultiReader(bytes.NewReader(header[len(snapshotMagic)+1:]), r)
+
+	var (
+		meta   *snapshotMeta
+		chunks []*snapshotChunk
+		err    error
+	)
+	switch header[len(snapshotMagic)] {
+	case snapshotVersion:
+		meta, chunks, err = readSingleSnapshot(r)
+	case snapshotChunkedVersion:
+		meta, chunks, err = l.readChunkedSnapshot(ctx, r, func(decoded RestoreProgress) {
+			if progress != nil {
+				progress(decoded.Decoded, decoded.Total, counted.count())
+			}
+		})
+	default:
+		return ErrUnsupportedVersion
+	}
+	if err == nil {
+		err = ctx.Err()
+	}
+	if err == nil {
+		err = checkReferences(meta, chunks)
+	}
+	if err != nil {
+		return err
+	}
+
+	l.mux.Lock()
+	defer l.mux.Unlock()
+
+	l.restoreChunks(meta, chunks)
+	if progress != nil {
+		progress(meta.Keys, meta.Keys, counted.count())
+	}
+
+	l.linearCurrentSize = l.trackedSize()
+	l.publishCounters()
+	l.notifyPushed()
+
+	// Loading the append log snapshot, the records it includes are skipped on replay
+	if l.wal == nil {
+		l.walSeq = meta.Seq
+	}
+
+	return l.compactLog()
+}
+
+// readSingleSnapshot return the state of a single frame snapshot as one chunk
+func readSingleSnapshot(r io.Reader) (*snapshotMeta, []*snapshotChunk, error) {
+
+	payload, err := readFrame(r)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var state snapshotState
+	if err := gob.NewDecoder(bytes.NewReader(payload)).Decode(&state); err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", ErrCorrupted, err)
+	}
+
+	for _, key := range state.Keys {
+		if _, ok := state.Values[key]; !ok {
+			return nil, nil, fmt.Errorf("%w: key without value", ErrCorrupted)
+		}
+	}
+
+	meta, chunk := splitState(&state)
+	return meta, []*snapshotChunk{chunk}, nil
+}
+
+// decodeChunk decode the values of a chunk written with a codec
+func (l *Linear) decodeChunk(chunk *snapshotChunk) error {
+
+	if chunk.Encoded == nil {
+		return nil
+	}
+
+	if !l.hasCodec() {
+		return fmt.Errorf("%w: values encoded by a codec, use WithCodec", ErrCorrupted)
+	}
+
+	if chunk.Values == nil {
+		chunk.Values = make(map[string]interface{}, len(chunk.Encoded))
+	}
+	for key, encoded := range chunk.Encoded {
+		value, err := l.decodeValue(key, encoded)
+		if err != nil {
+			return err
+		}
+		chunk.Values[key] = value
+	}
+	chunk.Encoded = nil
+
+	return nil
+}
+
+// snapshotEncoder return the value encoder of snapshots, nil when values are written as gob interface values
+func (l *Linear) snapshotEncoder() func(key string, value interface{}) ([]byte, error) {
+	if !l.hasCodec() {
+		return nil
+	}
+	return l.encodeValue
+}
+
+// splitState return state as the meta and the single chunk restoreChunks takes
+func splitState(state *snapshotState) (*snapshotMeta, *snapshotChunk) {
+	meta := snapshotMeta{Refs: state.Refs, Shared: state.Shared, Seq: state.Seq, Keys: len(state.Keys), Chunks: 1, Expiries: state.Expiries}
+	return &meta, &snapshotChunk{Keys: state.Keys, Values: state.Values}
+}
+
+// readChunkedSnapshot return the meta and the chunks of a chunked snapshot, decoding the chunks in parallel
+// onDecoded is called after every chunk decoded, reading stops once ctx is done
+func (l *Linear) readChunkedSnapshot(ctx context.Context, r io.Reader, onDecoded func(RestoreProgress)) (*snapshotMeta, []*snapshotChunk, error) {
+
+	payload, err := readFrame(r)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var meta snapshotMeta
+	if err := gob.NewDecoder(bytes.NewReader(payload)).Decode(&meta); err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", ErrCorrupted, err)
+	}
+
+	// Chunks hold snapshotChunkKeys occurrences but the last, the counts are checked without overflow
+	if meta.Chunks < 0 || meta.Keys < 0 || meta.Chunks != (meta.Keys+snapshotChunkKeys-1)/snapshotChunkKeys {
+		return nil, nil, fmt.Errorf("%w: bad chunk count", ErrCorrupted)
+	}
+
+	type decoded struct {
+		chunk *snapshotChunk
+		err   error
+	}
+
+	frames := make(chan []byte)
+	results := make(chan decoded)
+	var workers sync.WaitGroup
+	for i := 0; i < l.restoreWorkersOrDefault(); i++ {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			for payload := range frames {
+				var chunk snapshotChunk
+				if err := gob.NewDecoder(bytes.NewReader(payload)).Decode(&chunk); err != nil {
+					results <- decoded{err: fmt.Errorf("%w: %v", ErrCorrupted, err)}
+					continue
+				}
+				results <- decoded{chunk: &chunk, err: l.decodeChunk(&chunk)}
+			}
+		}()
+	}
+
+	// Frames are read in order while the workers decode the previous ones
+	var readErr error
+	go func() {
+		defer func() {
+			close(frames)
+			workers.Wait()
+			close(results)
+		}()
+		for i := 0; i < meta.Chunks; i++ {
+			payload, err := readFrame(r)
+			if err != nil {
+				readErr = err
+				return
+			}
+
+			select {
+			case frames <- payload:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	// The chunks grow with the frames read, not with the counts of the meta frame
+	var chunks []*snapshotChunk
+	progress := RestoreProgress{Total: meta.Keys}
+	for result := range results {
+		switch {
+		case err != nil:
+		case result.err != nil:
+			err = result.err
+		case result.chunk.Index < 0 || result.chunk.Index >= meta.Chunks:
+			err = fmt.Errorf("%w: bad chunk index", ErrCorrupted)
+		case result.chunk.Index < len(chunks) && chunks[result.chunk.Index] != nil:
+			err = fmt.Errorf("%w: bad chunk index", ErrCorrupted)
+		default:
+			for len(chunks) <= result.chunk.Index {
+				chunks = append(chunks, nil)
+			}
+			chunks[result.chunk.Index] = result.chunk
+			progress.Decoded += len(result.chunk.Keys)
+			l.reportRestore(progress)
+			onDecoded(progress)
+			err = ctx.Err()
+		}
+	}
+
+	if err != nil {
+		return nil, nil, err
+	}
+	if readErr != nil {
+		return nil, nil, readErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	if progress.Decoded != meta.Keys || len(chunks) != meta.Chunks {
+		return nil, nil, fmt.Errorf("%w: key count mismatch", ErrCorrupted)
+	}
+
+	// Keys without a value in their chunk must have one in an earlier chunk
+	for i, chunk := range chunks {
+		for _, key := range chunk.Keys {
+			if _, ok := chunk.Values[key]; ok {
+				continue
+			}
+
+			found := false
+			for j := i - 1; j >= 0 && !found; j-- {
+				_, found = chunks[j].Values[key]
+			}
+			if !found {
+				return nil, nil, fmt.Errorf("%w: key without value", ErrCorrupted)
+			}
+		}
+	}
+
+	return &meta, chunks, nil
+}
+
+// checkReferences check the references and the shared groups of meta name keys of the chunks
+func checkReferences(meta *snapshotMeta, chunks []*snapshotChunk) error {
+
+	keys := map[string]bool{}
+	for _, chunk := range chunks {
+		for _, key := range chunk.Keys {
+			keys[key] = true
+		}
+	}
+
+	for key := range meta.Refs {
+		if !keys[key] {
+			return fmt.Errorf("%w: reference count of a missing key", ErrCorrupted)
+		}
+	}
+
+	for _, group := range meta.Shared {
+		for _, key := range group {
+			if !keys[key] {
+				return fmt.Errorf("%w: shared group with a missing key", ErrCorrupted)
+			}
+		}
+	}
+
+	return nil
+}
+
+// restoreState replace the items, keys and references with those of state, caller must hold mux
+func (l *Linear) restoreState(state *snapshotState) {
+	meta, chunk := splitState(state)
+	l.restoreChunks(meta, []*snapshotChunk{chunk})
+}
+
+// restoreChunks replace the items, keys and references with those of the chunks, caller must hold mux
+// The values of the chunks are stored and measured in parallel, the keys are linked in chunk order
+func (l *Linear) restoreChunks(meta *snapshotMeta, chunks []*snapshotChunk) {
+
+	for key := range l.expiries {
+		l.cancelExpiry(key)
+	}
+	l.checksumReset()
+	l.debugReset()
+	l.resetSpill()
+
+	// Borrows taken before the restore release nothing of the restored keys
+	l.borrowed = nil
+	l.borrowEpoch++
+
+	l.items.Range(func(key, value interface{}) bool {
+		l.evictionRemoved(key.(string))
+		l.items.Delete(key)
+		return true
+	})
+
+	next := make(chan *snapshotChunk)
+	var workers sync.WaitGroup
+	for i := 0; i < l.restoreWorkersOrDefault(); i++ {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			for chunk := range next {
+				chunk.sizes = make(map[string]int64, len(chunk.Values))
+				for key, value := range chunk.Values {
+					l.items.Store(key, value)
+					chunk.sizes[key] = l.valueSize(key, value)
+					l.debugTrack(key, value)
+					l.checksumTrack(key, value)
+				}
+			}
+		}()
+	}
+
+	go func() {
+		for _, chunk := range chunks {
+			next <- chunk
+		}
+		close(next)
+	}()
+
+	l.keys = newKeyList(meta.Keys, l.growthPolicy)
+	if l.priorities != nil {
+		l.priorities.reset()
+	}
+
+	progress := RestoreProgress{Decoded: meta.Keys, Total: meta.Keys}
+	restored := time.Now().UnixNano()
+	for _, chunk := range chunks {
+		for _, key := range chunk.Keys {
+			n := l.keys.pushBack(key)
+			n.pushed = restored
+			l.priorityPushed(n)
+			l.evictionPushed(key)
+		}
+		progress.Restored += len(chunk.Keys)
+		l.reportRestore(progress)
+	}
+	workers.Wait()
+
+	l.valueSizes = make(map[string]int64, len(l.keys.index))
+	for _, chunk := range chunks {
+		for key, size := range chunk.sizes {
+			l.valueSizes[key] = size
+		}
+	}
+
+	l.refs = make(map[string]int, len(meta.Refs))
+	for key, refs := range meta.Refs {
+		l.refs[key] = refs
+	}
+
+	l.shared = map[string]*int{}
+	for _, keys := range meta.Shared {
+		group := new(int)
+		*group = len(keys)
+		for _, key := range keys {
+			l.shared[key] = group
+		}
+	}
+
+	if l.expiryClock == WallClock {
+		for key, expiry := range meta.Expiries {
+			if l.keys.contains(key) {
+				l.expireAt(key, time.Unix(0, expiry.At), expiry.TTL)
+			}
+		}
+	}
+}
+
+// restoreWorkersOrDefault return the number of goroutines restoring a snapshot
+func (l *Linear) restoreWorkersOrDefault() int {
+	if l.restoreWorkers > 0 {
+		return l.restoreWorkers
+	}
+	return runtime.GOMAXPROCS(0)
+}
+
+// reportRestore pass progress to the WithRestoreProgress callback
+func (l *Linear) reportRestore(progress RestoreProgress) {
+	if l.restoreProgress != nil {
+		l.restoreProgress(progress)
+	}
+}
@@ -0,0 +1,233 @@
+package linear
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSnapshotRestore(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	source := New(1024, false)
+	source.Push("1", "a")
+	source.Push("2", 2)
+	source.Push("1", "c")
+	source.Alias("3", "2")
+	contentKey, _ := source.PushContent("d")
+	source.PushContent("d")
+
+	var buf bytes.Buffer
+	if err := source.Snapshot(&buf); err != nil {
+		t.Errorf("Snapshot failed, expected %v, got %v", nil, err)
+	}
+
+	// Testing
+	linearClient := New(1024, false)
+	linearClient.Push("4", "e")
+
+	if err := linearClient.Restore(bytes.NewReader(buf.Bytes())); err != nil {
+		t.Errorf("Restore failed, expected %v, got %v", nil, err)
+	}
+
+	assert.Equal(linearClient.Getkeys(), source.Getkeys())
+	assert.Equal(linearClient.GetLinearCurrentSize(), source.GetLinearCurrentSize())
+	assert.Equal(linearClient.GetContentRefs(contentKey), 2)
+	assert.Nil(linearClient.CheckSize())
+
+	value, _ := linearClient.Read("3")
+	assert.Equal(value, 2)
+
+	value, _ = linearClient.Read("4")
+	assert.Nil(value)
+}
+
+func TestRestoreCorrupted(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	source := New(1024, false)
+	source.Push("1", "a")
+
+	var buf bytes.Buffer
+	source.Snapshot(&buf)
+
+	linearClient := New(1024, false)
+	linearClient.Push("2", "b")
+
+	// Testing
+	corrupted := append([]byte{}, buf.Bytes()...)
+	corrupted[len(corrupted)-1] ^= 0xff
+	assert.NotNil(linearClient.Restore(bytes.NewReader(corrupted)))
+	assert.NotNil(linearClient.Restore(bytes.NewReader(buf.Bytes()[:buf.Len()-1])))
+	assert.NotNil(linearClient.Restore(bytes.NewReader([]byte("garbage"))))
+
+	assert.Equal(linearClient.Getkeys(), []string{"2"})
+}
+
+func TestRestoreChunked(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	source := New(1<<30, false)
+	for i := 0; i < 3*snapshotChunkKeys+10; i++ {
+		source.Push(strconv.Itoa(i%(2*snapshotChunkKeys)), i)
+	}
+
+	var buf bytes.Buffer
+	assert.Nil(source.Snapshot(&buf))
+
+	var reports []RestoreProgress
+	linearClient, _ := NewWithOptions(WithRestoreWorkers(3), WithRestoreProgress(func(progress RestoreProgress) {
+		reports = append(reports, progress)
+	}))
+
+	// Testing
+	assert.Nil(linearClient.Restore(bytes.NewReader(buf.Bytes())))
+	assert.Equal(source.Getkeys(), linearClient.Getkeys())
+	assert.Equal(source.GetItemsMap(), linearClient.GetItemsMap())
+	assert.Equal(source.GetLinearCurrentSize(), linearClient.GetLinearCurrentSize())
+	assert.Nil(linearClient.CheckSize())
+
+	last := reports[len(reports)-1]
+	expected := RestoreProgress{Decoded: source.GetNumberOfKeys(), Restored: source.GetNumberOfKeys(), Total: source.GetNumberOfKeys()}
+	if last != expected {
+		t.Errorf("Restore failed, expected %v, got %v", expected, last)
+	}
+	assert.Len(reports, 8)
+
+	// A repeated chunk is detected
+	state := source.captureState()
+	chunks := []snapshotChunk{{Index: 0}, {Index: 1}, {Index: 1}}
+	var broken bytes.Buffer
+	broken.Write(snapshotMagic)
+	broken.WriteByte(snapshotChunkedVersion)
+	writeFrame(&broken, &snapshotMeta{Keys: 0, Chunks: len(chunks), Seq: state.Seq})
+	for i := range chunks {
+		writeFrame(&broken, &chunks[i])
+	}
+	err := linearClient.Restore(bytes.NewReader(broken.Bytes()))
+	assert.True(errors.Is(err, ErrCorrupted))
+	assert.Equal(source.Getkeys(), linearClient.Getkeys())
+}
+
+func TestRestoreSingleFrame(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	source := New(1024, false)
+	source.Push("1", "a")
+	source.Push("2", "b")
+	source.Alias("3", "1")
+
+	var buf bytes.Buffer
+	buf.Write(snapshotMagic)
+	buf.WriteByte(snapshotVersion)
+	assert.Nil(writeFrame(&buf, source.captureState()))
+
+	// Testing
+	linearClient := New(1024, false)
+	assert.Nil(linearClient.Restore(&buf))
+	assert.Equal(source.Getkeys(), linearClient.Getkeys())
+	assert.Equal(source.GetLinearCurrentSize(), linearClient.GetLinearCurrentSize())
+}
+
+func TestRestoreContext(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	source := New(1<<30, false)
+	for i := 0; i < 4*snapshotChunkKeys; i++ {
+		source.Push(strconv.Itoa(i), i)
+	}
+
+	var buf bytes.Buffer
+	source.Snapshot(&buf)
+
+	linearClient, _ := NewWithOptions(WithRestoreWorkers(1))
+	linearClient.Push("kept", 1)
+
+	// Testing
+	ctx, cancel := context.WithCancel(context.Background())
+	err := linearClient.RestoreContext(ctx, bytes.NewReader(buf.Bytes()), func(done, total int, bytes int64) {
+		cancel()
+	})
+	assert.True(errors.Is(err, context.Canceled))
+	assert.Equal([]string{"kept"}, linearClient.Getkeys())
+
+	var lastDone int
+	var lastBytes int64
+	err = linearClient.RestoreContext(context.Background(), bytes.NewReader(buf.Bytes()), func(done, total int, bytes int64) {
+		lastDone, lastBytes = done, bytes
+	})
+	assert.Nil(err)
+	assert.Equal(4*snapshotChunkKeys, lastDone)
+	if lastBytes != int64(buf.Len()) {
+		t.Errorf("RestoreContext failed, expected %v, got %v", buf.Len(), lastBytes)
+	}
+}
+
+// craftedSnapshot return a chunked snapshot made of the meta frame and the chunk frames
+func craftedSnapshot(meta snapshotMeta, chunks ...snapshotChunk) *bytes.Buffer {
+	var buf bytes.Buffer
+	buf.Write(append(append([]byte{}, snapshotMagic...), snapshotChunkedVersion))
+	writeFrame(&buf, &meta)
+	for i := range chunks {
+		writeFrame(&buf, &chunks[i])
+	}
+	return &buf
+}
+
+func TestRestoreCraftedMeta(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	linearClient := New(1024, false)
+	linearClient.Push("1", "a")
+
+	// Testing
+	chunk := snapshotChunk{Keys: []string{"2"}, Values: map[string]interface{}{"2": "b"}}
+	snapshots := []*bytes.Buffer{
+		craftedSnapshot(snapshotMeta{Keys: 1 << 40, Chunks: 1 << 40}),
+		craftedSnapshot(snapshotMeta{Keys: 1, Chunks: 1 << 62}),
+		craftedSnapshot(snapshotMeta{Keys: 1, Chunks: 1, Refs: map[string]int{"3": 1}}, chunk),
+		craftedSnapshot(snapshotMeta{Keys: 1, Chunks: 1, Shared: [][]string{{"2", "3"}}}, chunk),
+	}
+	for _, snapshot := range snapshots {
+		err := linearClient.Restore(snapshot)
+		if !errors.Is(err, ErrCorrupted) {
+			t.Errorf("Restore failed, expected %v, got %v", ErrCorrupted, err)
+		}
+	}
+	assert.Equal([]string{"1"}, linearClient.Getkeys())
+
+	assert.Nil(linearClient.Restore(craftedSnapshot(snapshotMeta{Keys: 1, Chunks: 1}, chunk)))
+	assert.Equal([]string{"2"}, linearClient.Getkeys())
+}
+
+func TestRestoreBorrowed(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	source, _ := NewWithOptions(WithMaxItems(1))
+	source.PushBytes("1", []byte("a"))
+	var snapshot bytes.Buffer
+	assert.Nil(source.Snapshot(&snapshot))
+
+	linearClient, _ := NewWithOptions(WithMaxItems(1))
+	linearClient.PushBytes("1", []byte("old"))
+	_, release, _ := linearClient.Borrow("1")
+
+	// Testing
+	assert.Nil(linearClient.Restore(&snapshot))
+	release()
+
+	// The restored key isn't borrowed, so it is evicted
+	assert.Nil(linearClient.PushBytes("2", []byte("b")))
+	assert.Equal([]string{"2"}, linearClient.Getkeys())
+}
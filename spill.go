@@ -0,0 +1,381 @@
+package linear
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"sync/atomic"
+	"time"
+)
+
+// spillFilePattern name the files of the spillover tier in their directory, so instances can share it
+const spillFilePattern = "linear-*.spill"
+
+// spillHeaderSize is the checksum and length written before every spilled record
+const spillHeaderSize = 8
+
+// spillCompactBytes is the dead bytes the spill file must hold before it is rewritten
+const spillCompactBytes = 1 << 20
+
+// SpillStats is a point in time copy of the spillover tier counters
+type SpillStats struct {
+	Items int   // Items on disk
+	Bytes int64 // Bytes of the items on disk, counted against maxDiskBytes
+}
+
+// spillRecord is an item written to the spill file
+type spillRecord struct {
+	key    string
+	offset int64
+	length int64 // Bytes of the record in the file, header included
+	size   int64 // Bytes the item accounted for in memory
+	pushed int64
+}
+
+// spillTier hold the items spilled out of memory, oldest first, it is guarded by the linear mux
+type spillTier struct {
+	dir      string
+	maxBytes int64
+	file     *os.File
+	end      int64 // Length of the file
+	records  []*spillRecord
+	keys     map[string][]*spillRecord // Records of every key, oldest first
+	bytes    int64
+	items    int64 // Accessed atomically, so Take and Pop can check for spilled items without the lock
+}
+
+// WithSpillover write the items the EvictOldest policy removes to make room to a file in dir instead of dropping them,
+// up to maxDiskBytes.
+// Read, Get, Update and Delete find spilled keys, Take serves the spilled items before the ones in memory and Pop after them.
+// Spilled items are not part of Getkeys, the counters and the other views of the linear. Once the disk is full too,
+// the oldest spilled items are evicted. Every instance creates its own file in dir on startup and removes it on Close,
+// values are encoded by the WithCodec codec.
+// Snapshots and the append log leave spilled items out, so it can't be combined with WithPersistence, Open or WithAppendLog
+func WithSpillover(dir string, maxDiskBytes int64) Option {
+	return func(l *Linear) {
+		l.spill = &spillTier{dir: dir, maxBytes: maxDiskBytes}
+	}
+}
+
+// openSpill create the spill file
+func (l *Linear) openSpill() error {
+
+	file, err := os.CreateTemp(l.spill.dir, spillFilePattern)
+	if err != nil {
+		return err
+	}
+
+	l.spill.file = file
+	l.spill.keys = map[string][]*spillRecord{}
+
+	return nil
+}
+
+// closeSpill close and remove the spill file
+func (l *Linear) closeSpill() error {
+
+	l.mux.Lock()
+	defer l.mux.Unlock()
+
+	err := l.spill.file.Close()
+	if removeErr := os.Remove(l.spill.file.Name()); err == nil {
+		err = removeErr
+	}
+
+	return err
+}
+
+// SpillStats return the counters of the spillover tier
+func (l *Linear) SpillStats() SpillStats {
+
+	l.mux.RLock()
+	defer l.mux.RUnlock()
+
+	if l.spill == nil {
+		return SpillStats{}
+	}
+
+	return SpillStats{Items: len(l.spill.records), Bytes: l.spill.bytes}
+}
+
+// spillNode write n to the spill file and remove it from memory, caller must hold mux
+func (l *Linear) spillNode(n *node) error {
+
+	key, pushed := n.key, n.pushed
+	item, _ := l.items.Load(key)
+	payload, err := l.encodeSpillRecord(key, item)
+	if err != nil {
+		return err
+	}
+
+	length := int64(len(payload))
+	if length > l.spill.maxBytes {
+		return fmt.Errorf("record of %d bytes over the spill limit", length)
+	}
+
+	// Evict the oldest spilled items until the record fits
+	for l.spill.bytes+length > l.spill.maxBytes {
+		record := l.spill.records[0]
+		evicted, err := l.readSpillRecord(record)
+		l.dropSpillRecord(record)
+		if err != nil {
+			l.logger.Printf("linear: reading the spilled %q failed: %v", record.key, err)
+		}
+		l.emit(Evicted, record.key, evicted)
+		atomic.AddInt64(&l.stats.evictions, 1)
+	}
+
+	if _, err := l.spill.file.WriteAt(payload, l.spill.end); err != nil {
+		return err
+	}
+
+	record := &spillRecord{key: key, offset: l.spill.end, length: length, size: calculateKeySize(key) + l.valueSizes[key], pushed: pushed}
+	l.spill.end += length
+	l.spill.bytes += length
+	l.spill.records = append(l.spill.records, record)
+	l.spill.keys[key] = append(l.spill.keys[key], record)
+	atomic.AddInt64(&l.spill.items, 1)
+
+	l.removeNode(n, item)
+
+	return nil
+}
+
+// readSpillRecord return the value of a spilled record, caller must hold mux
+func (l *Linear) readSpillRecord(record *spillRecord) (interface{}, error) {
+
+	payload := make([]byte, record.length)
+	if _, err := l.spill.file.ReadAt(payload, record.offset); err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+
+	body := payload[spillHeaderSize:]
+	if int64(binary.BigEndian.Uint32(payload)) != int64(len(body)) || crc32.ChecksumIEEE(body) != binary.BigEndian.Uint32(payload[4:]) {
+		return nil, fmt.Errorf("%w: spilled record of %q", ErrCorrupted, record.key)
+	}
+
+	keyLength, n := binary.Uvarint(body)
+	if n <= 0 || uint64(len(body)-n) < keyLength {
+		return nil, fmt.Errorf("%w: spilled record of %q", ErrCorrupted, record.key)
+	}
+
+	return l.decodeValue(record.key, body[n+int(keyLength):])
+}
+
+// dropSpillRecord remove record from the spilled records, caller must hold mux
+func (l *Linear) dropSpillRecord(record *spillRecord) {
+
+	l.spill.records = removeRecord(l.spill.records, record)
+	l.spill.keys[record.key] = removeRecord(l.spill.keys[record.key], record)
+	if len(l.spill.keys[record.key]) == 0 {
+		delete(l.spill.keys, record.key)
+	}
+	l.spill.bytes -= record.length
+	atomic.AddInt64(&l.spill.items, -1)
+
+	l.compactSpill()
+}
+
+// removeRecord remove record from records, the ends are the common case so they are checked first
+func removeRecord(records []*spillRecord, record *spillRecord) []*spillRecord {
+
+	last := len(records) - 1
+	switch {
+	case records[0] == record:
+		records[0] = nil
+		return records[1:]
+	case records[last] == record:
+		records[last] = nil
+		return records[:last]
+	}
+
+	for i, r := range records {
+		if r == record {
+			copy(records[i:], records[i+1:])
+			records[last] = nil
+			return records[:last]
+		}
+	}
+
+	return records
+}
+
+// encodeSpillRecord return the payload of a spilled record of the key, header included
+func (l *Linear) encodeSpillRecord(key string, item interface{}) ([]byte, error) {
+
+	value, err := l.encodeValue(key, item)
+	if err != nil {
+		return nil, err
+	}
+
+	keyLength := make([]byte, binary.MaxVarintLen64)
+	keyLength = keyLength[:binary.PutUvarint(keyLength, uint64(len(key)))]
+	payload := make([]byte, spillHeaderSize, spillHeaderSize+len(keyLength)+len(key)+len(value))
+	payload = append(append(append(payload, keyLength...), key...), value...)
+	binary.BigEndian.PutUint32(payload, uint32(len(payload)-spillHeaderSize))
+	binary.BigEndian.PutUint32(payload[4:], crc32.ChecksumIEEE(payload[spillHeaderSize:]))
+
+	return payload, nil
+}
+
+// compactSpill empty the spill file once it holds no record, and rewrite it once it holds mostly dead bytes, caller must hold mux
+func (l *Linear) compactSpill() {
+
+	if len(l.spill.records) == 0 {
+		l.spill.records = nil
+		l.spill.end = 0
+		if err := l.spill.file.Truncate(0); err != nil {
+			l.logger.Printf("linear: truncating the spill file failed: %v", err)
+		}
+		return
+	}
+
+	dead := l.spill.end - l.spill.bytes
+	if dead < spillCompactBytes || dead < l.spill.bytes {
+		return
+	}
+
+	// Records are moved to the front of the file in order, so a write never overwrites a record not moved yet
+	var offset int64
+	for _, record := range l.spill.records {
+		payload := make([]byte, record.length)
+		if _, err := l.spill.file.ReadAt(payload, record.offset); err != nil {
+			l.logger.Printf("linear: compacting the spill file failed: %v", err)
+			return
+		}
+		if _, err := l.spill.file.WriteAt(payload, offset); err != nil {
+			l.logger.Printf("linear: compacting the spill file failed: %v", err)
+			return
+		}
+		record.offset = offset
+		offset += record.length
+	}
+
+	l.spill.end = offset
+	if err := l.spill.file.Truncate(offset); err != nil {
+		l.logger.Printf("linear: truncating the spill file failed: %v", err)
+	}
+}
+
+// hasSpilled check if items are on disk
+func (l *Linear) hasSpilled() bool {
+	return l.spill != nil && atomic.LoadInt64(&l.spill.items) > 0
+}
+
+// readSpilled return the value of the newest spilled record of the key
+func (l *Linear) readSpilled(key string) (interface{}, bool) {
+
+	// Execution conditions
+	if !l.hasSpilled() {
+		return nil, false
+	}
+
+	l.mux.RLock()
+	defer l.mux.RUnlock()
+
+	records := l.spill.keys[key]
+	if len(records) == 0 {
+		return nil, false
+	}
+
+	value, err := l.readSpillRecord(records[len(records)-1])
+	if err != nil {
+		l.logger.Printf("linear: reading the spilled %q failed: %v", key, err)
+		return nil, false
+	}
+
+	return value, true
+}
+
+// takeSpilled remove and return the oldest spilled item, or the newest when front is false, caller must hold mux
+func (l *Linear) takeSpilled(front bool) (Entry, error) {
+
+	if len(l.spill.records) == 0 {
+		return Entry{}, ErrEmpty
+	}
+
+	record := l.spill.records[len(l.spill.records)-1]
+	if front {
+		record = l.spill.records[0]
+	}
+
+	return l.takeSpillRecord(record)
+}
+
+// takeSpillRecord remove and return the item of record, caller must hold mux
+func (l *Linear) takeSpillRecord(record *spillRecord) (Entry, error) {
+
+	value, err := l.readSpillRecord(record)
+	l.dropSpillRecord(record)
+	if err != nil {
+		return Entry{}, newError("take", record.key, err)
+	}
+
+	return Entry{Key: record.key, Value: value, Size: record.size, PushedAt: time.Unix(0, record.pushed)}, nil
+}
+
+// deleteSpilled remove every spilled record of the key and return the value of the newest one, caller must hold mux
+func (l *Linear) deleteSpilled(key string) (interface{}, bool) {
+
+	if l.spill == nil || len(l.spill.keys[key]) == 0 {
+		return nil, false
+	}
+
+	var value interface{}
+	for records := l.spill.keys[key]; len(records) > 0; records = l.spill.keys[key] {
+		entry, err := l.takeSpillRecord(records[len(records)-1])
+		if err != nil {
+			l.logger.Printf("linear: reading the spilled %q failed: %v", key, err)
+		} else if value == nil {
+			value = entry.Value
+		}
+	}
+
+	return value, true
+}
+
+// updateSpilled rewrite every spilled record of the key with value, caller must hold mux
+func (l *Linear) updateSpilled(key string, value interface{}, newValueSize int64) error {
+
+	records := l.spill.keys[key]
+	payload, err := l.encodeSpillRecord(key, value)
+	if err != nil {
+		return newError("update", key, err)
+	}
+
+	length := int64(len(payload))
+	bytes := l.spill.bytes
+	for _, record := range records {
+		bytes += length - record.length
+	}
+	if bytes > l.spill.maxBytes {
+		return newError("update", key, ErrCapacityExceeded)
+	}
+
+	// The new records are appended, the old ones become dead bytes for compactSpill
+	for _, record := range records {
+		if _, err := l.spill.file.WriteAt(payload, l.spill.end); err != nil {
+			return newError("update", key, err)
+		}
+		l.spill.bytes += length - record.length
+		record.offset, record.length, record.size = l.spill.end, length, calculateKeySize(key)+newValueSize
+		l.spill.end += length
+	}
+	l.compactSpill()
+
+	l.emit(Updated, key, value)
+
+	return nil
+}
+
+// resetSpill drop every spilled item, caller must hold mux
+func (l *Linear) resetSpill() {
+	if l.spill != nil && l.spill.file != nil {
+		for len(l.spill.records) > 0 {
+			l.dropSpillRecord(l.spill.records[0])
+		}
+	}
+}
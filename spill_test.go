@@ -0,0 +1,200 @@
+package linear
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithSpillover(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	dir := t.TempDir()
+	_, err := NewWithOptions(WithSpillover(dir, 0))
+	assert.True(errors.Is(err, ErrInvalidArgument))
+
+	linearClient, err := NewWithOptions(WithMaxItems(2), WithSpillover(dir, 1024))
+	assert.Nil(err)
+
+	// Testing
+	for _, key := range []string{"1", "2", "3", "4"} {
+		assert.Nil(linearClient.Push(key, key+key))
+	}
+	assert.Equal([]string{"3", "4"}, linearClient.Getkeys())
+	stats := linearClient.SpillStats()
+	assert.Equal(2, stats.Items)
+	assert.True(stats.Bytes > 0)
+	assert.Equal(int64(0), linearClient.Stats().Evictions)
+
+	// Spilled keys are read from disk without leaving it
+	value, err := linearClient.Read("1")
+	assert.Nil(err)
+	assert.Equal("11", value)
+	assert.Equal(2, linearClient.SpillStats().Items)
+
+	// Take pages the oldest spilled items back in before the ones in memory
+	entry, err := linearClient.TakeEntry()
+	assert.Nil(err)
+	assert.Equal("1", entry.Key)
+	assert.Equal("11", entry.Value)
+	assert.Equal(calculateItemSize("1", "11"), entry.Size)
+
+	value, _ = linearClient.Pop()
+	assert.Equal("44", value)
+	value, _ = linearClient.Pop()
+	assert.Equal("33", value)
+
+	// Pop reaches the spilled items once the memory is empty
+	value, err = linearClient.Pop()
+	assert.Nil(err)
+	assert.Equal("22", value)
+	_, err = linearClient.Take()
+	assert.True(errors.Is(err, ErrEmpty))
+	assert.Equal(SpillStats{}, linearClient.SpillStats())
+
+	path := linearClient.spill.file.Name()
+	assert.Equal(dir, filepath.Dir(path))
+	info, err := os.Stat(path)
+	assert.Nil(err)
+	assert.Equal(int64(0), info.Size())
+
+	assert.Nil(linearClient.Close())
+	_, err = os.Stat(path)
+	assert.True(os.IsNotExist(err))
+}
+
+func TestWithSpilloverDiskFull(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	linearClient, _ := NewWithOptions(WithMaxItems(1), WithSpillover(t.TempDir(), 40))
+	defer linearClient.Close()
+
+	// Testing
+	// A record takes 8 bytes of header, 1 of key length, 1 of key and the gob value
+	for _, key := range []string{"1", "2", "3", "4"} {
+		assert.Nil(linearClient.Push(key, 1))
+	}
+	stats := linearClient.SpillStats()
+	assert.True(stats.Items < 3)
+	assert.True(stats.Bytes <= 40)
+	assert.Equal(int64(3-stats.Items), linearClient.Stats().Evictions)
+
+	_, err := linearClient.Read("1")
+	assert.True(errors.Is(err, ErrKeyNotFound))
+	value, _ := linearClient.Read("3")
+	assert.Equal(1, value)
+
+	// Clear drops the spilled items too
+	assert.Nil(linearClient.Clear())
+	assert.Equal(SpillStats{}, linearClient.SpillStats())
+	_, err = linearClient.Read("3")
+	assert.True(errors.Is(err, ErrEmpty))
+}
+
+func TestWithSpilloverSharedDir(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	dir := t.TempDir()
+	first, err := NewWithOptions(WithMaxItems(1), WithSpillover(dir, 1024))
+	assert.Nil(err)
+	second, err := NewWithOptions(WithMaxItems(1), WithSpillover(dir, 1024))
+	assert.Nil(err)
+	defer second.Close()
+
+	// Testing
+	assert.NotEqual(first.spill.file.Name(), second.spill.file.Name())
+	for _, key := range []string{"1", "2", "3"} {
+		assert.Nil(first.Push(key, "first"+key))
+		assert.Nil(second.Push(key, "second"+key))
+	}
+
+	value, err := first.Read("1")
+	assert.Nil(err)
+	assert.Equal("first1", value)
+
+	// Closing one instance leaves the spilled items of the other
+	assert.Nil(first.Close())
+	value, err = second.Take()
+	assert.Nil(err)
+	assert.Equal("second1", value)
+	value, err = second.Take()
+	assert.Nil(err)
+	assert.Equal("second2", value)
+}
+
+func TestSpillCompaction(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	linearClient, _ := NewWithOptions(WithMaxItems(1), WithSpillover(t.TempDir(), 1<<30))
+	defer linearClient.Close()
+
+	value := make([]byte, 10<<10)
+	for i := 0; i < 200; i++ {
+		value[0] = byte(i)
+		linearClient.Push(string(rune('a'+i)), append([]byte(nil), value...))
+	}
+
+	// Testing
+	// Taking most of the spilled items leaves mostly dead bytes, so the file is rewritten
+	for i := 0; i < 150; i++ {
+		linearClient.Take()
+	}
+	info, _ := os.Stat(linearClient.spill.file.Name())
+	if dead := info.Size() - linearClient.SpillStats().Bytes; dead >= spillCompactBytes {
+		t.Errorf("compactSpill failed, expected %v, got %v", "less than spillCompactBytes dead bytes", dead)
+	}
+
+	for i := 150; i < 200; i++ {
+		entry, err := linearClient.TakeEntry()
+		assert.Nil(err)
+		assert.Equal(string(rune('a'+i)), entry.Key)
+		assert.Equal(byte(i), entry.Value.([]byte)[0])
+	}
+}
+
+func TestSpilledKeys(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	dir := t.TempDir()
+	_, err := NewWithOptions(WithSpillover(dir, 1024), WithPersistence(filepath.Join(dir, "snapshot"), 0))
+	assert.True(errors.Is(err, ErrInvalidArgument))
+	_, err = NewWithOptions(WithSpillover(dir, 1024), WithAppendLog(filepath.Join(dir, "log"), 0))
+	assert.True(errors.Is(err, ErrInvalidArgument))
+
+	linearClient, _ := NewWithOptions(WithMaxItems(1), WithSpillover(dir, 1024))
+	defer linearClient.Close()
+	for _, key := range []string{"1", "2", "3", "4"} {
+		linearClient.Push(key, key+key)
+	}
+
+	// Testing
+	assert.Nil(linearClient.Update("2", "two"))
+	value, _ := linearClient.Read("2")
+	assert.Equal("two", value)
+
+	value, err = linearClient.Get("2")
+	assert.Nil(err)
+	assert.Equal("two", value)
+	_, err = linearClient.Read("2")
+	assert.True(errors.Is(err, ErrKeyNotFound))
+
+	assert.Nil(linearClient.Delete("1"))
+	assert.True(errors.Is(linearClient.Delete("1"), ErrKeyNotFound))
+	assert.True(errors.Is(linearClient.Update("1", "one"), ErrKeyNotFound))
+	assert.Equal(1, linearClient.SpillStats().Items)
+
+	// The spilled item left is still served first
+	value, _ = linearClient.Take()
+	assert.Equal("33", value)
+	value, _ = linearClient.Take()
+	assert.Equal("44", value)
+	assert.Equal(SpillStats{}, linearClient.SpillStats())
+}
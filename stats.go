@@ -0,0 +1,98 @@
+package linear
+
+import (
+	"sync/atomic"
+)
+
+// Stats is a point in time copy of the linear counters
+type Stats struct {
+	Hits         int64 // Read and Get calls that found their key
+	Misses       int64 // Read and Get calls that didn't find their key
+	Pushes       int64 // Items stored by Push, Alias and the helpers built on them
+	Updates      int64 // Values replaced by Update
+	Evictions    int64 // Items removed to make room for new ones
+	Expired      int64 // Keys removed once their TTL elapsed
+	CurrentBytes int64
+	PeakBytes    int64
+	CurrentItems int64
+	PeakItems    int64
+
+	DroppedEvents      int64          // Events not sent to a subscriber whose buffer was full
+	ChecksumMismatches int64          // Sampled reads whose value no longer matched its WithChecksums hash
+	LazyDecodes        int64          // WithLazyDecode values decoded on first use
+	LazyHits           int64          // Uses of WithLazyDecode values decoded already
+	ContendedKeys      []ContendedKey // Keys over the WithContentionDetection threshold of the writes
+}
+
+// statsCounters hold the counters behind Stats, they are accessed atomically
+type statsCounters struct {
+	hits               int64
+	misses             int64
+	pushes             int64
+	updates            int64
+	evictions          int64
+	expired            int64
+	droppedEvents      int64
+	checksumMismatches int64
+	lazyDecodes        int64
+	lazyHits           int64
+	peakBytes          int64
+	peakItems          int64
+}
+
+// Stats return the current counters of the linear
+func (l *Linear) Stats() Stats {
+	return Stats{
+		Hits:         atomic.LoadInt64(&l.stats.hits),
+		Misses:       atomic.LoadInt64(&l.stats.misses),
+		Pushes:       atomic.LoadInt64(&l.stats.pushes),
+		Updates:      atomic.LoadInt64(&l.stats.updates),
+		Evictions:    atomic.LoadInt64(&l.stats.evictions),
+		Expired:      atomic.LoadInt64(&l.stats.expired),
+		CurrentBytes: atomic.LoadInt64(&l.approxSize),
+		PeakBytes:    atomic.LoadInt64(&l.stats.peakBytes),
+		CurrentItems: atomic.LoadInt64(&l.approxLen),
+		PeakItems:    atomic.LoadInt64(&l.stats.peakItems),
+
+		DroppedEvents:      atomic.LoadInt64(&l.stats.droppedEvents),
+		ChecksumMismatches: atomic.LoadInt64(&l.stats.checksumMismatches),
+		LazyDecodes:        atomic.LoadInt64(&l.stats.lazyDecodes),
+		LazyHits:           atomic.LoadInt64(&l.stats.lazyHits),
+		ContendedKeys:      l.contendedKeys(),
+	}
+}
+
+// ResetStats zero the counters, the peaks restart from the current bytes and items
+func (l *Linear) ResetStats() {
+	atomic.StoreInt64(&l.stats.hits, 0)
+	atomic.StoreInt64(&l.stats.misses, 0)
+	atomic.StoreInt64(&l.stats.pushes, 0)
+	atomic.StoreInt64(&l.stats.updates, 0)
+	atomic.StoreInt64(&l.stats.evictions, 0)
+	atomic.StoreInt64(&l.stats.expired, 0)
+	atomic.StoreInt64(&l.stats.droppedEvents, 0)
+	atomic.StoreInt64(&l.stats.checksumMismatches, 0)
+	atomic.StoreInt64(&l.stats.lazyDecodes, 0)
+	atomic.StoreInt64(&l.stats.lazyHits, 0)
+
+	if l.contention != nil {
+		l.mux.Lock()
+		l.contention.writes = 0
+		l.contention.counters = make(map[string]int64, l.contention.capacity)
+		l.mux.Unlock()
+	}
+
+	l.mux.RLock()
+	atomic.StoreInt64(&l.stats.peakBytes, l.linearCurrentSize)
+	atomic.StoreInt64(&l.stats.peakItems, int64(l.keys.len))
+	l.mux.RUnlock()
+}
+
+// countLookup count a hit or a miss
+func (l *Linear) countLookup(hit bool) {
+	if hit {
+		atomic.AddInt64(&l.stats.hits, 1)
+	} else {
+		atomic.AddInt64(&l.stats.misses, 1)
+	}
+}
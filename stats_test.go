@@ -0,0 +1,41 @@
+package linear
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStats(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	linearClient, _ := NewWithOptions(WithMaxItems(2))
+	linearClient.Push("1", "a")
+	linearClient.Push("2", "b")
+	linearClient.Push("3", "c")
+	linearClient.Update("3", "d")
+	linearClient.Read("2")
+	linearClient.Read("1")
+	linearClient.Get("3")
+	linearClient.Get("3")
+
+	// Testing
+	stats := linearClient.Stats()
+	assert.Equal(stats.Pushes, int64(3))
+	assert.Equal(stats.Updates, int64(1))
+	assert.Equal(stats.Evictions, int64(1))
+	assert.Equal(stats.Hits, int64(2))
+	assert.Equal(stats.Misses, int64(2))
+	assert.Equal(stats.CurrentItems, int64(1))
+	assert.Equal(stats.PeakItems, int64(2))
+	assert.Equal(stats.CurrentBytes, linearClient.GetLinearCurrentSize())
+	assert.Equal(stats.PeakBytes, 2*calculateItemSize("1", "a"))
+
+	linearClient.ResetStats()
+	stats = linearClient.Stats()
+	assert.Equal(stats.Pushes, int64(0))
+	assert.Equal(stats.Hits, int64(0))
+	assert.Equal(stats.PeakItems, int64(1))
+	assert.Equal(stats.PeakBytes, stats.CurrentBytes)
+}
@@ -0,0 +1,64 @@
+package linear
+
+import (
+	"io"
+)
+
+// PushReader read exactly size bytes from r and push them to the linear as a []byte value
+func (l *Linear) PushReader(key string, r io.Reader, size int64) error {
+
+	// Execution conditions
+	if l.IsClosed() {
+		return ErrClosed
+	}
+
+	// Argument validator
+	if key == "" {
+		return ErrInvalidKey
+	}
+
+	if r == nil {
+		return ErrInvalidArgument
+	}
+
+	if size < 0 {
+		return ErrInvalidArgument
+	}
+
+	if size > l.GetLinearSizes() {
+		return newError("push", key, ErrCapacityExceeded)
+	}
+
+	buf := make([]byte, size)
+	if _, err := io.ReadFull(r, buf); err != nil {
+		return err
+	}
+
+	return l.Push(key, buf)
+}
+
+// ReadTo write the item by key to w without remove it, the item must be a []byte or string
+func (l *Linear) ReadTo(key string, w io.Writer) (int64, error) {
+
+	// Argument validator
+	if w == nil {
+		return 0, ErrInvalidArgument
+	}
+
+	item, err := l.Read(key)
+	if err != nil {
+		return 0, err
+	}
+
+	var n int
+	switch value := item.(type) {
+	case []byte:
+		n, err = w.Write(value)
+	case string:
+		n, err = io.WriteString(w, value)
+	default:
+		return 0, newError("read", key, ErrInvalidValue)
+	}
+
+	return int64(n), err
+}
@@ -0,0 +1,66 @@
+package linear
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPushReader(t *testing.T) {
+	assert := assert.New(t)
+
+	linearClient := New(1024, false)
+
+	// Testing
+	err := linearClient.PushReader("1", strings.NewReader("abcdef"), 3)
+	if err != nil {
+		t.Errorf("PushReader failed, expected %v, got %v", nil, err)
+	}
+
+	value, err := linearClient.Read("1")
+	if err != nil {
+		t.Errorf("PushReader failed, expected %v, got %v", "abc", err)
+	}
+
+	assert.Equal(value, []byte("abc"))
+
+	assert.NotNil(linearClient.PushReader("2", strings.NewReader("a"), 3))
+	assert.NotNil(linearClient.PushReader("3", strings.NewReader("a"), 2048))
+
+	assert.Equal(linearClient.GetNumberOfKeys(), 1)
+}
+
+func TestReadTo(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	linearClient := New(1024, false)
+	linearClient.Push("1", []byte("abc"))
+	linearClient.Push("2", "def")
+	linearClient.Push("3", 1)
+
+	// Testing
+	var buf bytes.Buffer
+	n, err := linearClient.ReadTo("1", &buf)
+	if err != nil {
+		t.Errorf("ReadTo failed, expected %v, got %v", "abc", err)
+	}
+
+	assert.Equal(n, int64(3))
+
+	if _, err := linearClient.ReadTo("2", &buf); err != nil {
+		t.Errorf("ReadTo failed, expected %v, got %v", "def", err)
+	}
+
+	assert.Equal(buf.String(), "abcdef")
+
+	_, err = linearClient.ReadTo("3", &buf)
+	assert.NotNil(err)
+
+	_, err = linearClient.ReadTo("4", &buf)
+	assert.NotNil(err)
+
+	assert.Equal(linearClient.GetNumberOfKeys(), 3)
+}
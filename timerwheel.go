@@ -0,0 +1,171 @@
+package linear
+
+import (
+	"time"
+)
+
+const (
+	wheelBits   = 6
+	wheelSlots  = 1 << wheelBits
+	wheelMask   = wheelSlots - 1
+	wheelLevels = 4
+)
+
+// wheelTimer is a timer of the wheel, it fires exactly once unless cancelled first
+type wheelTimer struct {
+	key        string
+	value      interface{} // Value of a delayed push
+	valueSize  int64
+	expire     bool          // Expire the key instead of pushing value
+	at         time.Time     // Deadline of an expiry
+	ttl        time.Duration // TTL restored by sliding expiration
+	due        uint64
+	level      int
+	slot       int
+	prev, next *wheelTimer
+	scheduled  bool
+}
+
+// timerWheel is a hierarchical timer wheel, every level has wheelSlots slots
+// covering wheelSlots times the span of a slot of the level below it
+type timerWheel struct {
+	tick      time.Duration
+	start     time.Time
+	current   uint64
+	slots     [wheelLevels][wheelSlots]*wheelTimer
+	pending   int
+	scheduled int64
+	fired     int64
+	cancelled int64
+	cascades  int64
+}
+
+// newTimerWheel return an empty wheel whose ticks start at start
+func newTimerWheel(tick time.Duration, start time.Time) *timerWheel {
+	return &timerWheel{tick: tick, start: start}
+}
+
+// ticks return the number of ticks from the start of the wheel to at
+func (w *timerWheel) ticks(at time.Time) uint64 {
+	if !at.After(w.start) {
+		return 0
+	}
+	return uint64(at.Sub(w.start) / w.tick)
+}
+
+// schedule add t to the wheel due at at, times already past fire on the next tick
+func (w *timerWheel) schedule(t *wheelTimer, at time.Time) {
+	due := w.ticks(at)
+	if at.Sub(w.start)%w.tick != 0 {
+		due++ // Never fire early
+	}
+	if due <= w.current {
+		due = w.current + 1
+	}
+	t.due = due
+	w.place(t)
+	w.pending++
+	w.scheduled++
+}
+
+// place link t into the slot matching its due tick
+func (w *timerWheel) place(t *wheelTimer) {
+
+	delta := uint64(0)
+	if t.due > w.current {
+		delta = t.due - w.current
+	}
+
+	level := 0
+	for level < wheelLevels-1 && delta >= 1<<(wheelBits*uint(level+1)) {
+		level++
+	}
+
+	due := t.due
+	if limit := w.current + 1<<(wheelBits*wheelLevels) - 1; due > limit {
+		due = limit // Beyond the wheel, cascaded again until it fits
+	}
+
+	t.level = level
+	t.slot = int(due>>(wheelBits*uint(level))) & wheelMask
+	t.prev, t.next = nil, w.slots[level][t.slot]
+	if t.next != nil {
+		t.next.prev = t
+	}
+	w.slots[level][t.slot] = t
+	t.scheduled = true
+}
+
+// unlink remove t from its slot
+func (w *timerWheel) unlink(t *wheelTimer) {
+	if t.prev != nil {
+		t.prev.next = t.next
+	} else {
+		w.slots[t.level][t.slot] = t.next
+	}
+	if t.next != nil {
+		t.next.prev = t.prev
+	}
+	t.prev, t.next = nil, nil
+	t.scheduled = false
+}
+
+// cancel remove t from the wheel so it never fires and report if it was still scheduled
+func (w *timerWheel) cancel(t *wheelTimer) bool {
+	if !t.scheduled {
+		return false
+	}
+	w.unlink(t)
+	w.pending--
+	w.cancelled++
+	return true
+}
+
+// advance move the wheel up to now and return the timers that came due, in due order
+func (w *timerWheel) advance(now time.Time) []*wheelTimer {
+
+	target := w.ticks(now)
+	var due []*wheelTimer
+
+	for w.current < target {
+		// Nothing to fire on the way, jump straight to now
+		if w.pending == 0 {
+			w.current = target
+			break
+		}
+
+		w.current++
+
+		// Cascade the higher levels first, so their timers land in the slots about to be cascaded below them
+		top := 0
+		for top < wheelLevels-1 && w.current&(1<<(wheelBits*uint(top+1))-1) == 0 {
+			top++
+		}
+		for level := top; level > 0; level-- {
+			w.cascade(level, int(w.current>>(wheelBits*uint(level)))&wheelMask)
+		}
+
+		slot := int(w.current) & wheelMask
+		for t := w.slots[0][slot]; t != nil; t = w.slots[0][slot] {
+			w.unlink(t)
+			if t.due > w.current {
+				w.place(t) // A lap ahead
+				continue
+			}
+			w.pending--
+			w.fired++
+			due = append(due, t)
+		}
+	}
+
+	return due
+}
+
+// cascade move the timers of a slot of level down to the levels matching their remaining time
+func (w *timerWheel) cascade(level, slot int) {
+	for t := w.slots[level][slot]; t != nil; t = w.slots[level][slot] {
+		w.unlink(t)
+		w.place(t)
+		w.cascades++
+	}
+}
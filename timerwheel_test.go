@@ -0,0 +1,76 @@
+package linear
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimerWheel(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	start := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	wheel := newTimerWheel(time.Millisecond, start)
+	delays := []uint64{1, 2, 63, 64, 65, 4095, 4096, 4097, 262143, 262145}
+	timers := map[*wheelTimer]uint64{}
+	for _, delay := range delays {
+		timer := &wheelTimer{}
+		wheel.schedule(timer, start.Add(time.Duration(delay)*time.Millisecond))
+		timers[timer] = delay
+	}
+	cancelled := &wheelTimer{}
+	wheel.schedule(cancelled, start.Add(100*time.Millisecond))
+
+	// Testing
+	assert.True(wheel.cancel(cancelled))
+	assert.False(wheel.cancel(cancelled))
+	assert.Equal(len(delays), wheel.pending)
+
+	fired := map[*wheelTimer]bool{}
+	for tick := uint64(1); tick <= 262145; tick++ {
+		for _, timer := range wheel.advance(start.Add(time.Duration(tick) * time.Millisecond)) {
+			if fired[timer] {
+				t.Errorf("advance failed, timer due at %v fired twice", timers[timer])
+			}
+			fired[timer] = true
+			if timers[timer] != tick {
+				t.Errorf("advance failed, expected %v, got %v", timers[timer], tick)
+			}
+		}
+		if wheel.pending == 0 {
+			break
+		}
+	}
+
+	assert.Len(fired, len(delays))
+	assert.Equal(int64(len(delays)), wheel.fired)
+	assert.Equal(int64(1), wheel.cancelled)
+}
+
+func TestTimerWheelLate(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	start := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	wheel := newTimerWheel(time.Millisecond, start)
+	first, second := &wheelTimer{key: "1"}, &wheelTimer{key: "2"}
+	wheel.schedule(second, start.Add(5000*time.Millisecond))
+	wheel.schedule(first, start.Add(1500*time.Microsecond))
+
+	// Testing
+	assert.Empty(wheel.advance(start.Add(time.Millisecond)))
+	assert.Equal([]*wheelTimer{first, second}, wheel.advance(start.Add(time.Hour)))
+	assert.Equal(0, wheel.pending)
+
+	third := &wheelTimer{key: "3"}
+	wheel.schedule(third, start)
+	assert.Equal([]*wheelTimer{third}, wheel.advance(start.Add(time.Hour+time.Millisecond)))
+
+	// Beyond the span of the wheel
+	fourth := &wheelTimer{key: "4"}
+	wheel.schedule(fourth, start.Add(6*time.Hour))
+	assert.Empty(wheel.advance(start.Add(6*time.Hour - time.Millisecond)))
+	assert.Equal([]*wheelTimer{fourth}, wheel.advance(start.Add(6*time.Hour)))
+}
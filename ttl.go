@@ -0,0 +1,321 @@
+package linear
+
+import (
+	"errors"
+	"sync/atomic"
+	"time"
+)
+
+// defaultWheelTick is the resolution of TTLs and delays when WithWheelTick isn't used
+const defaultWheelTick = 10 * time.Millisecond
+
+// WheelStats is a point in time copy of the timer wheel counters
+type WheelStats struct {
+	Tick      time.Duration
+	Pending   int   // Timers waiting to fire
+	Scheduled int64 // Timers added since the wheel started
+	Fired     int64 // Timers that came due
+	Cancelled int64 // Timers removed before they came due
+	Cascades  int64 // Timers moved down a level of the wheel
+}
+
+// NoTTL is the TTL GetTTL returns for keys that don't expire
+const NoTTL time.Duration = -1
+
+// ExpiryClock decide which clock TTLs and delays are measured against
+type ExpiryClock int
+
+const (
+	// Monotonic measure TTLs as elapsed time, clock jumps don't move the deadlines
+	Monotonic ExpiryClock = iota
+	// WallClock make deadlines absolute wall-clock times, so they follow clock jumps. Snapshots, WithPersistence and
+	// WithAppendLog keep them, so they stay valid across restarts, JSON and the binary encoding don't
+	WallClock
+)
+
+// WithExpiryClock set the clock TTLs and delays are measured against, Monotonic by default
+func WithExpiryClock(clock ExpiryClock) Option {
+	return func(l *Linear) {
+		l.expiryClock = clock
+	}
+}
+
+// WithDefaultTTL expire the pushed keys once ttl elapsed, 0 means they don't expire
+// It applies to every push, batched, delayed and conditional ones included, PushWithTTL replaces it
+func WithDefaultTTL(ttl time.Duration) Option {
+	return func(l *Linear) {
+		l.defaultTTL = ttl
+	}
+}
+
+// WithWheelTick set the resolution of TTLs and delays, expiries fire at most one tick late
+func WithWheelTick(tick time.Duration) Option {
+	return func(l *Linear) {
+		l.wheelTick = tick
+	}
+}
+
+// PushWithTTL push item to the linear with key and remove every occurrence of the key once ttl elapsed
+// Pushing the key with a TTL again replaces its expiry
+func (l *Linear) PushWithTTL(key string, value interface{}, ttl time.Duration) error {
+
+	// Argument validator
+	if ttl <= 0 {
+		return ErrInvalidArgument
+	}
+
+	if err := l.Push(key, value); err != nil {
+		return err
+	}
+
+	l.mux.Lock()
+	defer l.mux.Unlock()
+
+	// Taken or evicted already
+	if l.keys.contains(key) {
+		l.setExpiry(key, ttl)
+	}
+
+	return nil
+}
+
+// setExpiry remove every occurrence of the key once ttl elapsed, replacing its previous expiry, caller must hold mux
+func (l *Linear) setExpiry(key string, ttl time.Duration) {
+	l.expireAt(key, l.now().Add(ttl), ttl)
+}
+
+// expireAt remove every occurrence of the key at deadline, ttl is restored by sliding expiration, caller must hold mux
+func (l *Linear) expireAt(key string, deadline time.Time, ttl time.Duration) {
+
+	if previous, ok := l.expiries[key]; ok {
+		l.wheel.cancel(previous)
+	}
+
+	t := &wheelTimer{key: key, expire: true, at: deadline, ttl: ttl}
+	if l.expiries == nil {
+		l.expiries = map[string]*wheelTimer{}
+	}
+	l.expiries[key] = t
+	l.startWheel().schedule(t, deadline)
+
+	if l.expiryClock == WallClock {
+		l.logRecord(walRecord{Op: walExpire, Key: key, Deadline: deadline.UnixNano(), TTL: ttl})
+	}
+}
+
+// Touch push back the expiry of the key by extend, keys without TTL are left without it
+func (l *Linear) Touch(key string, extend time.Duration) error {
+
+	// Execution conditions
+	if l.IsClosed() {
+		return ErrClosed
+	}
+
+	// Argument validator
+	if extend <= 0 {
+		return ErrInvalidArgument
+	}
+
+	l.mux.Lock()
+	defer l.mux.Unlock()
+
+	if !l.keys.contains(key) {
+		return newError("touch", key, ErrKeyNotFound)
+	}
+
+	if t, ok := l.expiries[key]; ok {
+		l.expireAt(key, t.at.Add(extend), t.ttl)
+	}
+
+	return nil
+}
+
+// GetTTL return the time left before the key expires, NoTTL when it doesn't expire
+func (l *Linear) GetTTL(key string) (time.Duration, error) {
+
+	// Execution conditions
+	if l.IsClosed() {
+		return 0, ErrClosed
+	}
+
+	l.mux.RLock()
+	defer l.mux.RUnlock()
+
+	if !l.keys.contains(key) {
+		return 0, newError("ttl", key, ErrKeyNotFound)
+	}
+
+	t, ok := l.expiries[key]
+	if !ok {
+		return NoTTL, nil
+	}
+
+	if left := t.at.Sub(l.now()); left > 0 {
+		return left, nil
+	}
+	return 0, nil // Due, waiting for the next tick
+}
+
+// WithSlidingExpiration restart the TTL of a key every time Read returns it
+func WithSlidingExpiration() Option {
+	return func(l *Linear) {
+		l.slidingTTL = true
+	}
+}
+
+// slide restart the TTL of a key that was read when sliding expiration is on
+func (l *Linear) slide(key string) {
+
+	// Execution conditions
+	if !l.slidingTTL {
+		return
+	}
+
+	l.mux.Lock()
+	if t, ok := l.expiries[key]; ok && l.keys.contains(key) {
+		l.setExpiry(key, t.ttl)
+	}
+	l.mux.Unlock()
+}
+
+// PushWithDeadline push item to the linear with key and remove every occurrence of the key at deadline
+// With the Monotonic clock the deadline is turned into a TTL on call
+func (l *Linear) PushWithDeadline(key string, value interface{}, deadline time.Time) error {
+
+	ttl := deadline.Sub(l.now())
+	if ttl <= 0 {
+		ttl = 1 // Already passed, expire on the next tick
+	}
+
+	return l.PushWithTTL(key, value, ttl)
+}
+
+// PushDelayed push item to the linear with key once delay elapsed, until then the item isn't visible
+func (l *Linear) PushDelayed(key string, value interface{}, delay time.Duration) error {
+
+	// Execution conditions
+	if l.IsClosed() {
+		return ErrClosed
+	}
+
+	// Argument validator
+	if key == "" && value == nil {
+		return ErrInvalidKey
+	}
+
+	if delay <= 0 {
+		return ErrInvalidArgument
+	}
+
+	if l.clone != nil {
+		value = l.clone(value)
+	}
+
+	t := &wheelTimer{key: key, value: value, valueSize: l.valueSize(key, value)}
+
+	l.mux.Lock()
+	l.startWheel().schedule(t, l.now().Add(delay))
+	l.mux.Unlock()
+
+	return nil
+}
+
+// WheelStats return the counters of the timer wheel behind TTLs and delays
+func (l *Linear) WheelStats() WheelStats {
+
+	l.mux.RLock()
+	defer l.mux.RUnlock()
+
+	if l.wheel == nil {
+		return WheelStats{Tick: l.tickOrDefault()}
+	}
+
+	return WheelStats{
+		Tick:      l.wheel.tick,
+		Pending:   l.wheel.pending,
+		Scheduled: l.wheel.scheduled,
+		Fired:     l.wheel.fired,
+		Cancelled: l.wheel.cancelled,
+		Cascades:  l.wheel.cascades,
+	}
+}
+
+// now return the current time on the expiry clock, wall-clock times drop their monotonic reading
+func (l *Linear) now() time.Time {
+	if l.expiryClock == WallClock {
+		return time.Now().Round(0)
+	}
+	return time.Now()
+}
+
+// tickOrDefault return the configured wheel tick
+func (l *Linear) tickOrDefault() time.Duration {
+	if l.wheelTick > 0 {
+		return l.wheelTick
+	}
+	return defaultWheelTick
+}
+
+// startWheel create the timer wheel and its worker on first use, caller must hold mux
+func (l *Linear) startWheel() *timerWheel {
+
+	if l.wheel != nil {
+		return l.wheel
+	}
+
+	l.wheel = newTimerWheel(l.tickOrDefault(), l.now())
+	l.startWorkerLocked(func(done <-chan struct{}) {
+		ticker := time.NewTicker(l.wheel.tick)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				l.fireTimers(l.now())
+			}
+		}
+	})
+
+	return l.wheel
+}
+
+// fireTimers expire the keys and push the delayed items that came due at now
+func (l *Linear) fireTimers(now time.Time) {
+
+	var failed []error
+
+	l.mux.Lock()
+	for _, t := range l.wheel.advance(now) {
+		if !t.expire {
+			if err := l.push(t.key, t.value, t.valueSize); err != nil {
+				failed = append(failed, err)
+			}
+			continue
+		}
+
+		if l.expiries[t.key] != t {
+			continue
+		}
+		delete(l.expiries, t.key)
+		if l.deleteKey(t.key, Expired) {
+			atomic.AddInt64(&l.stats.expired, 1)
+		}
+	}
+	l.mux.Unlock()
+
+	for _, err := range failed {
+		if !errors.Is(err, ErrClosed) {
+			l.logger.Printf("linear: delayed push failed: %v", err)
+		}
+	}
+}
+
+// cancelExpiry drop the expiry of a key leaving the linear, caller must hold mux
+func (l *Linear) cancelExpiry(key string) {
+	if t, ok := l.expiries[key]; ok {
+		l.wheel.cancel(t)
+		delete(l.expiries, key)
+	}
+}
@@ -0,0 +1,249 @@
+package linear
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPushWithTTL(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	linearClient, _ := NewWithOptions(WithWheelTick(time.Millisecond))
+	defer linearClient.Close()
+
+	// Testing
+	assert.True(errors.Is(linearClient.PushWithTTL("1", "a", 0), ErrInvalidArgument))
+
+	assert.Nil(linearClient.PushWithTTL("1", "a", 20*time.Millisecond))
+	assert.Nil(linearClient.PushWithTTL("2", "b", time.Hour))
+	assert.Nil(linearClient.Push("3", "c"))
+
+	assert.Eventually(func() bool {
+		_, exits := linearClient.IsExits("1")
+		return !exits
+	}, time.Second, time.Millisecond)
+	assert.Equal([]string{"2", "3"}, linearClient.Getkeys())
+	assert.Equal(int64(1), linearClient.Stats().Expired)
+
+	// A key leaving the linear drops its expiry
+	assert.Nil(linearClient.Delete("2"))
+	stats := linearClient.WheelStats()
+	assert.Equal(0, stats.Pending)
+	assert.Equal(int64(1), stats.Cancelled)
+	assert.Equal(time.Millisecond, stats.Tick)
+}
+
+func TestPushDelayed(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	linearClient, _ := NewWithOptions(WithWheelTick(time.Millisecond))
+	defer linearClient.Close()
+
+	// Testing
+	assert.Nil(linearClient.PushDelayed("1", "a", 20*time.Millisecond))
+	assert.Nil(linearClient.Push("2", "b"))
+	assert.Equal([]string{"2"}, linearClient.Getkeys())
+
+	assert.Eventually(func() bool { return linearClient.GetNumberOfKeys() == 2 }, time.Second, time.Millisecond)
+	assert.Equal([]string{"2", "1"}, linearClient.Getkeys())
+	assert.Equal(int64(1), linearClient.WheelStats().Fired)
+
+	assert.Nil(linearClient.Close())
+	assert.True(errors.Is(linearClient.PushDelayed("1", "a", time.Millisecond), ErrClosed))
+}
+
+func TestDefaultTTLEveryPush(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	linearClient, _ := NewWithOptions(WithWheelTick(time.Millisecond), WithDefaultTTL(20*time.Millisecond))
+	defer linearClient.Close()
+
+	// Testing
+	assert.Nil(linearClient.PushAll(map[string]interface{}{"1": "a"}))
+	assert.Nil(linearClient.FromMap(map[string]interface{}{"2": "b"}, nil))
+	assert.Nil(linearClient.PushDelayed("3", "c", time.Millisecond))
+	_, _, err := linearClient.GetOrSet("4", "d")
+	assert.Nil(err)
+	assert.Nil(linearClient.PushBytes("5", []byte("e")))
+
+	assert.Eventually(func() bool { return linearClient.GetNumberOfKeys() == 5 }, time.Second, time.Millisecond)
+	assert.Eventually(func() bool { return linearClient.IsEmpty() }, time.Second, time.Millisecond)
+	assert.Equal(int64(5), linearClient.Stats().Expired)
+}
+
+func TestExpiryClock(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	_, err := NewWithOptions(WithExpiryClock(ExpiryClock(5)))
+	assert.True(errors.Is(err, ErrInvalidArgument))
+
+	for _, clock := range []ExpiryClock{Monotonic, WallClock} {
+		linearClient, _ := NewWithOptions(WithWheelTick(time.Millisecond), WithExpiryClock(clock))
+
+		// Testing
+		assert.Nil(linearClient.PushWithDeadline("1", "a", time.Now().Add(20*time.Millisecond)))
+		assert.Nil(linearClient.PushWithDeadline("2", "b", time.Now().Add(-time.Hour)))
+		assert.Nil(linearClient.PushWithDeadline("3", "c", time.Now().Add(time.Hour)))
+
+		assert.Eventually(func() bool { return linearClient.GetNumberOfKeys() == 1 }, time.Second, time.Millisecond)
+		assert.Equal([]string{"3"}, linearClient.Getkeys())
+		assert.Equal(int64(2), linearClient.Stats().Expired)
+
+		linearClient.Close()
+	}
+}
+
+func TestWallClockRestart(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	for _, clock := range []ExpiryClock{Monotonic, WallClock} {
+		path := filepath.Join(t.TempDir(), "linear.snapshot")
+		linearClient, err := Open(path, WithWheelTick(time.Millisecond), WithExpiryClock(clock))
+		assert.Nil(err)
+		assert.Nil(linearClient.PushWithTTL("1", "a", 50*time.Millisecond))
+		assert.Nil(linearClient.PushWithTTL("2", "b", time.Hour))
+		assert.Nil(linearClient.Close())
+
+		// Testing
+		reopened, err := Open(path, WithWheelTick(time.Millisecond), WithExpiryClock(clock))
+		assert.Nil(err)
+		ttl, _ := reopened.GetTTL("2")
+
+		// Only wall-clock deadlines survive the restart
+		if clock == Monotonic {
+			assert.Equal(NoTTL, ttl)
+			assert.Equal([]string{"1", "2"}, reopened.Getkeys())
+		} else {
+			if ttl <= 59*time.Minute || ttl > time.Hour {
+				t.Errorf("GetTTL failed, expected %v, got %v", time.Hour, ttl)
+			}
+			assert.Eventually(func() bool { return reopened.GetNumberOfKeys() == 1 }, time.Second, time.Millisecond)
+			assert.Equal([]string{"2"}, reopened.Getkeys())
+		}
+		reopened.Close()
+	}
+}
+
+func TestWallClockAppendLog(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	path := filepath.Join(t.TempDir(), "linear.log")
+	linearClient, err := NewWithOptions(WithAppendLog(path, 0), WithExpiryClock(WallClock))
+	assert.Nil(err)
+	defer linearClient.Close()
+	assert.Nil(linearClient.PushWithTTL("1", "a", time.Hour))
+	assert.Nil(linearClient.Push("2", "b"))
+
+	// Testing
+	// The records are replayed without a snapshot
+	replayed, err := NewWithOptions(WithAppendLog(path, 0), WithExpiryClock(WallClock))
+	assert.Nil(err)
+	defer replayed.Close()
+
+	ttl, _ := replayed.GetTTL("1")
+	if ttl <= 59*time.Minute || ttl > time.Hour {
+		t.Errorf("GetTTL failed, expected %v, got %v", time.Hour, ttl)
+	}
+	ttl, _ = replayed.GetTTL("2")
+	assert.Equal(NoTTL, ttl)
+}
+
+func TestTouch(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	linearClient, _ := NewWithOptions(WithWheelTick(time.Millisecond))
+	defer linearClient.Close()
+	linearClient.PushWithTTL("1", "a", time.Minute)
+	linearClient.Push("2", "b")
+
+	// Testing
+	ttl, err := linearClient.GetTTL("1")
+	assert.Nil(err)
+	assert.True(ttl > 59*time.Second && ttl <= time.Minute)
+
+	assert.Nil(linearClient.Touch("1", time.Hour))
+	ttl, _ = linearClient.GetTTL("1")
+	if ttl <= time.Hour {
+		t.Errorf("Touch failed, expected more than %v, got %v", time.Hour, ttl)
+	}
+
+	ttl, _ = linearClient.GetTTL("2")
+	assert.Equal(NoTTL, ttl)
+	assert.Nil(linearClient.Touch("2", time.Hour))
+	ttl, _ = linearClient.GetTTL("2")
+	assert.Equal(NoTTL, ttl)
+
+	_, err = linearClient.GetTTL("3")
+	assert.True(errors.Is(err, ErrKeyNotFound))
+	assert.True(errors.Is(linearClient.Touch("3", time.Second), ErrKeyNotFound))
+	assert.True(errors.Is(linearClient.Touch("1", 0), ErrInvalidArgument))
+}
+
+func TestSlidingExpiration(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	linearClient, _ := NewWithOptions(WithWheelTick(time.Millisecond), WithSlidingExpiration())
+	defer linearClient.Close()
+	linearClient.PushWithTTL("1", "a", 50*time.Millisecond)
+	linearClient.PushWithTTL("2", "b", 50*time.Millisecond)
+
+	// Testing
+	for i := 0; i < 5; i++ {
+		time.Sleep(20 * time.Millisecond)
+		_, err := linearClient.Read("1")
+		assert.Nil(err)
+	}
+
+	_, exits := linearClient.IsExits("1")
+	assert.True(exits)
+	_, exits = linearClient.IsExits("2")
+	assert.False(exits)
+}
+
+func TestUpdateWithOptions(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	linearClient, _ := NewWithOptions(WithWheelTick(time.Millisecond), WithMaxItems(2), WithEvictionPolicy(LRU()))
+	defer linearClient.Close()
+	linearClient.PushWithTTL("1", "a", time.Minute)
+	linearClient.PushWithTTL("2", "b", time.Minute)
+	linearClient.Touch("1", time.Hour)
+	linearClient.Touch("2", time.Hour)
+
+	// Testing
+	// Update keeps the deadline and counts as an access, so "1" is the least recently used
+	assert.Nil(linearClient.Update("2", "b1"))
+	ttl, _ := linearClient.GetTTL("2")
+	if ttl <= time.Hour {
+		t.Errorf("Update failed, expected more than %v, got %v", time.Hour, ttl)
+	}
+
+	// ResetTTL restarts the TTL from now, without Touch "1" stays the least recently used
+	assert.Nil(linearClient.UpdateWithOptions("1", "a1", UpdateOptions{ResetTTL: true}))
+	ttl, _ = linearClient.GetTTL("1")
+	if ttl > time.Minute {
+		t.Errorf("UpdateWithOptions failed, expected at most %v, got %v", time.Minute, ttl)
+	}
+	linearClient.Push("3", "c")
+	assert.Equal([]string{"2", "3"}, linearClient.Getkeys())
+
+	// Touch makes "2" the most recently used
+	assert.Nil(linearClient.UpdateWithOptions("2", "b2", UpdateOptions{Touch: true}))
+	linearClient.Push("4", "d")
+	assert.Equal([]string{"2", "4"}, linearClient.Getkeys())
+
+	assert.True(errors.Is(linearClient.UpdateWithOptions("5", "e", UpdateOptions{}), ErrKeyNotFound))
+}
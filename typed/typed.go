@@ -0,0 +1,309 @@
+// Package typed provides a generic linear with compile-time typed keys and values
+package typed
+
+import (
+	"log"
+	"sync"
+	"unsafe"
+
+	"github.com/golang-common-packages/linear"
+)
+
+// node hold one occurrence of a key in the keys list
+type node[K comparable] struct {
+	key  K
+	prev *node[K]
+	next *node[K]
+}
+
+// Linear contains all the private properties
+type Linear[K comparable, V any] struct {
+	items             map[K]V
+	index             map[K][]*node[K]
+	head              *node[K]
+	tail              *node[K]
+	numberOfKeys      int
+	sizeChecker       bool
+	linearSizes       int64 // bytes
+	linearCurrentSize int64 // bytes
+	mux               sync.RWMutex
+}
+
+// New return new linear instance, it exits the process on invalid arguments, use NewWithError to handle them
+func New[K comparable, V any](maxSize int64, sizeChecker bool) *Linear[K, V] {
+
+	currentLinear, err := NewWithError[K, V](maxSize, sizeChecker)
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	return currentLinear
+}
+
+// NewWithError return new linear instance or linear.ErrInvalidSize when maxSize is not higher than 0
+func NewWithError[K comparable, V any](maxSize int64, sizeChecker bool) (*Linear[K, V], error) {
+
+	// Argument validator
+	if maxSize <= 0 {
+		return nil, linear.ErrInvalidSize
+	}
+
+	return &Linear[K, V]{
+		items:       map[K]V{},
+		index:       map[K][]*node[K]{},
+		sizeChecker: sizeChecker,
+		linearSizes: maxSize,
+	}, nil
+}
+
+// Push item to the linear with key
+// Pushing a key that already exits keeps the stored value and adds the key once more
+func (l *Linear[K, V]) Push(key K, value V) error {
+
+	itemSize := int64(unsafe.Sizeof(key)) + int64(unsafe.Sizeof(value))
+	if itemSize > l.linearSizes {
+		return linear.ErrCapacityExceeded
+	}
+
+	l.mux.Lock()
+	defer l.mux.Unlock()
+
+	// Clean space for new item
+	if l.sizeChecker {
+		for l.linearCurrentSize+itemSize > l.linearSizes && l.head != nil {
+			l.remove(l.head)
+		}
+	}
+
+	if _, exits := l.items[key]; !exits {
+		l.items[key] = value
+	}
+
+	n := &node[K]{key: key, prev: l.tail}
+	if l.tail != nil {
+		l.tail.next = n
+	} else {
+		l.head = n
+	}
+	l.tail = n
+	l.index[key] = append(l.index[key], n)
+	l.numberOfKeys++
+	l.linearCurrentSize += itemSize
+
+	return nil
+}
+
+// Pop return and remove the last item out of the linear
+func (l *Linear[K, V]) Pop() (V, error) {
+
+	l.mux.Lock()
+	defer l.mux.Unlock()
+
+	// Execution conditions
+	if l.tail == nil {
+		var zero V
+		return zero, linear.ErrEmpty
+	}
+
+	return l.remove(l.tail), nil
+}
+
+// Take return and remove the first item out of the linear
+func (l *Linear[K, V]) Take() (V, error) {
+
+	l.mux.Lock()
+	defer l.mux.Unlock()
+
+	// Execution conditions
+	if l.head == nil {
+		var zero V
+		return zero, linear.ErrEmpty
+	}
+
+	return l.remove(l.head), nil
+}
+
+// Get method return and remove the item by key out of the linear
+func (l *Linear[K, V]) Get(key K) (V, error) {
+
+	l.mux.Lock()
+	defer l.mux.Unlock()
+
+	// Execution conditions
+	var zero V
+	if l.head == nil {
+		return zero, linear.ErrEmpty
+	}
+
+	occurrences := l.index[key]
+	if len(occurrences) == 0 {
+		return zero, linear.ErrKeyNotFound
+	}
+
+	return l.remove(occurrences[0]), nil
+}
+
+// Read method return the item by key from linear without remove it
+func (l *Linear[K, V]) Read(key K) (V, error) {
+
+	l.mux.RLock()
+	defer l.mux.RUnlock()
+
+	// Execution conditions
+	if l.head == nil {
+		var zero V
+		return zero, linear.ErrEmpty
+	}
+
+	value, exits := l.items[key]
+	if !exits {
+		return value, linear.ErrKeyNotFound
+	}
+
+	return value, nil
+}
+
+// Update reassign value to the key
+func (l *Linear[K, V]) Update(key K, value V) error {
+
+	l.mux.Lock()
+	defer l.mux.Unlock()
+
+	// Execution conditions
+	if l.head == nil {
+		return linear.ErrEmpty
+	}
+
+	if _, exits := l.items[key]; !exits {
+		return linear.ErrKeyNotFound
+	}
+
+	l.items[key] = value
+
+	return nil
+}
+
+// Range call fn for every key and value from front to back until fn returns false
+func (l *Linear[K, V]) Range(fn func(key K, value V) bool) {
+
+	l.mux.RLock()
+	defer l.mux.RUnlock()
+
+	for n := l.head; n != nil; n = n.next {
+		if !fn(n.key, l.items[n.key]) {
+			return
+		}
+	}
+}
+
+// IsExits check key exits or not and return size and status
+func (l *Linear[K, V]) IsExits(key K) (int64, bool) {
+
+	l.mux.RLock()
+	defer l.mux.RUnlock()
+
+	value, exits := l.items[key]
+	if !exits {
+		return 0, false
+	}
+
+	return int64(unsafe.Sizeof(key)) + int64(unsafe.Sizeof(value)), true
+}
+
+// IsEmpty check linear size
+func (l *Linear[K, V]) IsEmpty() bool {
+	return l.GetNumberOfKeys() == 0
+}
+
+// Getkeys return the list of key
+func (l *Linear[K, V]) Getkeys() []K {
+
+	l.mux.RLock()
+	defer l.mux.RUnlock()
+
+	keys := make([]K, 0, l.numberOfKeys)
+	for n := l.head; n != nil; n = n.next {
+		keys = append(keys, n.key)
+	}
+
+	return keys
+}
+
+// GetNumberOfKeys return the number of keys
+func (l *Linear[K, V]) GetNumberOfKeys() int {
+
+	l.mux.RLock()
+	defer l.mux.RUnlock()
+
+	return l.numberOfKeys
+}
+
+// GetLinearSizes return the linear size
+func (l *Linear[K, V]) GetLinearSizes() int64 {
+
+	l.mux.RLock()
+	defer l.mux.RUnlock()
+
+	return l.linearSizes
+}
+
+// SetLinearSizes change the linear size with new value
+func (l *Linear[K, V]) SetLinearSizes(linearSizes int64) error {
+
+	// Argument validator
+	if linearSizes <= 0 {
+		return linear.ErrInvalidSize
+	}
+
+	l.mux.Lock()
+	l.linearSizes = linearSizes
+	l.mux.Unlock()
+
+	return nil
+}
+
+// GetLinearCurrentSize return the current linear size
+func (l *Linear[K, V]) GetLinearCurrentSize() int64 {
+
+	l.mux.RLock()
+	defer l.mux.RUnlock()
+
+	return l.linearCurrentSize
+}
+
+// remove unlink n and delete its item once no other occurrence of the key is left, caller must hold mux
+func (l *Linear[K, V]) remove(n *node[K]) V {
+
+	if n.prev != nil {
+		n.prev.next = n.next
+	} else {
+		l.head = n.next
+	}
+
+	if n.next != nil {
+		n.next.prev = n.prev
+	} else {
+		l.tail = n.prev
+	}
+
+	occurrences := l.index[n.key]
+	for i := range occurrences {
+		if occurrences[i] == n {
+			occurrences = append(occurrences[:i], occurrences[i+1:]...)
+			break
+		}
+	}
+
+	value := l.items[n.key]
+	if len(occurrences) == 0 {
+		delete(l.index, n.key)
+		delete(l.items, n.key)
+	} else {
+		l.index[n.key] = occurrences
+	}
+
+	l.numberOfKeys--
+	l.linearCurrentSize -= int64(unsafe.Sizeof(n.key)) + int64(unsafe.Sizeof(value))
+
+	return value
+}
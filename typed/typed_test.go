@@ -0,0 +1,116 @@
+package typed
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang-common-packages/linear"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPush(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		key      int
+		value    string
+		expected error
+	}{
+		{1, "a", nil},
+		{2, "b", nil},
+		{3, "", nil},
+		{4, "c", nil},
+		{5, "d", nil},
+	}
+
+	linearClient := New[int, string](1024, false)
+
+	for _, test := range tests {
+		assert.Equal(linearClient.Push(test.key, test.value), test.expected)
+	}
+
+	assert.Equal(linearClient.GetNumberOfKeys(), 5)
+	assert.Equal(linearClient.Getkeys(), []int{1, 2, 3, 4, 5})
+}
+
+func TestPopTakeGet(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	linearClient := New[string, int](1024, false)
+	linearClient.Push("1", 1)
+	linearClient.Push("2", 2)
+	linearClient.Push("3", 3)
+
+	// Testing
+	value, err := linearClient.Pop()
+	assert.Nil(err)
+	assert.Equal(value, 3)
+
+	value, err = linearClient.Take()
+	assert.Nil(err)
+	assert.Equal(value, 1)
+
+	value, err = linearClient.Get("2")
+	assert.Nil(err)
+	assert.Equal(value, 2)
+
+	_, err = linearClient.Pop()
+	assert.NotNil(err)
+	assert.Equal(linearClient.GetLinearCurrentSize(), int64(0))
+}
+
+func TestReadUpdate(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	linearClient := New[string, []byte](1024, false)
+	linearClient.Push("1", []byte("a"))
+
+	// Testing
+	assert.Nil(linearClient.Update("1", []byte("b")))
+	assert.NotNil(linearClient.Update("2", []byte("c")))
+
+	value, err := linearClient.Read("1")
+	assert.Nil(err)
+	assert.Equal(value, []byte("b"))
+
+	var keys []string
+	linearClient.Range(func(key string, value []byte) bool {
+		keys = append(keys, key)
+		return true
+	})
+	assert.Equal(keys, []string{"1"})
+}
+
+func TestSizeChecker(t *testing.T) {
+	assert := assert.New(t)
+
+	// int key and int value take 16 bytes
+	linearClient := New[int, int](32, true)
+	linearClient.Push(1, 1)
+	linearClient.Push(2, 2)
+	linearClient.Push(3, 3)
+
+	// Testing
+	assert.Equal(linearClient.Getkeys(), []int{2, 3})
+	assert.Equal(linearClient.GetLinearCurrentSize(), int64(32))
+}
+
+func TestNewWithError(t *testing.T) {
+	assert := assert.New(t)
+
+	// Testing
+	_, err := NewWithError[string, string](0, false)
+	assert.True(errors.Is(err, linear.ErrInvalidSize))
+}
+
+func BenchmarkPush(b *testing.B) {
+
+	linearClient := New[int, int](1000000, true)
+
+	// Run the Push method b.N times
+	for n := 0; n < b.N; n++ {
+		linearClient.Push(n, n)
+	}
+}
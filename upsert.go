@@ -0,0 +1,89 @@
+package linear
+
+import "sync/atomic"
+
+// PositionPolicy decide where Upsert places the key in the linear
+type PositionPolicy int
+
+const (
+	// KeepPosition leave an existing key where it is, a new key is pushed to the back
+	KeepPosition PositionPolicy = iota
+	// MoveToBack place the key at the back of the linear
+	MoveToBack
+	// MoveToFront place the key at the front of the linear
+	MoveToFront
+)
+
+// Upsert push the item when the key doesn't exit, otherwise update its value, then place the key following the policy
+func (l *Linear) Upsert(key string, value interface{}, policy PositionPolicy) error {
+	_, err := l.UpsertEvicted(key, value, policy)
+	return err
+}
+
+// UpsertEvicted upsert the item like Upsert and report if pushing it evicted another item
+// The existence check, the update or the push and the move happen under a single lock
+func (l *Linear) UpsertEvicted(key string, value interface{}, policy PositionPolicy) (bool, error) {
+
+	// Execution conditions
+	if l.IsClosed() {
+		return false, ErrClosed
+	}
+
+	// Argument validator
+	if policy < KeepPosition || policy > MoveToFront {
+		return false, ErrInvalidArgument
+	}
+
+	if key == "" && value == nil {
+		return false, ErrInvalidKey
+	}
+
+	if l.clone != nil {
+		value = l.clone(value)
+	}
+
+	valueSize := l.valueSize(key, value)
+
+	evicted, updated := false, false
+	ctx, cancel := l.blockContext()
+	defer cancel()
+	err := l.withRoom(ctx, lockUpdate, func() error {
+		if _, exits := l.items.Load(key); exits {
+			if calculateKeySize(key)+valueSize > l.linearSizes {
+				return newError("update", key, ErrCapacityExceeded)
+			}
+			if err := l.update(key, value, valueSize); err != nil {
+				return err
+			}
+			updated = true
+		} else {
+			// Evictions happen under mux, so the ones counted while holding it are made by this push
+			evictions := atomic.LoadInt64(&l.stats.evictions)
+			err := l.push(key, value, valueSize)
+			evicted = evicted || atomic.LoadInt64(&l.stats.evictions) > evictions
+			if err != nil {
+				return err
+			}
+		}
+
+		if policy == KeepPosition {
+			return nil
+		}
+
+		if n := l.keys.first(key); n != nil {
+			if policy == MoveToBack {
+				l.keys.moveToBack(n)
+			} else {
+				l.keys.moveToFront(n)
+			}
+			l.logRecord(walRecord{Op: walMove, Key: key, Front: policy == MoveToFront})
+		}
+		return nil
+	})
+
+	if updated {
+		atomic.AddInt64(&l.stats.updates, 1)
+	}
+
+	return evicted, err
+}
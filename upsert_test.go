@@ -0,0 +1,120 @@
+package linear
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpsert(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	datas := []struct {
+		key   string
+		value string
+	}{
+		{"1", "a"},
+		{"2", "b"},
+		{"3", "c"},
+	}
+
+	linearClient := New(1024, false)
+
+	for _, data := range datas {
+		linearClient.Push(data.key, data.value)
+	}
+
+	// Testing
+	tests := []struct {
+		key      string
+		value    string
+		policy   PositionPolicy
+		expected []string
+	}{
+		{"2", "b2", KeepPosition, []string{"1", "2", "3"}},
+		{"1", "a2", MoveToBack, []string{"2", "3", "1"}},
+		{"3", "c2", MoveToFront, []string{"3", "2", "1"}},
+		{"4", "d", KeepPosition, []string{"3", "2", "1", "4"}},
+		{"5", "e", MoveToFront, []string{"5", "3", "2", "1", "4"}},
+	}
+
+	for _, test := range tests {
+		if err := linearClient.Upsert(test.key, test.value, test.policy); err != nil {
+			t.Errorf("Upsert failed, expected %v, got %v", nil, err)
+		}
+
+		value, _ := linearClient.Read(test.key)
+		assert.Equal(value, test.value)
+		assert.Equal(linearClient.Getkeys(), test.expected)
+	}
+
+	assert.NotNil(linearClient.Upsert("6", "f", PositionPolicy(9)))
+	assert.Nil(linearClient.CheckSize())
+}
+
+func TestUpsertEvicted(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	linearClient, _ := NewWithOptions(WithMaxItems(2))
+
+	// Testing
+	evicted, err := linearClient.UpsertEvicted("1", "a", MoveToBack)
+	assert.Nil(err)
+	assert.False(evicted)
+	linearClient.Push("2", "b")
+
+	evicted, _ = linearClient.UpsertEvicted("2", "b2", MoveToBack)
+	assert.False(evicted)
+
+	evicted, err = linearClient.UpsertEvicted("3", "c", MoveToBack)
+	assert.Nil(err)
+	if !evicted {
+		t.Errorf("UpsertEvicted failed, expected %v, got %v", true, evicted)
+	}
+	assert.Equal([]string{"2", "3"}, linearClient.Getkeys())
+}
+
+func TestUpsertConcurrent(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	linearClient := New(1<<20, false)
+
+	// Testing
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			if err := linearClient.Upsert("k", i, MoveToBack); err != nil {
+				t.Errorf("Upsert failed, expected %v, got %v", nil, err)
+			}
+		}(i)
+		go func() {
+			defer wg.Done()
+			if err := linearClient.Delete("k"); err != nil && !errors.Is(err, ErrKeyNotFound) {
+				t.Errorf("Delete failed, expected %v, got %v", ErrKeyNotFound, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.True(linearClient.GetNumberOfKeys() <= 1)
+	linearClient.Delete("k")
+
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			linearClient.Upsert("k", i, KeepPosition)
+		}(i)
+	}
+	wg.Wait()
+
+	assert.Equal([]string{"k"}, linearClient.Getkeys())
+	assert.Nil(linearClient.CheckSize())
+}
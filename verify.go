@@ -0,0 +1,177 @@
+package linear
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// Issue is a broken invariant found by Verify
+type Issue struct {
+	Problem    string
+	Repairable bool // Fixed by recomputing derived state or by dropping torn append log records on open
+}
+
+// String return the problem and whether it is repairable
+func (i Issue) String() string {
+	if i.Repairable {
+		return i.Problem + " (repairable)"
+	}
+	return i.Problem
+}
+
+// Verify check the keys, items and size invariants of the linear and return the issues found
+func (l *Linear) Verify() []Issue {
+
+	l.mux.RLock()
+	defer l.mux.RUnlock()
+
+	var issues []Issue
+	report := func(repairable bool, format string, args ...interface{}) {
+		issues = append(issues, Issue{Problem: fmt.Sprintf(format, args...), Repairable: repairable})
+	}
+
+	// Keys list against its index
+	seen := map[string][]*node{}
+	count := 0
+	var prev *node
+	for n := l.keys.head; n != nil; n = n.next {
+		if n.prev != prev {
+			report(false, "key %q has a broken back link", n.key)
+		}
+		seen[n.key] = append(seen[n.key], n)
+		prev = n
+		count++
+	}
+
+	if prev != l.keys.tail {
+		report(false, "keys tail doesn't match the last key")
+	}
+
+	if count != l.keys.len {
+		report(true, "keys length is %d, the list holds %d keys", l.keys.len, count)
+	}
+
+	for key, occurrences := range l.keys.index {
+		if !sameNodes(occurrences, seen[key]) {
+			report(true, "key %q index doesn't match its occurrences in the list", key)
+		}
+	}
+
+	for key := range seen {
+		if _, ok := l.keys.index[key]; !ok {
+			report(true, "key %q is in the list but not in the index", key)
+		}
+	}
+
+	// Keys against items
+	for key := range seen {
+		if _, ok := l.items.Load(key); !ok {
+			report(false, "key %q has no item", key)
+		}
+		if _, ok := l.valueSizes[key]; !ok {
+			report(true, "key %q has no value size", key)
+		}
+	}
+
+	l.items.Range(func(key, value interface{}) bool {
+		if _, ok := seen[key.(string)]; !ok {
+			report(true, "item %q is not in the keys", key)
+		}
+		return true
+	})
+
+	// References
+	for key := range l.refs {
+		if _, ok := seen[key]; !ok {
+			report(true, "content references of %q outlive the key", key)
+		}
+	}
+
+	groups := map[*int]int{}
+	for key, group := range l.shared {
+		if _, ok := seen[key]; !ok {
+			report(true, "alias group of %q outlives the key", key)
+		}
+		groups[group]++
+	}
+	for group, keys := range groups {
+		if *group != keys {
+			report(true, "alias group counts %d keys, %d keys share it", *group, keys)
+		}
+	}
+
+	// Sizes
+	if computed := l.computeSize(); computed != l.linearCurrentSize {
+		report(true, "tracked size is %d bytes, the items take %d bytes", l.linearCurrentSize, computed)
+	}
+
+	return issues
+}
+
+// sameNodes check if a and b hold the same nodes in the same order
+func sameNodes(a, b []*node) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// VerifyFile check a snapshot file or an append log and its snapshot without changing them and return the issues found
+// The loaded state is verified as well, the error reports files that can't be read
+// opts configure the linear the files are loaded in, such as WithCodec for files written with a codec
+func VerifyFile(path string, opts ...Option) ([]Issue, error) {
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	l, err := NewWithOptions(opts...)
+	if err != nil {
+		return nil, err
+	}
+	defer l.Close()
+
+	var issues []Issue
+	if bytes.HasPrefix(data, snapshotMagic) {
+		if err := l.Restore(bytes.NewReader(data)); err != nil {
+			return []Issue{{Problem: fmt.Sprintf("snapshot %s: %v", path, err)}}, nil
+		}
+		return l.Verify(), nil
+	}
+
+	snapshot, err := os.Open(path + ".snapshot")
+	if err == nil {
+		err = l.Restore(snapshot)
+		snapshot.Close()
+		if err != nil {
+			return []Issue{{Problem: fmt.Sprintf("snapshot %s.snapshot: %v", path, err)}}, nil
+		}
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	l.mux.Lock()
+	valid, err := l.replay(bytes.NewReader(data), int64(len(data)))
+	l.mux.Unlock()
+
+	if err != nil {
+		return append(issues, Issue{Problem: fmt.Sprintf("append log %s: %v", path, err)}), nil
+	}
+
+	if valid != int64(len(data)) {
+		issues = append(issues, Issue{
+			Problem:    fmt.Sprintf("append log %s has %d bytes of torn or corrupted records after offset %d", path, int64(len(data))-valid, valid),
+			Repairable: true,
+		})
+	}
+
+	return append(issues, l.Verify()...), nil
+}
@@ -0,0 +1,78 @@
+package linear
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerify(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	linearClient := New(1024, false)
+	linearClient.Push("1", "a")
+	linearClient.Push("2", "b")
+	linearClient.Push("1", "a")
+	linearClient.Alias("3", "2")
+
+	// Testing
+	assert.Empty(linearClient.Verify())
+
+	linearClient.linearCurrentSize++
+	linearClient.items.Store("4", "d")
+	issues := linearClient.Verify()
+	assert.Len(issues, 2)
+	for _, issue := range issues {
+		assert.True(issue.Repairable, issue.Problem)
+	}
+
+	linearClient.items.Delete("2")
+	assert.Len(linearClient.Verify(), 3)
+}
+
+func TestVerifyFile(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	dir := t.TempDir()
+	snapshotPath := filepath.Join(dir, "linear.snapshot")
+	logPath := filepath.Join(dir, "linear.wal")
+
+	linearClient, _ := Open(snapshotPath, WithAppendLog(logPath, 0))
+	linearClient.Push("1", "a")
+	linearClient.Push("2", "b")
+	assert.Nil(linearClient.Close())
+
+	logged, _ := NewWithOptions(WithAppendLog(logPath, 0))
+	logged.Take()
+
+	// Testing
+	issues, err := VerifyFile(snapshotPath)
+	assert.Nil(err)
+	assert.Empty(issues)
+
+	issues, err = VerifyFile(logPath)
+	assert.Nil(err)
+	assert.Empty(issues)
+
+	f, _ := os.OpenFile(logPath, os.O_WRONLY|os.O_APPEND, 0)
+	f.Write([]byte{1, 2, 3})
+	f.Close()
+
+	issues, _ = VerifyFile(logPath)
+	assert.Len(issues, 1)
+	assert.True(issues[0].Repairable)
+
+	data, _ := os.ReadFile(snapshotPath)
+	data[len(data)-1] ^= 0xff
+	os.WriteFile(snapshotPath, data, 0o600)
+	issues, _ = VerifyFile(snapshotPath)
+	assert.Len(issues, 1)
+	assert.False(issues[0].Repairable)
+
+	_, err = VerifyFile(filepath.Join(dir, "missing"))
+	assert.NotNil(err)
+}
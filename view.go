@@ -0,0 +1,57 @@
+package linear
+
+// ReadOnlyView is a copy of the keys and items of a linear at one point in time
+// Later changes of the linear don't reach it, the values are shared so they must not be modified
+type ReadOnlyView struct {
+	keys   []string
+	values map[string]interface{}
+}
+
+// View return a consistent read-only view of the linear
+func (l *Linear) View() (*ReadOnlyView, error) {
+
+	// Execution conditions
+	if l.IsClosed() {
+		return nil, ErrClosed
+	}
+
+	l.mux.RLock()
+	defer l.mux.RUnlock()
+
+	view := ReadOnlyView{keys: l.keys.slice(), values: make(map[string]interface{}, len(l.keys.index))}
+	for key := range l.keys.index {
+		view.values[key], _ = l.loadItem(key)
+	}
+
+	return &view, nil
+}
+
+// Read return the item by key
+func (v *ReadOnlyView) Read(key string) (interface{}, error) {
+
+	value, ok := v.values[key]
+	if !ok {
+		return nil, newError("read", key, ErrKeyNotFound)
+	}
+
+	return value, nil
+}
+
+// Range call fn for every item from front to back until fn returns false
+func (v *ReadOnlyView) Range(fn func(key string, value interface{}) bool) {
+	for _, key := range v.keys {
+		if !fn(key, v.values[key]) {
+			return
+		}
+	}
+}
+
+// Keys return a copy of the list of key from front to back
+func (v *ReadOnlyView) Keys() []string {
+	return append([]string(nil), v.keys...)
+}
+
+// Len return the number of keys
+func (v *ReadOnlyView) Len() int {
+	return len(v.keys)
+}
@@ -0,0 +1,44 @@
+package linear
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestView(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	linearClient := New(1024, false)
+	linearClient.Push("1", "a")
+	linearClient.Push("2", "b")
+
+	// Testing
+	view, err := linearClient.View()
+	assert.Nil(err)
+
+	linearClient.Take()
+	linearClient.Push("3", "c")
+	linearClient.Update("2", "B")
+
+	assert.Equal([]string{"1", "2"}, view.Keys())
+	assert.Equal(2, view.Len())
+
+	value, err := view.Read("2")
+	assert.Nil(err)
+	if value != "b" {
+		t.Errorf("View failed, expected %v, got %v", "b", value)
+	}
+
+	_, err = view.Read("3")
+	assert.True(errors.Is(err, ErrKeyNotFound))
+
+	var keys []string
+	view.Range(func(key string, value interface{}) bool {
+		keys = append(keys, key)
+		return false
+	})
+	assert.Equal([]string{"1"}, keys)
+}
@@ -0,0 +1,65 @@
+package linear
+
+import (
+	"context"
+	"errors"
+)
+
+// PopWait return and remove the last item out of the linear, waiting until one is pushed or ctx is done
+func (l *Linear) PopWait(ctx context.Context) (interface{}, error) {
+	return l.wait(ctx, l.Pop)
+}
+
+// TakeWait return and remove the first item out of the linear, waiting until one is pushed or ctx is done
+func (l *Linear) TakeWait(ctx context.Context) (interface{}, error) {
+	return l.wait(ctx, l.Take)
+}
+
+// TakeEntryWait return and remove the first item out of the linear with its key, waiting until one is pushed or ctx is done
+func (l *Linear) TakeEntryWait(ctx context.Context) (Entry, error) {
+	entry, err := l.wait(ctx, func() (interface{}, error) { return l.TakeEntry() })
+	if err != nil {
+		return Entry{}, err
+	}
+	return entry.(Entry), nil
+}
+
+// wait retry remove every time an item is pushed until it succeeds, ctx is done or the linear is closed
+func (l *Linear) wait(ctx context.Context, remove func() (interface{}, error)) (interface{}, error) {
+
+	for {
+		// Subscribe before trying, so a push in between still wakes us up
+		l.mux.Lock()
+		pushed := l.pushedChan()
+		l.mux.Unlock()
+
+		item, err := remove()
+		if !errors.Is(err, ErrEmpty) {
+			return item, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-l.done:
+			return nil, ErrClosed
+		case <-pushed:
+		}
+	}
+}
+
+// pushedChan return a channel closed on the next push, caller must hold mux
+func (l *Linear) pushedChan() chan struct{} {
+	if l.pushed == nil {
+		l.pushed = make(chan struct{})
+	}
+	return l.pushed
+}
+
+// notifyPushed wake up the waiters, caller must hold mux
+func (l *Linear) notifyPushed() {
+	if l.pushed != nil {
+		close(l.pushed)
+		l.pushed = nil
+	}
+}
@@ -0,0 +1,56 @@
+package linear
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTakeWait(t *testing.T) {
+	assert := assert.New(t)
+
+	// Setting up
+	linearClient := New(1024, false)
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		linearClient.Push("1", "a")
+		linearClient.Push("2", "b")
+	}()
+
+	// Testing
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	value, err := linearClient.TakeWait(ctx)
+	if err != nil {
+		t.Errorf("TakeWait failed, expected %v, got %v", "a", err)
+	}
+
+	assert.Equal(value, "a")
+
+	value, err = linearClient.PopWait(ctx)
+	if err != nil {
+		t.Errorf("PopWait failed, expected %v, got %v", "b", err)
+	}
+
+	assert.Equal(value, "b")
+}
+
+func TestPopWaitCancel(t *testing.T) {
+	assert := assert.New(t)
+
+	linearClient := New(1024, false)
+
+	// Testing
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := linearClient.PopWait(ctx)
+	assert.Equal(err, context.DeadlineExceeded)
+
+	go linearClient.Close()
+	_, err = linearClient.PopWait(context.Background())
+	assert.NotNil(err)
+}